@@ -8,6 +8,7 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 
 	"github.com/criticalstack/crit/pkg/cluster/bootstrap"
+	"github.com/criticalstack/crit/pkg/log/echolog"
 )
 
 type bootstrapConfig struct {
@@ -18,19 +19,18 @@ type bootstrapConfig struct {
 
 type bootstrapRouter struct {
 	*echo.Echo
-	cfg *bootstrapConfig
+	cfg     *bootstrapConfig
+	labeler *nodeLabeler
 }
 
-func newBootstrapRouter(cfg *bootstrapConfig) *bootstrapRouter {
+func newBootstrapRouter(cfg *bootstrapConfig, labeler *nodeLabeler) *bootstrapRouter {
 	r := &bootstrapRouter{
-		Echo: echo.New(),
-		cfg:  cfg,
+		Echo:    echo.New(),
+		cfg:     cfg,
+		labeler: labeler,
 	}
-	r.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-		Skipper: func(c echo.Context) bool {
-			return c.Path() == "/healthz"
-		},
-		Format: "method=${method}, uri=${uri}, status=${status}\n",
+	r.Use(echolog.Logger(func(c echo.Context) bool {
+		return c.Path() == "/healthz"
 	}))
 	r.Use(middleware.Recover())
 	r.Use(middleware.BodyLimit("2M"))
@@ -52,9 +52,14 @@ func newBootstrapRouter(cfg *bootstrapConfig) *bootstrapRouter {
 				"error": err.Error(),
 			})
 		}
+		if err := r.labeler.Validate(auth.Labels, auth.Annotations); err != nil {
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		switch auth.Type {
 		case bootstrap.AmazonIdentityDocumentAndSignature:
-			return r.handleAmazonIdentityDocumentAndSignature(auth.Body)(c)
+			return r.handleAmazonIdentityDocumentAndSignature(&auth)(c)
 		default:
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": fmt.Sprintf("unknown auth type: %q", auth.Type)},
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -15,10 +16,10 @@ import (
 	"github.com/criticalstack/crit/pkg/cluster/bootstrap/authorizers/ec2metadata"
 )
 
-func (r *bootstrapRouter) handleAmazonIdentityDocumentAndSignature(data []byte) echo.HandlerFunc {
+func (r *bootstrapRouter) handleAmazonIdentityDocumentAndSignature(auth *bootstrap.Request) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var sdoc ec2metadata.SignedDocument
-		if err := json.Unmarshal(data, &sdoc); err != nil {
+		if err := json.Unmarshal(auth.Body, &sdoc); err != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 		}
 		if err := ec2metadata.Verify(sdoc.Document, sdoc.Signature); err != nil {
@@ -65,6 +66,10 @@ func (r *bootstrapRouter) handleAmazonIdentityDocumentAndSignature(data []byte)
 			})
 		}
 
+		// the requested labels are applied only to the node registered with
+		// the bootstrap token issued to this instance
+		r.labeler.Add(strings.SplitN(token, ".", 2)[0], auth.Labels, auth.Annotations)
+
 		return c.JSON(http.StatusOK, &bootstrap.Response{
 			BootstrapToken: token,
 		})
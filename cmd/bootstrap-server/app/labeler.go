@@ -0,0 +1,218 @@
+package app
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+
+	nodeutil "github.com/criticalstack/crit/pkg/kubernetes/util/node"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+const (
+	// pendingNodeTTL is how long the labels requested by an authorized node
+	// are kept while waiting for the node to register. This is longer than
+	// the expiration of the bootstrap token created for the node.
+	pendingNodeTTL = 30 * time.Minute
+
+	labelNodesInterval = 5 * time.Second
+)
+
+// deniedNodeLabels are the labels that a worker node can never request,
+// regardless of the allowed labels.
+var deniedNodeLabels = []string{
+	"node-role.kubernetes.io/master",
+	"node-role.kubernetes.io/control-plane",
+}
+
+type pendingNode struct {
+	labels      map[string]string
+	annotations map[string]string
+	expires     time.Time
+}
+
+// nodeLabeler applies the restricted labels and annotations requested by
+// authorized nodes once they have registered. The kubelet is not allowed to
+// set restricted labels on its own Node, so these are applied using the
+// bootstrap-server credentials instead.
+//
+// The Node is identified by the bootstrap token issued to the authorized
+// node, rather than by anything the Node reports about itself, since the
+// addresses and provider ID of a Node are set by its own kubelet. The kubelet
+// requests its client certificate for system:node:<name> with the bootstrap
+// token, so the CSR created by system:bootstrap:<token-id> names the Node.
+type nodeLabeler struct {
+	client             clientset.Interface
+	allowed            []string
+	allowedAnnotations []string
+
+	mu sync.Mutex
+	// pending is keyed by the bootstrap token ID
+	pending map[string]*pendingNode
+}
+
+func newNodeLabeler(client clientset.Interface, allowed, allowedAnnotations []string) *nodeLabeler {
+	return &nodeLabeler{
+		client:             client,
+		allowed:            allowed,
+		allowedAnnotations: allowedAnnotations,
+		pending:            make(map[string]*pendingNode),
+	}
+}
+
+// Validate ensures that every requested label and annotation is allowed to be
+// applied to worker nodes.
+func (l *nodeLabeler) Validate(labels, annotations map[string]string) error {
+	for k := range labels {
+		if !l.isAllowed(k) {
+			return errors.Errorf("node label not allowed: %q", k)
+		}
+	}
+	for k := range annotations {
+		if !isAllowedKey(l.allowedAnnotations, k) {
+			return errors.Errorf("node annotation not allowed: %q", k)
+		}
+	}
+	return nil
+}
+
+func (l *nodeLabeler) isAllowed(key string) bool {
+	for _, denied := range deniedNodeLabels {
+		if key == denied {
+			return false
+		}
+	}
+	return isAllowedKey(l.allowed, key)
+}
+
+// isAllowedKey returns true if the key is in the allowed keys, where a
+// trailing '/' allows every key with that prefix.
+func isAllowedKey(allowed []string, key string) bool {
+	for _, a := range allowed {
+		if key == a || (strings.HasSuffix(a, "/") && strings.HasPrefix(key, a)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add records the labels and annotations to apply once the node that was
+// issued the bootstrap token has registered.
+func (l *nodeLabeler) Add(tokenID string, labels, annotations map[string]string) {
+	if len(labels) == 0 && len(annotations) == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pending[tokenID] = &pendingNode{
+		labels:      labels,
+		annotations: annotations,
+		expires:     time.Now().Add(pendingNodeTTL),
+	}
+}
+
+// Run applies pending labels until the context is cancelled.
+func (l *nodeLabeler) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, l.labelNodes, labelNodesInterval)
+}
+
+func (l *nodeLabeler) labelNodes(ctx context.Context) {
+	l.mu.Lock()
+	pending := make(map[string]*pendingNode)
+	for tokenID, p := range l.pending {
+		if time.Now().After(p.expires) {
+			log.Warn("node did not register before labels expired", zap.String("tokenID", tokenID))
+			delete(l.pending, tokenID)
+			continue
+		}
+		pending[tokenID] = p
+	}
+	l.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	csrs, err := l.client.CertificatesV1beta1().CertificateSigningRequests().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Error("cannot list certificate signing requests", zap.Error(err))
+		return
+	}
+	for tokenID, p := range pending {
+		name := nodeNameForToken(csrs.Items, tokenID)
+		if name == "" {
+			continue
+		}
+		if _, err := l.client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Error("cannot get node", zap.String("node", name), zap.Error(err))
+			}
+			continue
+		}
+		if err := l.labelNode(ctx, name, p); err != nil {
+			log.Error("cannot label node", zap.String("node", name), zap.Error(err))
+			continue
+		}
+		log.Info("labeled node", zap.String("node", name), zap.String("labels", nodeutil.FormatLabels(p.labels)))
+		l.mu.Lock()
+		delete(l.pending, tokenID)
+		l.mu.Unlock()
+	}
+}
+
+// nodeNameForToken returns the name of the Node that requested an approved
+// client certificate using the bootstrap token, or an empty string if there
+// is none.
+func nodeNameForToken(csrs []certificatesv1beta1.CertificateSigningRequest, tokenID string) string {
+	for _, csr := range csrs {
+		if csr.Spec.Username != "system:bootstrap:"+tokenID || !isApproved(&csr) {
+			continue
+		}
+		block, _ := pem.Decode(csr.Spec.Request)
+		if block == nil {
+			continue
+		}
+		req, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if name := strings.TrimPrefix(req.Subject.CommonName, "system:node:"); name != req.Subject.CommonName && name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+func isApproved(csr *certificatesv1beta1.CertificateSigningRequest) bool {
+	approved := false
+	for _, cond := range csr.Status.Conditions {
+		switch cond.Type {
+		case certificatesv1beta1.CertificateDenied:
+			return false
+		case certificatesv1beta1.CertificateApproved:
+			approved = true
+		}
+	}
+	return approved
+}
+
+func (l *nodeLabeler) labelNode(ctx context.Context, name string, p *pendingNode) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return nodeutil.PatchNodeWithContext(ctx, l.client, name, func(n *corev1.Node) {
+		nodeutil.SetLabelsAndAnnotations(n, p.labels, p.annotations)
+	})
+}
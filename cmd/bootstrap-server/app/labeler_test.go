@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newNodeCSR(t *testing.T, name, tokenID, nodeName string, approved bool) *certificatesv1beta1.CertificateSigningRequest {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   "system:node:" + nodeName,
+			Organization: []string{"system:nodes"},
+		},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr := &certificatesv1beta1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: certificatesv1beta1.CertificateSigningRequestSpec{
+			Request:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+			Username: "system:bootstrap:" + tokenID,
+		},
+	}
+	if approved {
+		csr.Status.Conditions = []certificatesv1beta1.CertificateSigningRequestCondition{
+			{Type: certificatesv1beta1.CertificateApproved},
+		}
+	}
+	return csr
+}
+
+func newNode(name, ip string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{corev1.LabelHostname: name},
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: ip}},
+		},
+	}
+}
+
+func TestNodeLabelerLabelNodes(t *testing.T) {
+	client := fake.NewSimpleClientset([]runtime.Object{
+		// reports the address of the authorized instance, but did not
+		// request its certificate with the issued bootstrap token
+		newNode("impostor", "192.0.2.10"),
+		newNode("worker-1", "192.0.2.11"),
+		newNode("worker-2", "192.0.2.12"),
+		newNodeCSR(t, "csr-1", "abcdef", "worker-1", true),
+		newNodeCSR(t, "csr-2", "ghijkl", "worker-2", false),
+	}...)
+	l := newNodeLabeler(client, []string{"node-role.kubernetes.io/"}, nil)
+	l.Add("abcdef", map[string]string{"node-role.kubernetes.io/ingress": ""}, nil)
+	l.Add("ghijkl", map[string]string{"node-role.kubernetes.io/database": ""}, nil)
+	l.labelNodes(context.Background())
+
+	expected := map[string]string{
+		"impostor": "",
+		"worker-1": "node-role.kubernetes.io/ingress",
+		"worker-2": "",
+	}
+	for name, label := range expected {
+		n, err := client.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if label == "" {
+			if len(n.Labels) > 1 {
+				t.Errorf("expected node %s to not be labeled, received %v", name, n.Labels)
+			}
+			continue
+		}
+		if _, ok := n.Labels[label]; !ok {
+			t.Errorf("expected node %s to have label %s, received %v", name, label, n.Labels)
+		}
+	}
+	if _, ok := l.pending["abcdef"]; ok {
+		t.Error("expected labels for abcdef to no longer be pending")
+	}
+	if _, ok := l.pending["ghijkl"]; !ok {
+		t.Error("expected labels for ghijkl to be pending until the CSR is approved")
+	}
+}
+
+func TestNodeLabelerValidate(t *testing.T) {
+	l := newNodeLabeler(nil, []string{"node-role.kubernetes.io/"}, []string{"example.com/owner"})
+	cases := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		err         bool
+	}{
+		{
+			name:        "allowed",
+			labels:      map[string]string{"node-role.kubernetes.io/ingress": ""},
+			annotations: map[string]string{"example.com/owner": "team"},
+		},
+		{
+			name:   "denied label",
+			labels: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+			err:    true,
+		},
+		{
+			name:   "label not allowed",
+			labels: map[string]string{"kubernetes.io/hostname": "worker-1"},
+			err:    true,
+		},
+		{
+			name:        "annotation not allowed",
+			annotations: map[string]string{"node.alpha.kubernetes.io/ttl": "0"},
+			err:         true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := l.Validate(tc.labels, tc.annotations)
+			if tc.err && err == nil {
+				t.Fatal("expected error")
+			}
+			if !tc.err && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
@@ -10,6 +11,8 @@ import (
 	proxyproto "github.com/pires/go-proxyproto"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+
+	"github.com/criticalstack/crit/pkg/log"
 )
 
 type serverOptions struct {
@@ -19,6 +22,11 @@ type serverOptions struct {
 	Filters    string
 	Kubeconfig string
 	Port       int
+
+	AllowedNodeLabels      []string
+	AllowedNodeAnnotations []string
+
+	Log log.Options
 }
 
 func NewRootCmd() *cobra.Command {
@@ -29,19 +37,29 @@ func NewRootCmd() *cobra.Command {
 		Short:        "run bootstrap-server",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.Configure(&o.Log); err != nil {
+				return err
+			}
 			if o.CertFile == "" {
 				return errors.New("must provide CertFile")
 			}
 			if o.KeyFile == "" {
 				return errors.New("must provide KeyFile")
 			}
+			client, err := newClient(o.Kubeconfig)
+			if err != nil {
+				return err
+			}
+			labeler := newNodeLabeler(client, o.AllowedNodeLabels, o.AllowedNodeAnnotations)
+			go labeler.Run(context.Background())
+
 			s := &http.Server{
 				Addr: fmt.Sprintf(":%d", o.Port),
 				Handler: newBootstrapRouter(&bootstrapConfig{
 					Provider:   o.Provider,
 					Filters:    parseFilters(o.Filters),
 					Kubeconfig: o.Kubeconfig,
-				}),
+				}, labeler),
 				ReadTimeout:    10 * time.Second,
 				WriteTimeout:   10 * time.Second,
 				MaxHeaderBytes: 1 << 20,
@@ -60,6 +78,9 @@ func NewRootCmd() *cobra.Command {
 	cmd.Flags().StringVar(&o.KeyFile, "key-file", "", "server key")
 	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "")
 	cmd.Flags().IntVar(&o.Port, "port", 8080, "")
+	cmd.Flags().StringSliceVar(&o.AllowedNodeLabels, "allowed-node-labels", []string{"node-role.kubernetes.io/"}, "restricted labels that worker nodes are allowed to request, a trailing '/' allows every label with that prefix")
+	cmd.Flags().StringSliceVar(&o.AllowedNodeAnnotations, "allowed-node-annotations", nil, "annotations that worker nodes are allowed to request, a trailing '/' allows every annotation with that prefix")
+	o.Log.AddFlags(cmd.Flags())
 
 	return cmd
 }
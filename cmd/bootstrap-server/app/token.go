@@ -14,19 +14,27 @@ import (
 	"github.com/criticalstack/crit/pkg/kubernetes/pki"
 )
 
-func createNewToken(kubeconfigFile string) (string, error) {
+func newClient(kubeconfigFile string) (*clientset.Clientset, error) {
 	config, err := clientcmd.LoadFromFile(kubeconfigFile)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to load kubeconfig: %#v", kubeconfigFile)
+		return nil, errors.Wrapf(err, "failed to load kubeconfig: %#v", kubeconfigFile)
 	}
 	clientConfig, err := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
 	if err != nil {
-		return "", errors.Wrap(err, "failed to create API client configuration from kubeconfig")
+		return nil, errors.Wrap(err, "failed to create API client configuration from kubeconfig")
 	}
 
 	client, err := clientset.NewForConfig(clientConfig)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to create API client")
+		return nil, errors.Wrap(err, "failed to create API client")
+	}
+	return client, nil
+}
+
+func createNewToken(kubeconfigFile string) (string, error) {
+	client, err := newClient(kubeconfigFile)
+	if err != nil {
+		return "", err
 	}
 	id, secret := pki.GenerateBootstrapToken()
 	if err := kubernetes.UpdateSecret(client, context.TODO(), &corev1.Secret{
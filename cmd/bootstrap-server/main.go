@@ -1,9 +1,8 @@
 package main
 
 import (
-	"log"
-
 	"github.com/criticalstack/crit/cmd/bootstrap-server/app"
+	"github.com/criticalstack/crit/pkg/log"
 )
 
 func main() {
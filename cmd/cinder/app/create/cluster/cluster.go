@@ -24,6 +24,7 @@ var opts struct {
 	Image      string
 	Config     string
 	Kubeconfig string
+	Strict     bool
 	Verbose    bool
 }
 
@@ -111,7 +112,11 @@ func NewCommand() *cobra.Command {
 			cfg := &config.ClusterConfiguration{}
 			if err := utils.NewStep("Creating control-plane node", ":fire:", opts.Verbose, func() (err error) {
 				if opts.Config != "" {
-					cfg, err = config.LoadFromFile(opts.Config)
+					load := config.LoadFromFileStrict
+					if !opts.Strict {
+						load = config.LoadFromFile
+					}
+					cfg, err = load(opts.Config)
 					if err != nil {
 						return err
 					}
@@ -225,6 +230,7 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.Image, "image", constants.DefaultNodeImage, "node image")
 	cmd.Flags().StringVarP(&opts.Config, "config", "c", "", "cinder configuration file")
 	cmd.Flags().StringVar(&opts.Kubeconfig, "kubeconfig", "", "sets kubeconfig path instead of $KUBECONFIG or $HOME/.kube/config")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the configuration file")
 	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "show verbose output")
 	return cmd
 }
@@ -23,6 +23,7 @@ var opts struct {
 	Image      string
 	Config     string
 	Kubeconfig string
+	Strict     bool
 	Verbose    bool
 }
 
@@ -47,7 +48,11 @@ func NewCommand() *cobra.Command {
 			if err := utils.NewStep("Creating worker node", ":fire:", opts.Verbose, func() (err error) {
 				cfg := &config.ClusterConfiguration{}
 				if opts.Config != "" {
-					cfg, err = config.LoadFromFile(opts.Config)
+					load := config.LoadFromFileStrict
+					if !opts.Strict {
+						load = config.LoadFromFile
+					}
+					cfg, err = load(opts.Config)
 					if err != nil {
 						return err
 					}
@@ -115,6 +120,7 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.Image, "image", constants.DefaultNodeImage, "node image")
 	cmd.Flags().StringVarP(&opts.Config, "config", "c", "", "cinder configuration file")
 	cmd.Flags().StringVar(&opts.Kubeconfig, "kubeconfig", "", "sets kubeconfig path instead of $KUBECONFIG or $HOME/.kube/config")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the configuration file")
 	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "show verbose output")
 	return cmd
 }
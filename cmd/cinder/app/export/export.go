@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	exportkubeconfig "github.com/criticalstack/crit/cmd/cinder/app/export/kubeconfig"
+	exportsupportbundle "github.com/criticalstack/crit/cmd/cinder/app/export/supportbundle"
 )
 
 func NewCommand() *cobra.Command {
@@ -17,6 +18,7 @@ func NewCommand() *cobra.Command {
 	}
 	cmd.AddCommand(
 		exportkubeconfig.NewCommand(),
+		exportsupportbundle.NewCommand(),
 		// TODO(chrism): tail file and tail log command
 	)
 	return cmd
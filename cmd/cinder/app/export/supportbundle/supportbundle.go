@@ -0,0 +1,74 @@
+package supportbundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/criticalstack/crit/internal/cinder/cluster"
+)
+
+var opts struct {
+	Name   string
+	Nodes  []string
+	OutDir string
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "support-bundle",
+		Short:         "Export crit support bundles from cinder cluster nodes",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nodes, err := cluster.ListNodes(opts.Name)
+			if err != nil {
+				return err
+			}
+			if len(nodes) == 0 {
+				return errors.Errorf("cannot find nodes for cluster %q", opts.Name)
+			}
+			if err := os.MkdirAll(opts.OutDir, 0755); err != nil {
+				return err
+			}
+			for _, node := range nodes {
+				if len(opts.Nodes) > 0 && !contains(opts.Nodes, node.String()) {
+					continue
+				}
+				path := filepath.Join(opts.OutDir, fmt.Sprintf("%s-support-bundle.tar.gz", node.String()))
+				if err := writeSupportBundle(node, path); err != nil {
+					return errors.Wrapf(err, "cannot export support bundle for node %q: %s", node.String(), node.CombinedOutput())
+				}
+				fmt.Printf("Exported support bundle for node %q to %s\n", node.String(), path)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&opts.Name, "name", "cinder", "cluster name")
+	cmd.Flags().StringSliceVar(&opts.Nodes, "nodes", nil, "only export support bundles for these nodes")
+	cmd.Flags().StringVarP(&opts.OutDir, "output-dir", "o", ".", "directory to write support bundles to")
+	return cmd
+}
+
+func writeSupportBundle(node *cluster.Node, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return node.SupportBundle(f)
+}
+
+func contains(ss []string, match string) bool {
+	for _, s := range ss {
+		if s == match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package addons
+
+import (
+	"github.com/spf13/cobra"
+
+	addonsapply "github.com/criticalstack/crit/cmd/crit/app/addons/apply"
+	addonslist "github.com/criticalstack/crit/cmd/crit/app/addons/list"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "addons",
+		Short: "Manage cluster addons",
+	}
+	cmd.AddCommand(
+		addonsapply.NewCommand(),
+		addonslist.NewCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,98 @@
+package apply
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/cluster"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+var opts struct {
+	ConfigFiles []string
+	Values      []string
+	Strict      bool
+	Kubeconfig  string
+	AddonValues map[string]string
+	SkipWait    bool
+	Timeout     time.Duration
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply [name|path...]",
+		Short: "apply addons to a running cluster",
+		Long: `Apply addons to a running cluster. Addons can be the name of an embedded
+addon or the path to a local manifest file or directory. If no addons are
+provided, the addons found in the ControlPlaneConfiguration are applied.`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			obj, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+				Strict: opts.Strict,
+			})
+			if err != nil {
+				return err
+			}
+			cfg, ok := obj.(*config.ControlPlaneConfiguration)
+			if !ok {
+				return errors.Errorf("received invalid configuration type: %T", obj)
+			}
+			addons := cfg.Addons
+			if len(args) > 0 {
+				addons = make([]config.AddonConfiguration, 0)
+				for _, arg := range args {
+					addon := config.AddonConfiguration{Name: arg}
+					if _, err := os.Stat(arg); err == nil {
+						addon = config.AddonConfiguration{Path: arg}
+					}
+					addons = append(addons, addon)
+				}
+			}
+			restConfig, err := clientcmd.BuildConfigFromFlags("", opts.Kubeconfig)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			for i := range addons {
+				addon := &addons[i]
+				if addon.Values == nil {
+					addon.Values = make(map[string]string)
+				}
+				for k, v := range opts.AddonValues {
+					addon.Values[k] = v
+				}
+				if opts.SkipWait {
+					addon.SkipWait = true
+				}
+				if addon.Timeout.Duration == 0 || cmd.Flags().Changed("timeout") {
+					addon.Timeout.Duration = opts.Timeout
+				}
+				log.Info("applying addon", zap.String("addon", cluster.AddonName(addon)))
+				if err := cluster.ApplyAddon(ctx, restConfig, cfg, addon); err != nil {
+					return errors.Wrapf(err, "cannot apply addon %q", cluster.AddonName(addon))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the config file")
+	cmd.Flags().StringVar(&opts.Kubeconfig, "kubeconfig", "/etc/kubernetes/admin.conf", "kubeconfig used to apply addons")
+	cmd.Flags().StringToStringVar(&opts.AddonValues, "value", nil, "set addon template values (e.g. --value key=value)")
+	cmd.Flags().BoolVar(&opts.SkipWait, "skip-wait", false, "do not wait for addon workloads to become ready")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 5*time.Minute, "timeout for addon workloads to become ready, overriding the configured addon timeouts when set")
+	return cmd
+}
@@ -0,0 +1,30 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/criticalstack/crit/pkg/cluster"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "list",
+		Short:         "list embedded addons",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names, err := cluster.EmbeddedAddons()
+			if err != nil {
+				return err
+			}
+			for _, name := range names {
+				fmt.Println(name)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,101 @@
+package apply
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/cluster"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/log"
+	executil "github.com/criticalstack/crit/pkg/util/exec"
+	fmtutil "github.com/criticalstack/crit/pkg/util/fmt"
+)
+
+var opts struct {
+	ConfigFiles      []string
+	Values           []string
+	Strict           bool
+	DryRun           bool
+	Timeout          time.Duration
+	KubeletTimeout   time.Duration
+	ComponentTimeout time.Duration
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply config changes to a bootstrapped control plane node",
+		Long: `Apply config changes to a bootstrapped control plane node
+
+The apiserver serving certificate, kubeconfigs and static pod manifests are
+rendered from the config and compared with the files on disk, and the plan
+of changes is printed. Only the files that differ are rewritten, after which
+the affected components are restarted and must become healthy. The
+crit-config ConfigMap is then updated with the applied config.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+			defer cancel()
+
+			obj, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+				Strict: opts.Strict,
+			})
+			if err != nil {
+				return err
+			}
+			cfg, ok := obj.(*config.ControlPlaneConfiguration)
+			if !ok {
+				return errors.Errorf("expected ControlPlaneConfiguration, received %T", obj)
+			}
+			if err := cluster.ResolveControlPlaneConfig(ctx, cfg); err != nil {
+				return err
+			}
+			if errs := cluster.Validate(cfg); len(errs) > 0 {
+				stderr := executil.NewPrefixWriter(os.Stderr, "\t")
+				defer stderr.Close()
+
+				stderr.Write([]byte(fmtutil.FormatErrors(errs)))
+				return errors.New("invalid configuration")
+			}
+			p, err := cluster.PlanApply(cfg)
+			if err != nil {
+				return err
+			}
+			if p.Empty() {
+				log.Info("no changes to apply")
+				return nil
+			}
+			p.Print(os.Stdout)
+			if opts.DryRun {
+				return nil
+			}
+			c := cluster.New(filepath.Join(cfg.NodeConfiguration.KubeDir, "admin.conf"), &cluster.RuntimeConfig{
+				KubeletTimeout:   opts.KubeletTimeout,
+				ComponentTimeout: opts.ComponentTimeout,
+			})
+			if err := c.Apply(ctx, cfg, p); err != nil {
+				return err
+			}
+			return c.UploadInfo(ctx, cfg)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the config file")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "print the plan without making changes")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 10*time.Minute, "")
+	cmd.Flags().DurationVar(&opts.KubeletTimeout, "kubelet-timeout", 15*time.Second, "timeout for Kubelet to become healthy")
+	cmd.Flags().DurationVar(&opts.ComponentTimeout, "component-timeout", cluster.DefaultComponentTimeout, "timeout for the restarted components to become healthy")
+	return cmd
+}
@@ -3,17 +3,29 @@ package config
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/criticalstack/crit/cmd/crit/app/config/defaults"
+	"github.com/criticalstack/crit/cmd/crit/app/config/diff"
 	configimport "github.com/criticalstack/crit/cmd/crit/app/config/import"
+	"github.com/criticalstack/crit/cmd/crit/app/config/migrate"
+	"github.com/criticalstack/crit/cmd/crit/app/config/pull"
+	"github.com/criticalstack/crit/cmd/crit/app/config/schema"
+	"github.com/criticalstack/crit/cmd/crit/app/config/validate"
 )
 
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
-		Short: "Handle Kubernetes config files",
+		Short: "Handle Kubernetes and crit config files",
 	}
 
 	cmd.AddCommand(
+		defaults.NewCommand(),
+		diff.NewCommand(),
 		configimport.NewCommand(),
+		migrate.NewCommand(),
+		pull.NewCommand(),
+		schema.NewCommand(),
+		validate.NewCommand(),
 	)
 	return cmd
 }
@@ -0,0 +1,47 @@
+package defaults
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/criticalstack/crit/internal/config"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+)
+
+var opts struct {
+	Kind string
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "defaults",
+		Short:         "Print a crit config file with all defaults applied",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var obj runtime.Object
+			switch opts.Kind {
+			case "ControlPlaneConfiguration":
+				obj = &config.ControlPlaneConfiguration{}
+			case "WorkerConfiguration":
+				obj = &config.WorkerConfiguration{}
+			default:
+				return errors.Errorf("invalid kind %q, must be one of ControlPlaneConfiguration, WorkerConfiguration", opts.Kind)
+			}
+			clientsetscheme.Scheme.Default(obj)
+			data, err := configutil.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			fmt.Print(string(data))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Kind, "kind", "ControlPlaneConfiguration", "kind of config file (ControlPlaneConfiguration, WorkerConfiguration)")
+	return cmd
+}
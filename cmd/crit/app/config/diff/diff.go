@@ -0,0 +1,138 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/cluster"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+var opts struct {
+	ConfigFiles []string
+	Values      []string
+	Strict      bool
+	Kubeconfig  string
+	Timeout     time.Duration
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the control plane config with the cluster and manifests on disk",
+		Long: `Compare the control plane config with the cluster and manifests on disk
+
+The local config is compared field by field with the config stored in the
+crit-config ConfigMap, and the static pod manifests rendered from the local
+config are compared with the manifests on disk. The hostname and host
+addresses of the node, along with any node fields set by nodeOverrides, are
+expected to differ between control plane nodes and are not compared with the
+ConfigMap. The command exits with a non-zero status when any differences are
+found.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+			defer cancel()
+
+			obj, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+				Strict: opts.Strict,
+			})
+			if err != nil {
+				return err
+			}
+			cfg, ok := obj.(*config.ControlPlaneConfiguration)
+			if !ok {
+				return errors.Errorf("expected ControlPlaneConfiguration, received %T", obj)
+			}
+			if err := cluster.ResolveControlPlaneConfig(ctx, cfg); err != nil {
+				return err
+			}
+
+			found := false
+			stored, err := getStoredConfig(ctx, cfg)
+			if err != nil {
+				return errors.Wrapf(err, "cannot get %s ConfigMap", cluster.CritConfigName)
+			}
+			changes, err := cluster.DiffConfig(stored, cfg)
+			if err != nil {
+				return err
+			}
+			if len(changes) > 0 {
+				found = true
+				fmt.Printf("%s ConfigMap:\n", cluster.CritConfigName)
+				for _, c := range changes {
+					fmt.Printf("\t%s\n", c)
+				}
+			}
+
+			diffs, err := cluster.DiffKubeManifests(cfg)
+			if err != nil {
+				return err
+			}
+			for _, d := range diffs {
+				found = true
+				fmt.Printf("%s:\n", d.Path)
+				if d.Missing {
+					fmt.Printf("\tmanifest does not exist\n")
+				}
+				for _, c := range d.Changes {
+					fmt.Printf("\t%s\n", c)
+				}
+			}
+			if found {
+				return errors.New("configuration differs")
+			}
+			log.Info("configuration matches", zap.Strings("config", opts.ConfigFiles))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "reject config files with unknown fields")
+	cmd.Flags().StringVar(&opts.Kubeconfig, "kubeconfig", "", "kubeconfig used to get the crit-config ConfigMap (default admin.conf in the node kubeDir)")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 30*time.Second, "timeout for getting the crit-config ConfigMap")
+	return cmd
+}
+
+func getStoredConfig(ctx context.Context, cfg *config.ControlPlaneConfiguration) (*config.ControlPlaneConfiguration, error) {
+	kubeconfig := opts.Kubeconfig
+	if kubeconfig == "" {
+		kubeconfig = filepath.Join(cfg.NodeConfiguration.KubeDir, "admin.conf")
+	}
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	client, err := clientset.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	data, err := cluster.GetStoredConfig(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := configutil.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	stored, ok := obj.(*config.ControlPlaneConfiguration)
+	if !ok {
+		return nil, errors.Errorf("expected ControlPlaneConfiguration, received %T", obj)
+	}
+	return stored, nil
+}
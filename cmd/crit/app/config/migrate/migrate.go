@@ -0,0 +1,49 @@
+package migrate
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+)
+
+var opts struct {
+	ConfigFiles []string
+	Values      []string
+	OutputFile  string
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "migrate",
+		Short:         "Convert a crit config file to the latest version",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// config files are converted to the latest version when loaded
+			cfg, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+			})
+			if err != nil {
+				return err
+			}
+			data, err := configutil.Marshal(cfg)
+			if err != nil {
+				return err
+			}
+			if opts.OutputFile == "" || opts.OutputFile == "-" {
+				fmt.Print(string(data))
+				return nil
+			}
+			return ioutil.WriteFile(opts.OutputFile, data, 0644)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().StringVarP(&opts.OutputFile, "output", "o", "-", "output file")
+	return cmd
+}
@@ -0,0 +1,72 @@
+package pull
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/criticalstack/crit/pkg/cluster"
+	"github.com/criticalstack/crit/pkg/config/constants"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+var opts struct {
+	Kubeconfig string
+	OutputFile string
+	Timeout    time.Duration
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Write the control plane config stored in the cluster to a file",
+		Long: `Write the control plane config stored in the cluster to a file
+
+The config is read from the crit-config ConfigMap, which is updated by each
+control plane node once it has been bootstrapped. Runtime defaults, such as
+the hostname and host addresses, are those of the last control plane node to
+update it.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+			defer cancel()
+
+			restConfig, err := clientcmd.BuildConfigFromFlags("", opts.Kubeconfig)
+			if err != nil {
+				return err
+			}
+			client, err := clientset.NewForConfig(restConfig)
+			if err != nil {
+				return err
+			}
+			data, err := cluster.GetStoredConfig(ctx, client)
+			if err != nil {
+				return errors.Wrapf(err, "cannot get %s ConfigMap", cluster.CritConfigName)
+			}
+			if opts.OutputFile == "" || opts.OutputFile == "-" {
+				fmt.Print(string(data))
+				return nil
+			}
+			if err := ioutil.WriteFile(opts.OutputFile, data, 0600); err != nil {
+				return err
+			}
+			log.Info("config written", zap.String("path", opts.OutputFile))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Kubeconfig, "kubeconfig", filepath.Join(constants.DefaultKubeDir, "admin.conf"), "kubeconfig used to get the crit-config ConfigMap")
+	cmd.Flags().StringVarP(&opts.OutputFile, "output", "o", "-", "output file")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 30*time.Second, "timeout for getting the crit-config ConfigMap")
+	return cmd
+}
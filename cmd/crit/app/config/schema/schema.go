@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/criticalstack/crit/pkg/config/jsonschema"
+	"github.com/criticalstack/crit/pkg/config/v1alpha2"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+var opts struct {
+	APIVersion string
+	Kind       string
+	OutputDir  string
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "schema",
+		Short:         "Print the JSON Schema for a crit config file",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.OutputDir != "" {
+				if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+					return err
+				}
+				for _, gvk := range jsonschema.Kinds {
+					data, err := marshalSchema(gvk)
+					if err != nil {
+						return err
+					}
+					path := filepath.Join(opts.OutputDir, fileName(gvk))
+					if err := ioutil.WriteFile(path, data, 0644); err != nil {
+						return err
+					}
+					log.Info("wrote schema", zap.Stringer("kind", gvk), zap.String("path", path))
+				}
+				return nil
+			}
+			gv, err := schema.ParseGroupVersion(opts.APIVersion)
+			if err != nil {
+				return err
+			}
+			data, err := marshalSchema(gv.WithKind(opts.Kind))
+			if err != nil {
+				return errors.Wrapf(err, "available kinds: %s", availableKinds())
+			}
+			fmt.Print(string(data))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.APIVersion, "api-version", v1alpha2.SchemeGroupVersion.String(), "apiVersion of config file")
+	cmd.Flags().StringVar(&opts.Kind, "kind", "ControlPlaneConfiguration", "kind of config file (ControlPlaneConfiguration, WorkerConfiguration, ClusterConfiguration)")
+	cmd.Flags().StringVar(&opts.OutputDir, "output-dir", "", "write the schemas for all kinds to this directory")
+	return cmd
+}
+
+func marshalSchema(gvk schema.GroupVersionKind) ([]byte, error) {
+	s, err := jsonschema.ForKind(gvk)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// fileName returns the schema file name for a kind, e.g.
+// "crit.sh_v1alpha2_controlplaneconfiguration.json".
+func fileName(gvk schema.GroupVersionKind) string {
+	return fmt.Sprintf("%s_%s_%s.json", gvk.Group, gvk.Version, strings.ToLower(gvk.Kind))
+}
+
+func availableKinds() string {
+	kinds := make([]string, 0)
+	for _, gvk := range jsonschema.Kinds {
+		kinds = append(kinds, gvk.GroupVersion().String()+" "+gvk.Kind)
+	}
+	return strings.Join(kinds, ", ")
+}
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/criticalstack/crit/pkg/cluster"
+	"github.com/criticalstack/crit/pkg/config/jsonschema"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/log"
+	executil "github.com/criticalstack/crit/pkg/util/exec"
+	fmtutil "github.com/criticalstack/crit/pkg/util/fmt"
+)
+
+var opts struct {
+	ConfigFiles []string
+	Values      []string
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "validate",
+		Short:         "Validate a crit config file without making changes to the host",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := configutil.Compose(opts.ConfigFiles, opts.Values)
+			if err != nil {
+				return err
+			}
+
+			// the schema catches mistakes, such as unknown fields, that are
+			// lost once the config is decoded
+			errs := jsonschema.ValidateDocument(data)
+			cfg, err := configutil.Unmarshal(data)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				errs = append(errs, cluster.Validate(cfg)...)
+			}
+			if len(errs) > 0 {
+				stderr := executil.NewPrefixWriter(os.Stderr, "\t")
+				defer stderr.Close()
+
+				stderr.Write([]byte(fmtutil.FormatErrors(errs)))
+				return errors.Errorf("invalid configuration: %s", strings.Join(opts.ConfigFiles, ", "))
+			}
+			log.Info("configuration is valid", zap.Strings("config", opts.ConfigFiles))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	return cmd
+}
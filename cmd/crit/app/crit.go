@@ -6,28 +6,41 @@ import (
 
 	e2dapp "github.com/criticalstack/e2d/cmd/e2d/app"
 
+	"github.com/criticalstack/crit/cmd/crit/app/addons"
+	"github.com/criticalstack/crit/cmd/crit/app/apply"
 	"github.com/criticalstack/crit/cmd/crit/app/certs"
 	"github.com/criticalstack/crit/cmd/crit/app/config"
 	"github.com/criticalstack/crit/cmd/crit/app/create"
 	"github.com/criticalstack/crit/cmd/crit/app/generate"
+	"github.com/criticalstack/crit/cmd/crit/app/images"
+	"github.com/criticalstack/crit/cmd/crit/app/preflight"
+	"github.com/criticalstack/crit/cmd/crit/app/supportbundle"
 	"github.com/criticalstack/crit/cmd/crit/app/template"
 	"github.com/criticalstack/crit/cmd/crit/app/up"
 	"github.com/criticalstack/crit/cmd/crit/app/version"
 	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/trace"
 )
 
 var global struct {
 	Verbosity int
+	Log       log.Options
+	Trace     trace.Options
 }
 
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "crit",
 		Short: "bootstrap Critical Stack clusters",
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if global.Verbosity > 0 {
 				log.SetLevel(zapcore.DebugLevel)
 			}
+			if err := log.Configure(&global.Log); err != nil {
+				return err
+			}
+			global.Trace.ServiceName = "crit"
+			return trace.Configure(&global.Trace)
 		},
 	}
 
@@ -37,15 +50,22 @@ func NewCommand() *cobra.Command {
 	)
 
 	cmd.AddCommand(
+		addons.NewCommand(),
+		apply.NewCommand(),
 		certs.NewCommand(),
 		config.NewCommand(),
 		create.NewCommand(),
 		generate.NewCommand(),
+		images.NewCommand(),
+		preflight.NewCommand(),
+		supportbundle.NewCommand(),
 		template.NewCommand(),
 		up.NewCommand(),
 		version.NewCommand(),
 	)
 
 	cmd.PersistentFlags().CountVarP(&global.Verbosity, "verbose", "v", "log output verbosity")
+	global.Log.AddFlags(cmd.PersistentFlags())
+	global.Trace.AddFlags(cmd.PersistentFlags())
 	return cmd
 }
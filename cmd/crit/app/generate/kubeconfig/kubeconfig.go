@@ -2,8 +2,10 @@ package kubeconfig
 
 import (
 	"crypto/x509"
+	"fmt"
 	"path/filepath"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/criticalstack/crit/pkg/kubeconfig"
@@ -17,6 +19,12 @@ var opts struct {
 	CertDir      string
 	CommonName   string
 	Organization string
+
+	OIDC             bool
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCExtraScopes  []string
 }
 
 func NewCommand() *cobra.Command {
@@ -33,6 +41,32 @@ func NewCommand() *cobra.Command {
 					return err
 				}
 			}
+			if opts.OIDC {
+				if opts.OIDCIssuerURL == "" || opts.OIDCClientID == "" {
+					return errors.New("must provide --oidc-issuer-url and --oidc-client-id with --oidc")
+				}
+				caCert, err := pki.ReadCertFromFile(filepath.Join(opts.CertDir, opts.CertName+".crt"))
+				if err != nil {
+					return err
+				}
+				extraArgs := make([]string, 0)
+				if opts.OIDCClientSecret != "" {
+					extraArgs = append(extraArgs, fmt.Sprintf("--oidc-client-secret=%s", opts.OIDCClientSecret))
+				}
+				for _, scope := range opts.OIDCExtraScopes {
+					extraArgs = append(extraArgs, fmt.Sprintf("--oidc-extra-scope=%s", scope))
+				}
+				k := kubeconfig.NewForOIDC(
+					opts.Server,
+					opts.Name,
+					"oidc",
+					pki.EncodeCertPEM(caCert),
+					opts.OIDCIssuerURL,
+					opts.OIDCClientID,
+					extraArgs...,
+				)
+				return kubeconfig.WriteToFile(k, args[0])
+			}
 			ca, err := pki.LoadCertificateAuthority(opts.CertDir, opts.CertName)
 			if err != nil {
 				return err
@@ -63,5 +97,10 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.CertDir, "cert-dir", ".", "")
 	cmd.Flags().StringVar(&opts.CommonName, "CN", "kubernetes-admin", "")
 	cmd.Flags().StringVar(&opts.Organization, "O", "system:masters", "")
+	cmd.Flags().BoolVar(&opts.OIDC, "oidc", false, "write a user that authenticates with the kubectl oidc-login exec plugin instead of a client certificate")
+	cmd.Flags().StringVar(&opts.OIDCIssuerURL, "oidc-issuer-url", "", "OpenID Connect issuer URL")
+	cmd.Flags().StringVar(&opts.OIDCClientID, "oidc-client-id", "", "OpenID Connect client ID")
+	cmd.Flags().StringVar(&opts.OIDCClientSecret, "oidc-client-secret", "", "OpenID Connect client secret")
+	cmd.Flags().StringSliceVar(&opts.OIDCExtraScopes, "oidc-extra-scope", nil, "additional scopes to request from the OpenID Connect provider")
 	return cmd
 }
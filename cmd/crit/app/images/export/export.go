@@ -0,0 +1,82 @@
+package export
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/criticalstack/crit/pkg/cluster"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/kubernetes/remote"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+var opts struct {
+	ConfigFiles []string
+	Values      []string
+	Strict      bool
+	Output      string
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [images...]",
+		Short: "export images to a tarball bundle",
+		Long: `Export images to a tarball bundle that can be loaded on another node with
+"crit images import". If no images are provided, the images required by the
+node configuration are exported. Any images not present are pulled first.`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+				Strict: opts.Strict,
+			})
+			if err != nil {
+				return err
+			}
+			if err := cluster.ApplyNodeOverrides(context.Background(), cfg); err != nil {
+				return err
+			}
+			images, cr, err := cluster.RequiredImages(cfg)
+			if err != nil {
+				return err
+			}
+			if len(args) > 0 {
+				images = args
+			}
+			ctx := context.Background()
+			client, err := remote.NewImageServiceClient(ctx, cr.CRISocket())
+			if err != nil {
+				return err
+			}
+			for _, image := range images {
+				ok, err := client.IsImagePresent(ctx, image)
+				if err != nil {
+					return err
+				}
+				if ok {
+					continue
+				}
+				if _, err := client.Pull(ctx, image); err != nil {
+					return errors.Wrapf(err, "cannot pull image %q", image)
+				}
+				log.Info("pulled image", zap.String("image", image))
+			}
+			if err := remote.ExportImages(ctx, cr, opts.Output, images); err != nil {
+				return err
+			}
+			log.Info("exported images", zap.String("path", opts.Output), zap.Strings("images", images))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the config file")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "images.tar", "path of the image bundle")
+	return cmd
+}
@@ -0,0 +1,24 @@
+package images
+
+import (
+	"github.com/spf13/cobra"
+
+	imagesexport "github.com/criticalstack/crit/cmd/crit/app/images/export"
+	imagesimport "github.com/criticalstack/crit/cmd/crit/app/images/import"
+	imageslist "github.com/criticalstack/crit/cmd/crit/app/images/list"
+	imagespull "github.com/criticalstack/crit/cmd/crit/app/images/pull"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "images",
+		Short: "Manage container images used by crit",
+	}
+	cmd.AddCommand(
+		imagesexport.NewCommand(),
+		imagesimport.NewCommand(),
+		imageslist.NewCommand(),
+		imagespull.NewCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,36 @@
+package imagesimport
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/criticalstack/crit/pkg/config/constants"
+	"github.com/criticalstack/crit/pkg/kubernetes/remote"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+var opts struct {
+	ContainerRuntime string
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "import [bundle]",
+		Short:         "import images from a tarball bundle",
+		Args:          cobra.ExactArgs(1),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := remote.ImportImages(context.Background(), constants.ContainerRuntime(opts.ContainerRuntime), args[0]); err != nil {
+				return err
+			}
+			log.Info("imported images", zap.String("path", args[0]))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.ContainerRuntime, "container-runtime", string(constants.Containerd), "container runtime to import images into (containerd, docker)")
+	return cmd
+}
@@ -0,0 +1,81 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/criticalstack/crit/pkg/cluster"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/kubernetes/remote"
+)
+
+var opts struct {
+	ConfigFiles []string
+	Values      []string
+	Strict      bool
+	Check       bool
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "list",
+		Short:         "list the images required by a node",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+				Strict: opts.Strict,
+			})
+			if err != nil {
+				return err
+			}
+			if err := cluster.ApplyNodeOverrides(context.Background(), cfg); err != nil {
+				return err
+			}
+			images, cr, err := cluster.RequiredImages(cfg)
+			if err != nil {
+				return err
+			}
+			if !opts.Check {
+				for _, image := range images {
+					fmt.Println(image)
+				}
+				return nil
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			client, err := remote.NewImageServiceClient(ctx, cr.CRISocket())
+			if err != nil {
+				return err
+			}
+			w := new(tabwriter.Writer)
+			w.Init(os.Stdout, 0, 8, 1, '\t', 0)
+			defer w.Flush()
+
+			fmt.Fprintln(w, "IMAGE\tPRESENT")
+			for _, image := range images {
+				ok, err := client.IsImagePresent(ctx, image)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(w, "%s\t%t\n", image, ok)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the config file")
+	cmd.Flags().BoolVar(&opts.Check, "check", false, "check if the images are present in the container runtime")
+	return cmd
+}
@@ -0,0 +1,65 @@
+package pull
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/criticalstack/crit/pkg/cluster"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/kubernetes/remote"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+var opts struct {
+	ConfigFiles []string
+	Values      []string
+	Strict      bool
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "pull",
+		Short:         "pull the images required by a node",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+				Strict: opts.Strict,
+			})
+			if err != nil {
+				return err
+			}
+			if err := cluster.ApplyNodeOverrides(context.Background(), cfg); err != nil {
+				return err
+			}
+			images, cr, err := cluster.RequiredImages(cfg)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			client, err := remote.NewImageServiceClient(ctx, cr.CRISocket())
+			if err != nil {
+				return err
+			}
+			for _, image := range images {
+				ref, err := client.Pull(ctx, image)
+				if err != nil {
+					return errors.Wrapf(err, "cannot pull image %q", image)
+				}
+				log.Info("pulled image", zap.String("image", image), zap.String("ref", ref))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the config file")
+	return cmd
+}
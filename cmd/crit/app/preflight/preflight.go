@@ -0,0 +1,60 @@
+package preflight
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/criticalstack/crit/pkg/cluster"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+var opts struct {
+	ConfigFiles           []string
+	Values                []string
+	Strict                bool
+	Timeout               time.Duration
+	IgnorePreflightErrors []string
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "preflight",
+		Short:         "Run the host checks performed before bootstrapping a node",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+			defer cancel()
+
+			cfg, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+				Strict: opts.Strict,
+			})
+			if err != nil {
+				return err
+			}
+			if err := cluster.ApplyNodeOverrides(ctx, cfg); err != nil {
+				return err
+			}
+			rc := &cluster.RuntimeConfig{
+				IgnorePreflightErrors: opts.IgnorePreflightErrors,
+			}
+			if err := cluster.Preflight(ctx, rc, cfg); err != nil {
+				return err
+			}
+			log.Info("preflight checks passed")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the config file")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 1*time.Minute, "")
+	cmd.Flags().StringSliceVar(&opts.IgnorePreflightErrors, "ignore-preflight-errors", nil, "preflight checks whose errors will be shown as warnings (e.g. 'Swap,Port-10250'), the value 'all' ignores errors from all checks")
+	return cmd
+}
@@ -0,0 +1,92 @@
+package supportbundle
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/criticalstack/crit/pkg/cluster"
+	"github.com/criticalstack/crit/pkg/config/constants"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+var opts struct {
+	ConfigFiles      []string
+	Values           []string
+	Output           string
+	KubeDir          string
+	ContainerRuntime string
+	Lines            int
+	Timeout          time.Duration
+}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect node diagnostics into a support bundle",
+		Long: `Collect node diagnostics into a support bundle
+
+The support bundle is a gzipped tarball containing the crit config (with
+secrets redacted), static pod manifests, kubelet config, certificate metadata,
+the kubelet and container runtime journals, container statuses and logs, the
+crit-config ConfigMap, node conditions and etcd health. Private keys and
+kubeconfigs are never included. Anything that cannot be collected, for
+example when the cluster is unavailable, is listed in errors.txt.`,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+			defer cancel()
+
+			var cfg runtime.Object
+			if len(opts.ConfigFiles) > 0 {
+				var err error
+				cfg, err = configutil.Load(&configutil.LoadOptions{
+					Files:  opts.ConfigFiles,
+					Values: opts.Values,
+				})
+				if err != nil {
+					log.Warn("cannot load config, continuing without it", zap.Error(err))
+				}
+			}
+			var w io.Writer = os.Stdout
+			if opts.Output != "-" {
+				f, err := os.OpenFile(opts.Output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+
+				w = f
+			}
+			if err := cluster.WriteSupportBundle(ctx, w, &cluster.SupportBundleOptions{
+				Config:           cfg,
+				KubeDir:          opts.KubeDir,
+				ContainerRuntime: constants.ContainerRuntime(opts.ContainerRuntime),
+				Lines:            opts.Lines,
+			}); err != nil {
+				return err
+			}
+			if opts.Output != "-" {
+				log.Info("support bundle written", zap.String("path", opts.Output))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", nil, "config file used to bootstrap the node, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "crit-support-bundle.tar.gz", "output file, or - for stdout")
+	cmd.Flags().StringVar(&opts.KubeDir, "kube-dir", constants.DefaultKubeDir, "Kubernetes config directory, used when a config file is not provided")
+	cmd.Flags().StringVar(&opts.ContainerRuntime, "container-runtime", string(constants.Containerd), "container runtime, used when a config file is not provided")
+	cmd.Flags().IntVar(&opts.Lines, "lines", cluster.DefaultSupportBundleLines, "number of lines of each journal and container log")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 2*time.Minute, "")
+	return cmd
+}
@@ -10,7 +10,9 @@ import (
 )
 
 var opts struct {
-	ConfigFile string
+	ConfigFiles []string
+	Values      []string
+	Strict      bool
 }
 
 func NewCommand() *cobra.Command {
@@ -20,7 +22,11 @@ func NewCommand() *cobra.Command {
 		Args:          cobra.MinimumNArgs(1),
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := configutil.LoadFromFile(opts.ConfigFile)
+			cfg, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+				Strict: opts.Strict,
+			})
 			if err != nil {
 				return err
 			}
@@ -34,6 +40,8 @@ func NewCommand() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.ConfigFile, "config", "c", "config.yaml", "config file")
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the config file")
 	return cmd
 }
@@ -2,6 +2,7 @@ package up
 
 import (
 	"context"
+	"os"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,9 +16,22 @@ import (
 )
 
 var opts struct {
-	ConfigFile     string
-	Timeout        time.Duration
-	KubeletTimeout time.Duration
+	ConfigFiles      []string
+	Values           []string
+	Strict           bool
+	Timeout          time.Duration
+	KubeletTimeout   time.Duration
+	ComponentTimeout time.Duration
+
+	IgnorePreflightErrors []string
+	FailureReportFile     string
+	FailureReportLines    int
+
+	WaitNodeReady    bool
+	NodeReadyTimeout time.Duration
+
+	EventsFile string
+	EventsFD   int
 }
 
 func NewCommand() *cobra.Command {
@@ -30,16 +44,35 @@ func NewCommand() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
 			defer cancel()
 
-			cfg, err := configutil.LoadFromFile(opts.ConfigFile)
+			cfg, err := configutil.Load(&configutil.LoadOptions{
+				Files:  opts.ConfigFiles,
+				Values: opts.Values,
+				Strict: opts.Strict,
+			})
 			if err != nil {
 				return err
 			}
 			rc := &cluster.RuntimeConfig{
-				KubeletTimeout: opts.KubeletTimeout,
+				KubeletTimeout:        opts.KubeletTimeout,
+				ComponentTimeout:      opts.ComponentTimeout,
+				IgnorePreflightErrors: opts.IgnorePreflightErrors,
+				FailureReportFile:     opts.FailureReportFile,
+				FailureReportLines:    opts.FailureReportLines,
+				WaitNodeReady:         opts.WaitNodeReady,
+				NodeReadyTimeout:      opts.NodeReadyTimeout,
 			}
 			if log.Level() == zapcore.DebugLevel {
 				rc.Verbose = true
 			}
+			events, err := openEvents()
+			if err != nil {
+				return err
+			}
+			if events != nil {
+				defer events.Close()
+
+				rc.Events = events
+			}
 			switch c := cfg.(type) {
 			case *config.ControlPlaneConfiguration:
 				return cluster.RunControlPlane(ctx, rc, c)
@@ -51,8 +84,40 @@ func NewCommand() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.ConfigFile, "config", "c", "config.yaml", "config file")
+	cmd.Flags().StringArrayVarP(&opts.ConfigFiles, "config", "c", []string{"config.yaml"}, "config file, can be provided multiple times to merge files in order")
+	cmd.Flags().StringArrayVar(&opts.Values, "set", nil, "set a config value with path=value (e.g. node.kubelet.cgroupDriver=systemd), can be provided multiple times")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "return an error for unknown fields in the config file")
 	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 20*time.Minute, "")
 	cmd.Flags().DurationVar(&opts.KubeletTimeout, "kubelet-timeout", 15*time.Second, "timeout for Kubelet to become healthy")
+	cmd.Flags().DurationVar(&opts.ComponentTimeout, "component-timeout", cluster.DefaultComponentTimeout, "timeout for all control plane components to become healthy")
+	cmd.Flags().StringSliceVar(&opts.IgnorePreflightErrors, "ignore-preflight-errors", nil, "preflight checks whose errors will be shown as warnings (e.g. 'Swap,Port-10250'), the value 'all' ignores errors from all checks")
+	cmd.Flags().StringVar(&opts.FailureReportFile, "failure-report-file", "", "write a JSON failure report to this file if the node fails to bootstrap")
+	cmd.Flags().IntVar(&opts.FailureReportLines, "failure-report-lines", cluster.DefaultFailureReportLines, "number of kubelet journal and container log lines included in the failure report")
+	cmd.Flags().BoolVar(&opts.WaitNodeReady, "wait-node-ready", false, "wait for worker nodes to register and become Ready")
+	cmd.Flags().DurationVar(&opts.NodeReadyTimeout, "node-ready-timeout", cluster.DefaultNodeReadyTimeout, "timeout for the node to register and become Ready when --wait-node-ready is set")
+	cmd.Flags().StringVar(&opts.EventsFile, "events-file", "", "write newline-delimited JSON events for each bootstrap step to this file")
+	cmd.Flags().IntVar(&opts.EventsFD, "events-fd", -1, "write newline-delimited JSON events for each bootstrap step to this file descriptor")
 	return cmd
 }
+
+// openEvents opens the destination of the bootstrap events, returning nil if
+// events were not requested.
+func openEvents() (*os.File, error) {
+	switch {
+	case opts.EventsFile != "" && opts.EventsFD >= 0:
+		return nil, errors.New("cannot specify both --events-file and --events-fd")
+	case opts.EventsFile != "":
+		f, err := os.OpenFile(opts.EventsFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot open events file")
+		}
+		return f, nil
+	case opts.EventsFD >= 0:
+		f := os.NewFile(uintptr(opts.EventsFD), "events")
+		if _, err := f.Stat(); err != nil {
+			return nil, errors.Wrapf(err, "invalid events file descriptor: %d", opts.EventsFD)
+		}
+		return f, nil
+	}
+	return nil, nil
+}
@@ -1,13 +1,27 @@
 package main
 
 import (
-	"log"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
 
 	"github.com/criticalstack/crit/cmd/crit/app"
+	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/trace"
 )
 
 func main() {
-	if err := app.NewCommand().Execute(); err != nil {
+	err := app.NewCommand().Execute()
+
+	// spans are exported before exiting, so that they are available when
+	// the command fails
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := trace.Shutdown(ctx); err != nil {
+		log.Warn("cannot export trace spans", zap.Error(err))
+	}
+	cancel()
+	if err != nil {
 		log.Fatal(err)
 	}
 }
@@ -5,7 +5,6 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net"
 	"net/http"
 	"net/url"
@@ -14,6 +13,9 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/spf13/cobra"
+
+	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/log/echolog"
 )
 
 var opts struct {
@@ -24,6 +26,7 @@ var opts struct {
 	KeyFile           string
 	Port              int
 	APIServerBindPort int
+	Log               log.Options
 }
 
 func NewCommand() *cobra.Command {
@@ -33,6 +36,9 @@ func NewCommand() *cobra.Command {
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.Configure(&opts.Log); err != nil {
+				return err
+			}
 			caCert, err := ioutil.ReadFile(opts.CAFile)
 			if err != nil {
 				return err
@@ -56,7 +62,7 @@ func NewCommand() *cobra.Command {
 				return err
 			}
 			e := echo.New()
-			e.Use(middleware.Logger())
+			e.Use(echolog.Logger(nil))
 			e.Use(middleware.Recover())
 			e.Use(middleware.ProxyWithConfig(middleware.ProxyConfig{
 				Balancer: middleware.NewRoundRobinBalancer([]*middleware.ProxyTarget{{URL: u}}),
@@ -90,6 +96,7 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.TLSKeyFile, "tls-private-key-file", "/etc/kubernetes/pki/apiserver.key", "")
 	cmd.Flags().IntVar(&opts.Port, "secure-port", 6444, "")
 	cmd.Flags().IntVar(&opts.APIServerBindPort, "apiserver-port", 6443, "")
+	opts.Log.AddFlags(cmd.Flags())
 	return cmd
 }
 
@@ -16,6 +16,7 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/procfs v0.0.2
 	github.com/spf13/cobra v1.0.0
+	github.com/spf13/pflag v1.0.5
 	go.uber.org/zap v1.15.0
 	google.golang.org/grpc v1.29.1
 	k8s.io/api v0.18.5
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// genschemadocs extracts the godoc comments of configuration types, so that
+// they can be used as descriptions and defaults in the generated JSON
+// schemas. Comments are not available with reflection, so these are
+// generated ahead of time.
+
+type fieldDoc struct {
+	Description string
+	Default     string
+	Enum        []string
+}
+
+type typeDoc struct {
+	Description string
+	Enum        []string
+	Fields      map[string]fieldDoc
+}
+
+func main() {
+	var headerFile string
+
+	cmd := &cobra.Command{
+		Use:   "genschemadocs [output] [package dirs...]",
+		Short: "Generate JSON schema descriptions from configuration type comments",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			module, err := readModulePath("go.mod")
+			if err != nil {
+				return err
+			}
+			docs := make(map[string]typeDoc)
+			for _, dir := range args[1:] {
+				importPath := path.Join(module, filepath.ToSlash(filepath.Clean(dir)))
+				if err := parsePackage(dir, importPath, docs); err != nil {
+					return err
+				}
+			}
+			var header []byte
+			if headerFile != "" {
+				header, err = ioutil.ReadFile(headerFile)
+				if err != nil {
+					return err
+				}
+			}
+			data, err := generate(header, docs)
+			if err != nil {
+				return err
+			}
+			return ioutil.WriteFile(args[0], data, 0644)
+		},
+	}
+	cmd.Flags().StringVar(&headerFile, "go-header-file", "", "file containing the header for generated files")
+	if err := cmd.Execute(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readModulePath(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+		}
+	}
+	return "", fmt.Errorf("cannot find module path in %s", path)
+}
+
+func parsePackage(dir, importPath string, docs map[string]typeDoc) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && !strings.HasPrefix(fi.Name(), "zz_generated")
+	}, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					if !ts.Name.IsExported() {
+						continue
+					}
+					doc := ts.Doc
+					if doc == nil && len(gd.Specs) == 1 {
+						doc = gd.Doc
+					}
+					var td typeDoc
+					td.Description, _, td.Enum = parseComment(doc)
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						td.Fields = parseFields(st)
+					}
+					if td.Description == "" && len(td.Enum) == 0 && len(td.Fields) == 0 {
+						continue
+					}
+					docs[importPath+"."+ts.Name.Name] = td
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func parseFields(st *ast.StructType) map[string]fieldDoc {
+	fields := make(map[string]fieldDoc)
+	for _, field := range st.Fields.List {
+		var fd fieldDoc
+		fd.Description, fd.Default, fd.Enum = parseComment(field.Doc)
+		if fd.Description == "" && fd.Default == "" && len(fd.Enum) == 0 {
+			continue
+		}
+		for _, name := range field.Names {
+			if name.IsExported() {
+				fields[name.Name] = fd
+			}
+		}
+		if len(field.Names) == 0 && field.Tag != nil {
+			// embedded fields are only documented when they are not inlined
+			tag, _ := strconv.Unquote(field.Tag.Value)
+			if name := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]; name != "" {
+				fields[embeddedName(field.Type)] = fd
+			}
+		}
+	}
+	return fields
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// parseComment separates a doc comment into the description, the value of a
+// "Default:" line and the values of an enum marker. Other markers, such as
+// +optional, are removed, along with TODO and NOTE comments meant for
+// developers.
+func parseComment(cg *ast.CommentGroup) (desc, def string, enum []string) {
+	if cg == nil {
+		return "", "", nil
+	}
+	var b strings.Builder
+	newParagraph := false
+	skip := false
+	for _, line := range strings.Split(cg.Text(), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			newParagraph = b.Len() > 0
+			continue
+		case strings.HasPrefix(trimmed, "+kubebuilder:validation:Enum="):
+			enum = strings.Split(strings.TrimPrefix(trimmed, "+kubebuilder:validation:Enum="), ";")
+			continue
+		case strings.HasPrefix(trimmed, "+"):
+			continue
+		case strings.HasPrefix(trimmed, "Default:"):
+			def = strings.TrimSpace(strings.TrimPrefix(trimmed, "Default:"))
+			continue
+		case strings.HasPrefix(trimmed, "TODO(") || strings.HasPrefix(trimmed, "NOTE("):
+			skip = true
+		}
+		if skip {
+			continue
+		}
+		switch {
+		case b.Len() == 0:
+		case newParagraph:
+			b.WriteString("\n\n")
+		case strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"):
+			// indented lines, such as examples and links, are kept on their
+			// own line
+			b.WriteString("\n")
+		default:
+			b.WriteString(" ")
+		}
+		newParagraph = false
+		b.WriteString(trimmed)
+	}
+	return b.String(), def, enum
+}
+
+func generate(header []byte, docs map[string]typeDoc) ([]byte, error) {
+	var buf bytes.Buffer
+	if len(header) > 0 {
+		buf.Write(header)
+		buf.WriteString("\n")
+	}
+	buf.WriteString("// Code generated by genschemadocs. DO NOT EDIT.\n\n")
+	buf.WriteString("package jsonschema\n\n")
+	buf.WriteString("var typeDocs = map[string]typeDoc{\n")
+	names := make([]string, 0, len(docs))
+	for name := range docs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		td := docs[name]
+		fmt.Fprintf(&buf, "%q: {\n", name)
+		if td.Description != "" {
+			fmt.Fprintf(&buf, "Description: %q,\n", td.Description)
+		}
+		if len(td.Enum) > 0 {
+			fmt.Fprintf(&buf, "Enum: %#v,\n", td.Enum)
+		}
+		if len(td.Fields) > 0 {
+			buf.WriteString("Fields: map[string]fieldDoc{\n")
+			fieldNames := make([]string, 0, len(td.Fields))
+			for fieldName := range td.Fields {
+				fieldNames = append(fieldNames, fieldName)
+			}
+			sort.Strings(fieldNames)
+			for _, fieldName := range fieldNames {
+				fd := td.Fields[fieldName]
+				fmt.Fprintf(&buf, "%q: {", fieldName)
+				if fd.Description != "" {
+					fmt.Fprintf(&buf, "Description: %q, ", fd.Description)
+				}
+				if fd.Default != "" {
+					fmt.Fprintf(&buf, "Default: %q, ", fd.Default)
+				}
+				if len(fd.Enum) > 0 {
+					fmt.Fprintf(&buf, "Enum: %#v, ", fd.Enum)
+				}
+				buf.WriteString("},\n")
+			}
+			buf.WriteString("},\n")
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
@@ -12,6 +12,7 @@ cd "${TOOLS_DIR}"
 go build -o "bin/defaulter-gen" k8s.io/code-generator/cmd/defaulter-gen
 go build -o "bin/deepcopy-gen" k8s.io/code-generator/cmd/deepcopy-gen
 go build -o "bin/conversion-gen" k8s.io/code-generator/cmd/conversion-gen
+go build -o "bin/genschemadocs" ./genschemadocs
 cd "${REPO_ROOT}"
 
 # run generators
@@ -26,5 +27,8 @@ cd "${REPO_ROOT}"
 "${TOOLS_BIN}/deepcopy-gen" -i ./internal/cinder/config/v1alpha1 -o . -O zz_generated.deepcopy --go-header-file hack/boilerplate.go.txt
 "${TOOLS_BIN}/defaulter-gen" -i ./internal/cinder/config/v1alpha1 -o . -O zz_generated.default --go-header-file hack/boilerplate.go.txt
 
+"${TOOLS_BIN}/genschemadocs" --go-header-file hack/boilerplate.go.txt pkg/config/jsonschema/zz_generated.docs.go \
+    ./pkg/cluster/components/util ./pkg/config/v1alpha1 ./pkg/config/v1alpha2 ./internal/cinder/config/v1alpha1
+
 # gofmt the tree
 find . -name "*.go" -type f -print0 | xargs -0 gofmt -s -w
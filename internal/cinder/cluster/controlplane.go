@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"k8s.io/utils/pointer"
@@ -24,9 +25,6 @@ var (
 		"e2d pki gencerts --ca-cert /etc/kubernetes/pki/etcd/ca.crt --ca-key /etc/kubernetes/pki/etcd/ca.key --output-dir /etc/kubernetes/pki/etcd",
 		"systemctl enable --now e2d",
 	}
-	postCritCommands = []string{
-		"kubectl taint nodes --all node-role.kubernetes.io/master-",
-	}
 )
 
 type ControlPlaneConfig struct {
@@ -86,7 +84,6 @@ func CreateControlPlaneNode(ctx context.Context, cfg *ControlPlaneConfig) (*Node
 	if shouldRestartContainerd(cfg.ClusterConfiguration.Files) {
 		cfg.ClusterConfiguration.PreCritCommands = append([]string{"systemctl restart containerd"}, cfg.ClusterConfiguration.PreCritCommands...)
 	}
-	cfg.ClusterConfiguration.PostCritCommands = append(postCritCommands, cfg.ClusterConfiguration.PostCritCommands...)
 	if err := node.RunCloudInit(cfg.ClusterConfiguration); err != nil {
 		return node, err
 	}
@@ -106,6 +103,11 @@ func SetControlPlaneConfigurationDefaults(cfg *critconfig.ControlPlaneConfigurat
 		cfg.KubeControllerManagerConfiguration.ExtraArgs = make(map[string]string)
 	}
 	cfg.KubeControllerManagerConfiguration.ExtraArgs["enable-hostpath-provisioner"] = "true"
+	// workloads are scheduled on the control plane node, since it may be the
+	// only node in the cluster
+	if cfg.ControlPlaneNode.Taints == nil {
+		cfg.ControlPlaneNode.Taints = make([]corev1.Taint, 0)
+	}
 	if cfg.NodeConfiguration.KubeletConfiguration == nil {
 		cfg.NodeConfiguration.KubeletConfiguration = &kubeletconfigv1beta1.KubeletConfiguration{}
 	}
@@ -77,6 +77,12 @@ func (n *Node) ReadFile(path string) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// SupportBundle runs crit support-bundle on the node and writes the bundle
+// to w.
+func (n *Node) SupportBundle(w io.Writer) error {
+	return n.Command("crit", "support-bundle", "-c", "/var/lib/crit/config.yaml", "-o", "-").SetStdout(w).Run()
+}
+
 func (n *Node) MkdirAll(path string, perm os.FileMode) error {
 	return n.Command("mkdir", "-p", path).Run()
 }
@@ -126,7 +132,9 @@ func (n *Node) RunCloudInit(cfg *config.ClusterConfiguration) (err error) {
 	if err := n.Command("bash", "/cinder/scripts/pre-up.sh").Run(); err != nil {
 		return err
 	}
-	return n.Command("crit", "up", "-c", "/var/lib/crit/config.yaml").Run()
+	// cinder nodes are containers sharing the kernel of the host, so host
+	// checks such as swap and sysctls do not reflect the node itself
+	return n.Command("crit", "up", "-c", "/var/lib/crit/config.yaml", "--ignore-preflight-errors=all").Run()
 }
 
 func (n *Node) SystemdReady(ctx context.Context) error {
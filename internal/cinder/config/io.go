@@ -16,6 +16,24 @@ func LoadFromFile(path string) (*ClusterConfiguration, error) {
 	if err != nil {
 		return nil, err
 	}
+	return unmarshal(data)
+}
+
+// LoadFromFileStrict is the same as LoadFromFile, but returns an error if the
+// config file contains unknown fields, including those of the embedded crit
+// configs.
+func LoadFromFileStrict(path string) (*ClusterConfiguration, error) {
+	data, err := configutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := configutil.CheckUnknownFields(data); err != nil {
+		return nil, err
+	}
+	return unmarshal(data)
+}
+
+func unmarshal(data []byte) (*ClusterConfiguration, error) {
 	v, err := yamlutil.UnmarshalFromYaml(data, SchemeGroupVersion)
 	if err != nil {
 		return nil, err
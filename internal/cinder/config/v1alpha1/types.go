@@ -59,7 +59,7 @@ type Mount struct {
 }
 
 // Encoding specifies the cloud-init file encoding.
-// +kubebuilder:validation:Enum=base64;gzip;gzip+base64
+// +kubebuilder:validation:Enum=base64;gzip;gzip+base64;hostpath
 type Encoding string
 
 const (
@@ -13,10 +13,17 @@ type (
 	ControlPlaneConfiguration          = externalconfig.ControlPlaneConfiguration
 	WorkerConfiguration                = externalconfig.WorkerConfiguration
 	NodeConfiguration                  = externalconfig.NodeConfiguration
+	NodeAddressConfiguration           = externalconfig.NodeAddressConfiguration
+	NodeOverride                       = externalconfig.NodeOverride
 	EtcdConfiguration                  = externalconfig.EtcdConfiguration
 	CritBootstrapServerConfiguration   = externalconfig.CritBootstrapServerConfiguration
 	KubeAPIServerConfiguration         = externalconfig.KubeAPIServerConfiguration
 	KubeControllerManagerConfiguration = externalconfig.KubeControllerManagerConfiguration
+	PodSecurityConfiguration           = externalconfig.PodSecurityConfiguration
+	OIDCConfiguration                  = externalconfig.OIDCConfiguration
+	AddonConfiguration                 = externalconfig.AddonConfiguration
+	ImagesConfiguration                = externalconfig.ImagesConfiguration
+	ImageOverride                      = externalconfig.ImageOverride
 )
 
 var SchemeGroupVersion = externalconfig.SchemeGroupVersion
@@ -0,0 +1,255 @@
+package cluster
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/kubernetes/dynamic"
+	yamlutil "github.com/criticalstack/crit/pkg/kubernetes/yaml"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+// nonAddonTemplates are embedded templates that cannot be applied as addons,
+// either because they are not Kubernetes manifests or because they are
+// deployed by a workflow step that also creates the resources they depend
+// upon (e.g. the kube-proxy ConfigMap and RBAC).
+var nonAddonTemplates = map[string]bool{
+	"audit-policy":   true,
+	"coredns":        true,
+	"kube-proxy":     true,
+	"psp-privileged": true,
+	"psp-restricted": true,
+}
+
+// EmbeddedAddons returns the names of the embedded templates that can be
+// applied as addons.
+func EmbeddedAddons() ([]string, error) {
+	d, err := Files.Open("/")
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	files, err := d.Readdir(-1)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".yaml" {
+			continue
+		}
+		name := strings.TrimSuffix(f.Name(), ".yaml")
+		if nonAddonTemplates[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// addonValues is provided to addon templates. The configuration is embedded
+// so that addon templates have access to the same fields as other embedded
+// templates.
+type addonValues struct {
+	*config.ControlPlaneConfiguration
+	Values map[string]string
+}
+
+// RenderAddon renders the manifests for an addon. Embedded addons are
+// referenced by name, while local addons can be either a single manifest file
+// or a directory of manifest files, which are rendered in lexical order.
+func RenderAddon(cfg *config.ControlPlaneConfiguration, addon *config.AddonConfiguration) ([]byte, error) {
+	v := &addonValues{
+		ControlPlaneConfiguration: cfg,
+		Values:                    addon.Values,
+	}
+	if v.Values == nil {
+		v.Values = make(map[string]string)
+	}
+	switch {
+	case addon.Name != "" && addon.Path != "":
+		return nil, errors.Errorf("addon %q cannot specify both name and path", addon.Name)
+	case addon.Name != "":
+		if nonAddonTemplates[addon.Name] {
+			return nil, errors.Errorf("embedded template %q is not an addon", addon.Name)
+		}
+		return Execute(addon.Name+".yaml", v)
+	case addon.Path != "":
+		info, err := os.Stat(addon.Path)
+		if err != nil {
+			return nil, err
+		}
+		paths := []string{addon.Path}
+		if info.IsDir() {
+			paths = paths[:0]
+			files, err := ioutil.ReadDir(addon.Path)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range files {
+				switch filepath.Ext(f.Name()) {
+				case ".yaml", ".yml", ".json":
+					paths = append(paths, filepath.Join(addon.Path, f.Name()))
+				}
+			}
+		}
+		docs := make([]string, 0)
+		for _, path := range paths {
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			data, err = ExecuteTemplate(path, data, v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot render addon file %q", path)
+			}
+			docs = append(docs, string(data))
+		}
+		return []byte(strings.Join(docs, "\n---\n")), nil
+	default:
+		return nil, errors.New("addon must specify either name or path")
+	}
+}
+
+// AddonName returns a name suitable for identifying an addon in log output.
+func AddonName(addon *config.AddonConfiguration) string {
+	if addon.Name != "" {
+		return addon.Name
+	}
+	return addon.Path
+}
+
+// ApplyAddon renders and applies an addon. Objects are applied server-side, so
+// applying an addon again updates any existing objects. Unless disabled, it
+// then waits for any workloads (Deployment, DaemonSet, StatefulSet) in the
+// addon to become ready.
+func ApplyAddon(ctx context.Context, restConfig *rest.Config, cfg *config.ControlPlaneConfiguration, addon *config.AddonConfiguration) error {
+	data, err := RenderAddon(cfg, addon)
+	if err != nil {
+		return err
+	}
+	if err := dynamic.Reconcile(ctx, restConfig, "crit", data); err != nil {
+		return err
+	}
+	if addon.SkipWait {
+		return nil
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	objs, err := yamlutil.UnmarshalFromYamlUnstructured(data)
+	if err != nil {
+		return err
+	}
+	timeout := addon.Timeout.Duration
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, obj := range objs {
+		ns := obj.GetNamespace()
+		if ns == "" {
+			ns = metav1.NamespaceSystem
+		}
+		var isReady func() (bool, error)
+		switch obj.GetKind() {
+		case "Deployment":
+			isReady = func() (bool, error) {
+				d, err := client.AppsV1().Deployments(ns).Get(ctx, obj.GetName(), metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				return deploymentReady(d), nil
+			}
+		case "DaemonSet":
+			isReady = func() (bool, error) {
+				ds, err := client.AppsV1().DaemonSets(ns).Get(ctx, obj.GetName(), metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				return daemonSetReady(ds), nil
+			}
+		case "StatefulSet":
+			isReady = func() (bool, error) {
+				ss, err := client.AppsV1().StatefulSets(ns).Get(ctx, obj.GetName(), metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				return statefulSetReady(ss), nil
+			}
+		default:
+			continue
+		}
+		log.Debug("waiting for addon workload", zap.String("kind", obj.GetKind()), zap.String("namespace", ns), zap.String("name", obj.GetName()))
+		if err := wait.PollImmediateUntil(2*time.Second, func() (bool, error) {
+			ok, err := isReady()
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					return false, nil
+				}
+				log.Debug("cannot get addon workload", zap.Error(err))
+				return false, nil
+			}
+			return ok, nil
+		}, ctx.Done()); err != nil {
+			return errors.Wrapf(err, "timed out waiting for %s %s/%s to become ready", obj.GetKind(), ns, obj.GetName())
+		}
+	}
+	return nil
+}
+
+func deploymentReady(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation && d.Status.UpdatedReplicas >= replicas && d.Status.AvailableReplicas >= replicas
+}
+
+func daemonSetReady(ds *appsv1.DaemonSet) bool {
+	return ds.Status.ObservedGeneration >= ds.Generation && ds.Status.DesiredNumberScheduled > 0 && ds.Status.NumberAvailable >= ds.Status.DesiredNumberScheduled
+}
+
+func statefulSetReady(ss *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if ss.Spec.Replicas != nil {
+		replicas = *ss.Spec.Replicas
+	}
+	return ss.Status.ObservedGeneration >= ss.Generation && ss.Status.ReadyReplicas >= replicas
+}
+
+// ApplyAddons applies all addons found in the configuration in order.
+func (c *Cluster) ApplyAddons(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
+	if len(cfg.Addons) == 0 {
+		return nil
+	}
+	log.Info("addons", zap.String("description", "apply cluster addons"))
+	for i := range cfg.Addons {
+		addon := &cfg.Addons[i]
+		log.Info("applying addon", zap.String("addon", AddonName(addon)))
+		if err := ApplyAddon(ctx, c.Config(), cfg, addon); err != nil {
+			return errors.Wrapf(err, "cannot apply addon %q", AddonName(addon))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package cluster
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/criticalstack/crit/internal/config"
+)
+
+func TestEmbeddedAddons(t *testing.T) {
+	names, err := EmbeddedAddons()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatal("expected embedded addons")
+	}
+	for _, name := range names {
+		if nonAddonTemplates[name] {
+			t.Errorf("expected %q to not be listed as an addon", name)
+		}
+		if _, err := RenderAddon(&config.ControlPlaneConfiguration{}, &config.AddonConfiguration{Name: name}); err != nil {
+			t.Errorf("cannot render addon %q: %v", name, err)
+		}
+	}
+}
+
+func TestRenderAddon(t *testing.T) {
+	cases := []struct {
+		name     string
+		addon    config.AddonConfiguration
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "default values",
+			addon:    config.AddonConfiguration{Name: "metrics-server"},
+			expected: []string{"replicas: 1\n"},
+		},
+		{
+			name: "values",
+			addon: config.AddonConfiguration{
+				Name: "metrics-server",
+				Values: map[string]string{
+					"replicas":           "3",
+					"kubeletInsecureTLS": "true",
+				},
+			},
+			expected: []string{"replicas: 3\n", "- --kubelet-insecure-tls\n"},
+		},
+		{
+			name:  "not an addon",
+			addon: config.AddonConfiguration{Name: "kube-proxy"},
+			err:   true,
+		},
+		{
+			name:  "unknown addon",
+			addon: config.AddonConfiguration{Name: "unknown"},
+			err:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := RenderAddon(&config.ControlPlaneConfiguration{}, &tc.addon)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tc.expected {
+				if !strings.Contains(string(data), s) {
+					t.Errorf("expected rendered addon to contain %q", s)
+				}
+			}
+		})
+	}
+}
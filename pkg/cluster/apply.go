@@ -0,0 +1,380 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/criticalstack/crit/internal/config"
+	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	clusterutil "github.com/criticalstack/crit/pkg/cluster/util"
+	"github.com/criticalstack/crit/pkg/kubernetes/pki"
+	"github.com/criticalstack/crit/pkg/kubernetes/remote"
+	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/util/diff"
+	executil "github.com/criticalstack/crit/pkg/util/exec"
+)
+
+const kubeletComponent = "kubelet"
+
+// ArtifactChange is a file derived from the control plane config that
+// differs from the file on disk.
+type ArtifactChange struct {
+	Path string
+
+	// Missing is true when the file does not exist on disk.
+	Missing bool
+
+	Changes []diff.Change
+
+	// Components are the names of the static pod components, or the
+	// kubelet, that must be restarted for the change to take effect.
+	Components []string
+
+	write func(ca *pki.CertificateAuthority) error
+}
+
+// ApplyPlan is the set of artifacts that are rewritten when applying a
+// config to a control plane node that has already been bootstrapped.
+type ApplyPlan struct {
+	Artifacts []*ArtifactChange
+}
+
+// Empty returns true if there are no changes to apply.
+func (p *ApplyPlan) Empty() bool {
+	return len(p.Artifacts) == 0
+}
+
+// Components returns the names of the components affected by the plan.
+func (p *ApplyPlan) Components() []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, a := range p.Artifacts {
+		for _, name := range a.Components {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Print writes the changes of each artifact.
+func (p *ApplyPlan) Print(w io.Writer) {
+	for _, a := range p.Artifacts {
+		fmt.Fprintf(w, "%s:\n", a.Path)
+		if a.Missing {
+			fmt.Fprintf(w, "\tfile does not exist\n")
+		}
+		for _, c := range a.Changes {
+			fmt.Fprintf(w, "\t%s\n", c)
+		}
+	}
+	if names := p.Components(); len(names) > 0 {
+		fmt.Fprintf(w, "components to restart: %v\n", names)
+	}
+}
+
+// PlanApply compares the serving certificate, kubeconfigs and static pod
+// manifests produced by CreateNodeCerts, WriteKubeConfigs and
+// WriteKubeManifests with the files on disk. The config should have runtime
+// defaults applied with ResolveControlPlaneConfig. Artifacts are ordered so
+// that certificates and kubeconfigs are written before the manifests that
+// use them.
+func PlanApply(cfg *config.ControlPlaneConfiguration) (*ApplyPlan, error) {
+	p := &ApplyPlan{}
+	if a, err := planAPIServerCert(cfg); err != nil {
+		return nil, err
+	} else if a != nil {
+		p.Artifacts = append(p.Artifacts, a)
+	}
+	kubeconfigs, err := planKubeConfigs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.Artifacts = append(p.Artifacts, kubeconfigs...)
+	diffs, err := DiffKubeManifests(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := KubeManifests(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range diffs {
+		pod := pods[d.Path]
+		path := d.Path
+		p.Artifacts = append(p.Artifacts, &ArtifactChange{
+			Path:       d.Path,
+			Missing:    d.Missing,
+			Changes:    d.Changes,
+			Components: []string{pod.Spec.Containers[0].Name},
+			write: func(*pki.CertificateAuthority) error {
+				return computil.WriteKubeComponent(pod, path)
+			},
+		})
+	}
+	return p, nil
+}
+
+func planAPIServerCert(cfg *config.ControlPlaneConfiguration) (*ArtifactChange, error) {
+	dir := filepath.Join(cfg.NodeConfiguration.KubeDir, "pki")
+	certConfig, err := clusterutil.APIServerCertConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	a := &ArtifactChange{
+		Path:       filepath.Join(dir, "apiserver.crt"),
+		Components: []string{"kube-apiserver"},
+		write: func(ca *pki.CertificateAuthority) error {
+			kp, err := ca.NewSignedKeyPair("apiserver", certConfig)
+			if err != nil {
+				return err
+			}
+			return kp.WriteFiles(dir)
+		},
+	}
+	cert, err := pki.ReadCertFromFile(a.Path)
+	if os.IsNotExist(errors.Cause(err)) {
+		a.Missing = true
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	current := subjectAltNames(cert.DNSNames, cert.IPAddresses)
+	desired := subjectAltNames(certConfig.AltNames.DNSNames, certConfig.AltNames.IPs)
+	// SANs are compared as sets, since their order is not significant
+	a.Changes = make([]diff.Change, 0)
+	for _, name := range difference(current, desired) {
+		a.Changes = append(a.Changes, diff.Change{Path: "subjectAltNames", Old: name})
+	}
+	for _, name := range difference(desired, current) {
+		a.Changes = append(a.Changes, diff.Change{Path: "subjectAltNames", New: name})
+	}
+	if len(a.Changes) == 0 {
+		return nil, nil
+	}
+	return a, nil
+}
+
+// difference returns the items of a that are not in b.
+func difference(a, b []string) []string {
+	s := make(map[string]bool)
+	for _, item := range b {
+		s[item] = true
+	}
+	result := make([]string, 0)
+	for _, item := range a {
+		if !s[item] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// subjectAltNames returns the sorted, unique DNS names and IP addresses of a
+// certificate.
+func subjectAltNames(dnsNames []string, ips []net.IP) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, name := range dnsNames {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, ip := range ips {
+		if s := ip.String(); !seen[s] {
+			seen[s] = true
+			names = append(names, s)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// kubeConfigComponents are the components that use each kubeconfig.
+var kubeConfigComponents = map[string][]string{
+	clusterutil.ControllerManagerFilename: {"kube-controller-manager"},
+	clusterutil.SchedulerFilename:         {"kube-scheduler"},
+	clusterutil.KubeletFilename:           {kubeletComponent},
+}
+
+func planKubeConfigs(cfg *config.ControlPlaneConfiguration) ([]*ArtifactChange, error) {
+	specs := clusterutil.KubeConfigSpecs(cfg)
+	filenames := make([]string, 0, len(specs))
+	for filename := range specs {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	changes := make([]*ArtifactChange, 0)
+	for _, filename := range filenames {
+		spec := specs[filename]
+		a := &ArtifactChange{
+			Path:       filepath.Join(cfg.NodeConfiguration.KubeDir, filename),
+			Components: kubeConfigComponents[filename],
+		}
+		path := a.Path
+		a.write = func(ca *pki.CertificateAuthority) error {
+			return clusterutil.WriteKubeConfig(spec, ca, path)
+		}
+		current, err := clusterutil.ReadKubeConfigSpec(a.Path)
+		if os.IsNotExist(errors.Cause(err)) {
+			a.Missing = true
+			changes = append(changes, a)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		a.Changes, err = diff.Objects(current, spec)
+		if err != nil {
+			return nil, err
+		}
+		if len(a.Changes) > 0 {
+			changes = append(changes, a)
+		}
+	}
+	return changes, nil
+}
+
+// Apply rewrites the changed artifacts of the plan and restarts the affected
+// components, waiting for them to become healthy. Static pods with a changed
+// manifest are restarted by the kubelet, while the containers of components
+// with changed certificates or kubeconfigs are stopped so that the kubelet
+// recreates them.
+func (c *Cluster) Apply(ctx context.Context, cfg *config.ControlPlaneConfiguration, p *ApplyPlan) error {
+	log.Info("apply", zap.String("description", "rewrite changed control plane artifacts"))
+	if p.Empty() {
+		return nil
+	}
+	ca, err := pki.LoadCertificateAuthority(filepath.Join(cfg.NodeConfiguration.KubeDir, "pki"), "ca")
+	if err != nil {
+		return err
+	}
+	r, err := remote.NewRuntimeServiceClient(ctx, cfg.NodeConfiguration.ContainerRuntime.CRISocket())
+	if err != nil {
+		return err
+	}
+
+	// the current containers are recorded so that the components are only
+	// considered healthy once they have been replaced
+	components := make([]string, 0)
+	previous := make(map[string]string)
+	restartKubelet := false
+	for _, name := range p.Components() {
+		if name == kubeletComponent {
+			restartKubelet = true
+			continue
+		}
+		components = append(components, name)
+		if container, err := r.GetLatestContainerByName(ctx, name); err == nil {
+			previous[name] = container.Id
+		}
+	}
+	manifestChanged := make(map[string]bool)
+	for _, a := range p.Artifacts {
+		if err := a.write(ca); err != nil {
+			return errors.Wrapf(err, "cannot write %s", a.Path)
+		}
+		log.Info("artifact written", zap.String("path", a.Path))
+		if filepath.Dir(a.Path) == filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests") {
+			for _, name := range a.Components {
+				manifestChanged[name] = true
+			}
+		}
+	}
+	if restartKubelet {
+		if err := c.StopKubelet(ctx, &cfg.NodeConfiguration); err != nil {
+			return err
+		}
+		if err := c.StartKubelet(ctx, &cfg.NodeConfiguration); err != nil {
+			return err
+		}
+	}
+	for _, name := range components {
+		id, ok := previous[name]
+		if !ok || manifestChanged[name] {
+			continue
+		}
+		log.Info("restarting component", zap.String("component", name))
+		if _, err := r.StopContainer(ctx, &runtimeapi.StopContainerRequest{ContainerId: id, Timeout: 30}); err != nil {
+			return errors.Wrapf(err, "cannot stop %s container", name)
+		}
+	}
+	if len(components) == 0 {
+		return nil
+	}
+	return c.waitComponentsReplaced(ctx, cfg, r, components, previous)
+}
+
+// waitComponentsReplaced waits for each component to be running in a new
+// container and to report as healthy.
+func (c *Cluster) waitComponentsReplaced(ctx context.Context, cfg *config.ControlPlaneConfiguration, r *remote.RuntimeServiceClient, components []string, previous map[string]string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.componentTimeout())
+	defer cancel()
+
+	manifests := make([]string, 0)
+	for _, name := range components {
+		manifests = append(manifests, filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests", name+".yaml"))
+	}
+	w, err := newComponentWatcher(r, manifests)
+	if err != nil {
+		return err
+	}
+	for name, id := range previous {
+		if seen, ok := w.seen[name]; ok {
+			seen[id] = true
+		}
+	}
+
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	var statuses []ComponentStatus
+	for {
+		select {
+		case <-ticker.C:
+			statuses = w.Check(ctx)
+			for i := range statuses {
+				// replacing the container is expected, and is not counted
+				// as a restart
+				if statuses[i].Restarts > 0 {
+					statuses[i].Restarts--
+				}
+				if statuses[i].ContainerID == previous[statuses[i].Name] {
+					statuses[i].Healthy = false
+				}
+			}
+			if err := componentsFailed(statuses); err != nil {
+				return c.applyFailure(statuses, err)
+			}
+			if componentsHealthy(statuses) {
+				return nil
+			}
+		case <-ctx.Done():
+			return c.applyFailure(statuses, errors.Wrap(ctx.Err(), "timed out waiting for components to become healthy"))
+		}
+	}
+}
+
+func (c *Cluster) applyFailure(statuses []ComponentStatus, reterr error) error {
+	stderr := executil.NewPrefixWriter(os.Stderr, "\t")
+	defer stderr.Close()
+
+	printComponentStatuses(stderr, statuses)
+	return reterr
+}
@@ -40,6 +40,11 @@ func (at *AuthorizationType) UnmarshalText(data []byte) error {
 type Request struct {
 	Type AuthorizationType `json:"type"`
 	Body json.RawMessage   `json:"body"`
+
+	// Labels and Annotations are applied to the Node by the bootstrap server
+	// once it has registered, since they cannot be set by the kubelet.
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 type Response struct {
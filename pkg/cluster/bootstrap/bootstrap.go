@@ -19,6 +19,7 @@ import (
 	"github.com/criticalstack/crit/internal/config"
 	"github.com/criticalstack/crit/pkg/cluster/bootstrap/authorizers/ec2metadata"
 	"github.com/criticalstack/crit/pkg/kubeconfig"
+	nodeutil "github.com/criticalstack/crit/pkg/kubernetes/util/node"
 	"github.com/criticalstack/crit/pkg/log"
 )
 
@@ -89,9 +90,12 @@ func GetBootstrapKubeletKubeconfig(cfg *config.WorkerConfiguration) (*clientcmda
 		if err != nil {
 			return nil, err
 		}
+		_, restricted := nodeutil.SplitLabels(cfg.NodeConfiguration.Labels)
 		data, err = json.Marshal(&Request{
-			Type: AmazonIdentityDocumentAndSignature,
-			Body: body,
+			Type:        AmazonIdentityDocumentAndSignature,
+			Body:        body,
+			Labels:      restricted,
+			Annotations: cfg.NodeConfiguration.Annotations,
 		})
 		if err != nil {
 			return nil, err
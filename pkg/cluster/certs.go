@@ -15,6 +15,7 @@ import (
 	"github.com/criticalstack/crit/internal/config"
 	clusterutil "github.com/criticalstack/crit/pkg/cluster/util"
 	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/trace"
 )
 
 // sharedClusterFiles represents files that must be shared by all nodes in the
@@ -48,7 +49,7 @@ func (c *Cluster) CreateOrDownloadCerts(ctx context.Context, cfg *config.Control
 	} else {
 		log.Warn("The etcd CAKey was not specified in the provided configuration. Without it, the shared clusters files cannot be encrypted at rest.")
 	}
-	db, err := e2db.New(ctx, &e2db.Config{
+	db, err := newTracedDB(ctx, &e2db.Config{
 		ClientAddr: cfg.EtcdConfiguration.ClientAddr(),
 		CAFile:     cfg.EtcdConfiguration.CAFile,
 		CertFile:   cfg.EtcdConfiguration.CertFile,
@@ -64,11 +65,14 @@ func (c *Cluster) CreateOrDownloadCerts(ctx context.Context, cfg *config.Control
 		zap.String("etcd-address", cfg.EtcdConfiguration.ClientAddr()),
 	)
 
-	return db.Table(new(ClusterFile), opts...).Tx(func(tx *e2db.Tx) error {
+	var written []string
+	err = db.Table(new(ClusterFile), opts...).Tx(func(ctx context.Context, tx *e2db.Tx) error {
+		written = written[:0]
 		var files []*ClusterFile
 		if err := tx.All(&files); err != nil && errors.Cause(err) != e2db.ErrNoRows {
 			return err
 		}
+		trace.FromContext(ctx).SetAttributes(trace.Int("e2db.rows", len(files)))
 
 		if len(files) > 0 {
 			log.Info("existing cluster pki found")
@@ -76,6 +80,7 @@ func (c *Cluster) CreateOrDownloadCerts(ctx context.Context, cfg *config.Control
 				if err := f.Write(); err != nil {
 					return err
 				}
+				written = append(written, f.Name)
 			}
 			return nil
 		}
@@ -84,6 +89,13 @@ func (c *Cluster) CreateOrDownloadCerts(ctx context.Context, cfg *config.Control
 		// create them. This will only ever happen once for any given
 		// cluster.
 		log.Info("cluster pki not found in table, generating new pki locally ...")
+
+		// existing CAs are kept, so only the missing files are written
+		for _, path := range sharedClusterFiles {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				written = append(written, path)
+			}
+		}
 		fns := []func(string) error{
 			clusterutil.WriteClusterCA,
 			clusterutil.WriteFrontProxyCA,
@@ -107,22 +119,39 @@ func (c *Cluster) CreateOrDownloadCerts(ctx context.Context, cfg *config.Control
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	c.events.wrote(written...)
+	return nil
 }
 
 // CreateNodeCerts generates certs specific to the node. This should run after
 // the shared cluster certs have been created/downloaded.
 func (c *Cluster) CreateNodeCerts(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
 	log.Info("node-certs", zap.String("description", "create node certs"))
-	fns := []func(*config.ControlPlaneConfiguration) error{
-		clusterutil.WriteAPIServerCertAndKey,
-		clusterutil.WriteAPIServerKubeletClientCertAndKey,
-		clusterutil.WriteFrontProxyClientCertAndKey,
-		clusterutil.WriteAPIServerHealthcheckClientCertAndKey,
+	certs := []struct {
+		name  string
+		write func(*config.ControlPlaneConfiguration) error
+	}{
+		{"apiserver", clusterutil.WriteAPIServerCertAndKey},
+		{"apiserver-kubelet-client", clusterutil.WriteAPIServerKubeletClientCertAndKey},
+		{"front-proxy-client", clusterutil.WriteFrontProxyClientCertAndKey},
+		{"apiserver-healthcheck-client", clusterutil.WriteAPIServerHealthcheckClientCertAndKey},
 	}
-	for _, fn := range fns {
-		if err := fn(cfg); err != nil {
+	dir := filepath.Join(cfg.NodeConfiguration.KubeDir, "pki")
+	for _, cert := range certs {
+		// existing certs are kept, so they are only written when the key
+		// does not exist
+		key := filepath.Join(dir, cert.name+".key")
+		_, err := os.Stat(key)
+		exists := err == nil
+		if err := cert.write(cfg); err != nil {
 			return err
 		}
+		if !exists {
+			c.events.wrote(filepath.Join(dir, cert.name+".crt"), key)
+		}
 	}
 	return nil
 }
@@ -4,6 +4,7 @@ package cluster
 
 import (
 	"context"
+	"io"
 	"path/filepath"
 	"time"
 
@@ -16,17 +17,48 @@ import (
 	"github.com/criticalstack/crit/internal/config"
 	"github.com/criticalstack/crit/internal/feature"
 	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/trace"
 )
 
 type RuntimeConfig struct {
 	KubeletTimeout time.Duration
 	Verbose        bool
+
+	// IgnorePreflightErrors is a list of preflight checks whose errors are
+	// reported as warnings. The value "all" ignores the errors of every
+	// check.
+	IgnorePreflightErrors []string
+
+	// ComponentTimeout is the amount of time to wait for all control plane
+	// components to become healthy.
+	ComponentTimeout time.Duration
+
+	// FailureReportFile is the path the failure report is written to when
+	// the node fails to bootstrap.
+	FailureReportFile string
+
+	// FailureReportLines is the number of lines of the kubelet journal and
+	// container logs included in the failure report.
+	FailureReportLines int
+
+	// WaitNodeReady adds a final step to worker nodes that waits for the
+	// Node to register and become Ready.
+	WaitNodeReady bool
+
+	// NodeReadyTimeout is the amount of time to wait for the Node to become
+	// Ready.
+	NodeReadyTimeout time.Duration
+
+	// Events is written newline-delimited JSON events as each workflow step
+	// is started and finished, followed by a summary of the node.
+	Events io.Writer
 }
 
 type Cluster struct {
 	kubeConfigFile string
 	rc             *RuntimeConfig
 	fns            []interface{}
+	events         *eventWriter
 }
 
 func New(kubeConfigFile string, rc *RuntimeConfig) *Cluster {
@@ -37,6 +69,13 @@ func New(kubeConfigFile string, rc *RuntimeConfig) *Cluster {
 	}
 }
 
+func (c *Cluster) ignorePreflightErrors() []string {
+	if c.rc == nil {
+		return nil
+	}
+	return c.rc.IgnorePreflightErrors
+}
+
 func (c *Cluster) Add(fns ...interface{}) {
 	c.fns = append(c.fns, fns...)
 }
@@ -45,7 +84,9 @@ func (c *Cluster) Config() *rest.Config {
 	config, err := clientcmd.BuildConfigFromFlags("", c.kubeConfigFile)
 	if err != nil {
 		log.Debug("Cluster.Config", zap.Error(err))
+		return config
 	}
+	config.Wrap(trace.NewTransport)
 	return config
 }
 
@@ -64,8 +105,21 @@ type (
 )
 
 // RunControlPlane creates a new control plane node.
-func RunControlPlane(ctx context.Context, rc *RuntimeConfig, cfg *config.ControlPlaneConfiguration) error {
+func RunControlPlane(ctx context.Context, rc *RuntimeConfig, cfg *config.ControlPlaneConfiguration) (reterr error) {
+	ctx, span := trace.Start(ctx, "RunControlPlane", trace.SpanKindInternal)
+	defer func() {
+		span.SetError(reterr)
+		span.Finish()
+	}()
+	events := newEventWriter(rc)
+	defer func() {
+		events.summary(roleControlPlane, &cfg.NodeConfiguration, cfg.ControlPlaneEndpoint.String(), reterr)
+	}()
+	if err := ApplyNodeOverrides(ctx, cfg); err != nil {
+		return err
+	}
 	c := New(filepath.Join(cfg.NodeConfiguration.KubeDir, "admin.conf"), rc)
+	c.events = events
 
 	// set crit feature gates
 	if err := feature.MutableGates.SetFromMap(cfg.FeatureGates); err != nil {
@@ -79,8 +133,13 @@ func RunControlPlane(ctx context.Context, rc *RuntimeConfig, cfg *config.Control
 		c.WriteKubeConfigs,
 		c.WriteKubeletConfigs,
 		c.StartKubelet,
+		c.WritePodSecurityAdmissionConfig,
 		c.WriteKubeManifests,
 		c.WaitClusterAvailable,
+
+		// the pod security admission must be configured before any
+		// workloads are deployed, or their pods will be rejected
+		c.ApplyPodSecurity,
 	)
 	if feature.Gates.Enabled(feature.BootstrapServer) {
 		c.Add(c.WriteBootstrapServerManifest)
@@ -90,6 +149,7 @@ func RunControlPlane(ctx context.Context, rc *RuntimeConfig, cfg *config.Control
 		c.DeployKubeProxy,
 		c.EnableCSRApprover,
 		c.MarkControlPlane,
+		c.LabelNode,
 		c.UploadInfo,
 	)
 	if feature.Gates.Enabled(feature.AuthProxyCA) {
@@ -98,26 +158,35 @@ func RunControlPlane(ctx context.Context, rc *RuntimeConfig, cfg *config.Control
 	if feature.Gates.Enabled(feature.UploadETCDSecrets) {
 		c.Add(c.UploadETCDSecrets)
 	}
-	for _, fn := range c.fns {
+	c.Add(c.ApplyAddons)
+	return c.runSteps(ctx, func(ctx context.Context, fn interface{}) error {
 		switch fn := fn.(type) {
 		case controlPlaneFunc:
-			if err := fn(ctx, cfg); err != nil {
-				return err
-			}
+			return fn(ctx, cfg)
 		case nodeFunc:
-			if err := fn(ctx, &cfg.NodeConfiguration); err != nil {
-				return err
-			}
+			return fn(ctx, &cfg.NodeConfiguration)
 		default:
 			panic(errors.Errorf("invalid cluster workflow function: %T", fn))
 		}
-	}
-	return nil
+	})
 }
 
 // RunWorkerNode creates a new worker node.
-func RunWorkerNode(ctx context.Context, rc *RuntimeConfig, cfg *config.WorkerConfiguration) error {
+func RunWorkerNode(ctx context.Context, rc *RuntimeConfig, cfg *config.WorkerConfiguration) (reterr error) {
+	ctx, span := trace.Start(ctx, "RunWorkerNode", trace.SpanKindInternal)
+	defer func() {
+		span.SetError(reterr)
+		span.Finish()
+	}()
+	events := newEventWriter(rc)
+	defer func() {
+		events.summary(roleWorker, &cfg.NodeConfiguration, cfg.ControlPlaneEndpoint.String(), reterr)
+	}()
+	if err := ApplyNodeOverrides(ctx, cfg); err != nil {
+		return err
+	}
 	c := New(filepath.Join(cfg.NodeConfiguration.KubeDir, "kubelet.conf"), rc)
+	c.events = events
 
 	// set crit feature gates
 	if err := feature.MutableGates.SetFromMap(cfg.FeatureGates); err != nil {
@@ -130,19 +199,17 @@ func RunWorkerNode(ctx context.Context, rc *RuntimeConfig, cfg *config.WorkerCon
 		c.WriteKubeletConfigs,
 		c.StartKubelet,
 	)
-	for _, fn := range c.fns {
+	if rc != nil && rc.WaitNodeReady {
+		c.Add(c.WaitNodeReady)
+	}
+	return c.runSteps(ctx, func(ctx context.Context, fn interface{}) error {
 		switch fn := fn.(type) {
 		case workerFunc:
-			if err := fn(ctx, cfg); err != nil {
-				return err
-			}
+			return fn(ctx, cfg)
 		case nodeFunc:
-			if err := fn(ctx, &cfg.NodeConfiguration); err != nil {
-				return err
-			}
+			return fn(ctx, &cfg.NodeConfiguration)
 		default:
 			panic(errors.Errorf("invalid cluster workflow function: %T", fn))
 		}
-	}
-	return nil
+	})
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/criticalstack/crit/internal/config"
 	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	"github.com/criticalstack/crit/pkg/config/constants"
 	"github.com/criticalstack/crit/pkg/kubernetes/util/pointer"
 	"github.com/criticalstack/crit/pkg/log"
 )
@@ -30,10 +31,10 @@ func NewAPIServerStaticPod(cfg *config.ControlPlaneConfiguration) (*corev1.Pod,
 	certsDir := filepath.Join(cfg.NodeConfiguration.KubeDir, "pki")
 
 	defaultArguments := map[string]string{
-		"advertise-address":               cfg.NodeConfiguration.HostIPv4,
+		"advertise-address":               cfg.AdvertiseAddress(),
 		"insecure-port":                   "0",
 		"enable-admission-plugins":        "NodeRestriction",
-		"service-cluster-ip-range":        cfg.ServiceSubnet,
+		"service-cluster-ip-range":        strings.Join(cfg.ServiceSubnets, ","),
 		"service-account-key-file":        filepath.Join(certsDir, "sa.pub"),
 		"client-ca-file":                  filepath.Join(certsDir, "ca.crt"),
 		"tls-cert-file":                   filepath.Join(certsDir, "apiserver.crt"),
@@ -72,7 +73,15 @@ func NewAPIServerStaticPod(cfg *config.ControlPlaneConfiguration) (*corev1.Pod,
 		log.Warn(`ignoring apiserver extraArgs "secure-port", use BindPort instead`)
 	}
 	defaultArguments["authorization-mode"] = strings.Join(modes, ",")
-	defaultArguments["enable-admission-plugins"] = strings.Join(AdmissionPlugins, ",")
+	admissionPlugins := append([]string{}, AdmissionPlugins...)
+	if cfg.PodSecurity.Enabled {
+		if UsePodSecurityAdmission(cfg.NodeConfiguration.KubernetesVersion) {
+			defaultArguments["admission-control-config-file"] = PodSecurityAdmissionConfigFile(cfg)
+		} else {
+			admissionPlugins = append(admissionPlugins, "PodSecurityPolicy")
+		}
+	}
+	defaultArguments["enable-admission-plugins"] = strings.Join(admissionPlugins, ",")
 	for k, v := range cfg.KubeAPIServerConfiguration.FeatureGates {
 		FeatureGates[k] = v
 	}
@@ -86,6 +95,21 @@ func NewAPIServerStaticPod(cfg *config.ControlPlaneConfiguration) (*corev1.Pod,
 	if cfg.NodeConfiguration.CloudProvider != "" {
 		defaultArguments["cloud-provider"] = cfg.NodeConfiguration.CloudProvider
 	}
+	if oidc := cfg.KubeAPIServerConfiguration.OIDC; oidc != nil {
+		for k, v := range map[string]string{
+			"oidc-issuer-url":      oidc.IssuerURL,
+			"oidc-client-id":       oidc.ClientID,
+			"oidc-username-claim":  oidc.UsernameClaim,
+			"oidc-username-prefix": oidc.UsernamePrefix,
+			"oidc-groups-claim":    oidc.GroupsClaim,
+			"oidc-groups-prefix":   oidc.GroupsPrefix,
+			"oidc-ca-file":         oidc.CAFile,
+		} {
+			if v != "" {
+				defaultArguments[k] = v
+			}
+		}
+	}
 
 	command := []string{"kube-apiserver"}
 	command = append(command, computil.BuildArgumentListFromMap(defaultArguments, cfg.KubeAPIServerConfiguration.ExtraArgs)...)
@@ -107,7 +131,7 @@ func NewAPIServerStaticPod(cfg *config.ControlPlaneConfiguration) (*corev1.Pod,
 			Containers: []corev1.Container{
 				{
 					Name:            "kube-apiserver",
-					Image:           fmt.Sprintf("k8s.gcr.io/kube-apiserver:v%s", cfg.NodeConfiguration.KubernetesVersion),
+					Image:           cfg.Image(constants.KubeAPIServer),
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Command:         command,
 					VolumeMounts: []corev1.VolumeMount{
@@ -125,7 +149,7 @@ func NewAPIServerStaticPod(cfg *config.ControlPlaneConfiguration) (*corev1.Pod,
 					LivenessProbe: &corev1.Probe{
 						Handler: corev1.Handler{
 							HTTPGet: &corev1.HTTPGetAction{
-								Host:   cfg.NodeConfiguration.HostIPv4,
+								Host:   cfg.AdvertiseAddress(),
 								Path:   "/healthz",
 								Port:   intstr.FromInt(cfg.KubeAPIServerConfiguration.BindPort),
 								Scheme: corev1.URISchemeHTTPS,
@@ -178,7 +202,7 @@ func NewAPIServerStaticPod(cfg *config.ControlPlaneConfiguration) (*corev1.Pod,
 	if cfg.KubeAPIServerConfiguration.ExtraArgs["anonymous-auth"] == "false" {
 		p.Spec.Containers = append(p.Spec.Containers, corev1.Container{
 			Name:  "crit-healthcheck-proxy",
-			Image: fmt.Sprintf("docker.io/criticalstack/healthcheck-proxy:v%s", cfg.KubeAPIServerConfiguration.HealthcheckProxyVersion),
+			Image: cfg.Image(constants.HealthcheckProxy),
 			Command: append([]string{"/healthcheck-proxy"}, computil.BuildArgumentListFromMap(map[string]string{
 				"client-ca-file":                 filepath.Join(certsDir, "ca.crt"),
 				"tls-cert-file":                  filepath.Join(certsDir, "apiserver.crt"),
@@ -206,6 +230,43 @@ func NewAPIServerStaticPod(cfg *config.ControlPlaneConfiguration) (*corev1.Pod,
 		p.Spec.Containers[0].LivenessProbe.Handler.HTTPGet.Port = intstr.FromInt(cfg.KubeAPIServerConfiguration.HealthcheckProxyBindPort)
 	}
 
+	if cfg.PodSecurity.Enabled && UsePodSecurityAdmission(cfg.NodeConfiguration.KubernetesVersion) {
+		admissionDir := filepath.Dir(PodSecurityAdmissionConfigFile(cfg))
+		p.Spec.Volumes = append(p.Spec.Volumes, corev1.Volume{
+			Name: "admission-config",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: admissionDir,
+					Type: pointer.HostPathTypePtr(corev1.HostPathDirectoryOrCreate),
+				},
+			},
+		})
+		p.Spec.Containers[0].VolumeMounts = append(p.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "admission-config",
+			MountPath: admissionDir,
+			ReadOnly:  true,
+		})
+	}
+
+	// The OIDC CA file is only mounted when it is not already available to
+	// the apiserver container via the certs directory.
+	if oidc := cfg.KubeAPIServerConfiguration.OIDC; oidc != nil && oidc.CAFile != "" && !strings.HasPrefix(oidc.CAFile, certsDir+"/") {
+		p.Spec.Volumes = append(p.Spec.Volumes, corev1.Volume{
+			Name: "oidc-ca",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: oidc.CAFile,
+					Type: pointer.HostPathTypePtr(corev1.HostPathFile),
+				},
+			},
+		})
+		p.Spec.Containers[0].VolumeMounts = append(p.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "oidc-ca",
+			MountPath: oidc.CAFile,
+			ReadOnly:  true,
+		})
+	}
+
 	p.Spec.Volumes = append(p.Spec.Volumes, getCACertsExtraVolumes()...)
 	p.Spec.Containers[0].VolumeMounts = append(p.Spec.Containers[0].VolumeMounts, getCACertsExtraVolumeMounts()...)
 
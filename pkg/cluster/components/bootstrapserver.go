@@ -1,7 +1,6 @@
 package components
 
 import (
-	"fmt"
 	"path/filepath"
 	"strconv"
 
@@ -12,6 +11,7 @@ import (
 
 	"github.com/criticalstack/crit/internal/config"
 	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	"github.com/criticalstack/crit/pkg/config/constants"
 	"github.com/criticalstack/crit/pkg/kubernetes/util/pointer"
 )
 
@@ -53,7 +53,7 @@ func NewBootstrapServerStaticPod(cfg *config.ControlPlaneConfiguration) *corev1.
 			Containers: []corev1.Container{
 				{
 					Name:            "bootstrap-server",
-					Image:           fmt.Sprintf("docker.io/criticalstack/bootstrap-server:v%s", cfg.CritBootstrapServerConfiguration.Version),
+					Image:           cfg.Image(constants.CritBootstrapServer),
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Command:         command,
 					VolumeMounts: []corev1.VolumeMount{
@@ -71,7 +71,7 @@ func NewBootstrapServerStaticPod(cfg *config.ControlPlaneConfiguration) *corev1.
 					LivenessProbe: &corev1.Probe{
 						Handler: corev1.Handler{
 							HTTPGet: &corev1.HTTPGetAction{
-								Host:   cfg.NodeConfiguration.HostIPv4,
+								Host:   cfg.AdvertiseAddress(),
 								Path:   "/healthz",
 								Port:   intstr.FromInt(serverPort),
 								Scheme: corev1.URISchemeHTTPS,
@@ -11,9 +11,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	netutils "k8s.io/utils/net"
 
 	"github.com/criticalstack/crit/internal/config"
 	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	"github.com/criticalstack/crit/pkg/config/constants"
 	"github.com/criticalstack/crit/pkg/kubernetes/util/pointer"
 	"github.com/criticalstack/crit/pkg/log"
 	netutil "github.com/criticalstack/crit/pkg/util/net"
@@ -43,13 +45,22 @@ func NewControllerManagerStaticPod(cfg *config.ControlPlaneConfiguration) *corev
 	// Let the controller-manager allocate Node CIDRs for the Pod network.
 	// Each node will get a subspace of the address CIDR provided with --pod-network-cidr.
 	if cfg.PodSubnet != "" {
-		// TODO(Arvinderpal): Needs to be fixed once PR #73977 lands. Should be a list of maskSizes.
-		maskSize := netutil.CalcNodeCidrSize(cfg.PodSubnet)
 		defaultArguments["allocate-node-cidrs"] = "true"
-		defaultArguments["cluster-cidr"] = cfg.PodSubnet
-		defaultArguments["node-cidr-mask-size"] = maskSize
+		defaultArguments["cluster-cidr"] = strings.Join(cfg.PodSubnets, ",")
+		if cfg.IsDualStack() {
+			// dual-stack clusters require a mask size for each IP family
+			for _, subnet := range cfg.PodSubnets {
+				if netutils.IsIPv6CIDRString(subnet) {
+					defaultArguments["node-cidr-mask-size-ipv6"] = netutil.CalcNodeCidrSize(subnet)
+				} else {
+					defaultArguments["node-cidr-mask-size-ipv4"] = netutil.CalcNodeCidrSize(subnet)
+				}
+			}
+		} else {
+			defaultArguments["node-cidr-mask-size"] = netutil.CalcNodeCidrSize(cfg.PodSubnet)
+		}
 		if cfg.ServiceSubnet != "" {
-			defaultArguments["service-cluster-ip-range"] = cfg.ServiceSubnet
+			defaultArguments["service-cluster-ip-range"] = strings.Join(cfg.ServiceSubnets, ",")
 		}
 	}
 
@@ -104,7 +115,7 @@ func NewControllerManagerStaticPod(cfg *config.ControlPlaneConfiguration) *corev
 			Containers: []corev1.Container{
 				{
 					Name:            "kube-controller-manager",
-					Image:           fmt.Sprintf("k8s.gcr.io/kube-controller-manager:v%s", cfg.NodeConfiguration.KubernetesVersion),
+					Image:           cfg.Image(constants.KubeControllerManager),
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Command:         command,
 					VolumeMounts: []corev1.VolumeMount{
@@ -127,7 +138,7 @@ func NewControllerManagerStaticPod(cfg *config.ControlPlaneConfiguration) *corev
 					LivenessProbe: &corev1.Probe{
 						Handler: corev1.Handler{
 							HTTPGet: &corev1.HTTPGetAction{
-								Host:   cfg.NodeConfiguration.HostIPv4,
+								Host:   cfg.AdvertiseAddress(),
 								Path:   "/healthz",
 								Port:   intstr.FromInt(10252),
 								Scheme: corev1.URISchemeHTTP,
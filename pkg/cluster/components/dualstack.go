@@ -0,0 +1,47 @@
+package components
+
+import (
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/criticalstack/crit/internal/config"
+)
+
+// IPv6DualStackFeatureGate is the Kubernetes feature gate that enables
+// dual-stack pod and service networking.
+const IPv6DualStackFeatureGate = "IPv6DualStack"
+
+var (
+	// IPv6DualStackDefaultVersion is the first version of Kubernetes where
+	// the IPv6DualStack feature gate is enabled by default. Versions prior to
+	// this must enable the feature gate for every component.
+	IPv6DualStackDefaultVersion = version.MustParseSemantic("v1.21.0")
+
+	// DualStackNodeIPsMinVersion is the first version of Kubernetes where the
+	// kubelet accepts a node IP for each IP family.
+	DualStackNodeIPsMinVersion = version.MustParseSemantic("v1.20.0")
+)
+
+// RequiresIPv6DualStackFeatureGate determines if the IPv6DualStack feature
+// gate must be enabled for a dual-stack cluster with the provided Kubernetes
+// version.
+func RequiresIPv6DualStackFeatureGate(kubernetesVersion string) bool {
+	v, err := version.ParseSemantic(kubernetesVersion)
+	if err != nil {
+		return false
+	}
+	return v.LessThan(IPv6DualStackDefaultVersion)
+}
+
+// UseDualStackNodeIPs determines if the kubelet can be given both host IP
+// addresses. Older kubelets, and kubelets using a cloud provider, only accept
+// a single node IP.
+func UseDualStackNodeIPs(cfg *config.NodeConfiguration) bool {
+	if cfg.CloudProvider != "" {
+		return false
+	}
+	v, err := version.ParseSemantic(cfg.KubernetesVersion)
+	if err != nil {
+		return false
+	}
+	return v.AtLeast(DualStackNodeIPsMinVersion)
+}
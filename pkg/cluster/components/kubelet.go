@@ -12,6 +12,7 @@ import (
 
 	"github.com/criticalstack/crit/internal/config"
 	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	nodeutil "github.com/criticalstack/crit/pkg/kubernetes/util/node"
 	yamlutil "github.com/criticalstack/crit/pkg/kubernetes/yaml"
 	"github.com/criticalstack/crit/pkg/log"
 	processutil "github.com/criticalstack/crit/pkg/util/process"
@@ -86,10 +87,35 @@ func WriteKubeletDynamicEnvFile(cfg *config.NodeConfiguration, registerTaintsUsi
 	if cfg.CloudProvider != "" {
 		cfg.KubeletExtraArgs["cloud-provider"] = cfg.CloudProvider
 	}
-	cfg.KubeletExtraArgs["address"] = cfg.HostIPv4
-	cfg.KubeletExtraArgs["node-ip"] = cfg.HostIPv4
+	nodeIPs := cfg.HostIPs()
+	if len(nodeIPs) > 1 && !UseDualStackNodeIPs(cfg) {
+		nodeIPs = nodeIPs[:1]
+	}
+	cfg.KubeletExtraArgs["node-ip"] = strings.Join(nodeIPs, ",")
+	cfg.KubeletExtraArgs["address"] = cfg.HostIP()
+	if len(nodeIPs) > 1 {
+		// listen on all addresses so the kubelet is reachable from both IP
+		// families
+		cfg.KubeletExtraArgs["address"] = "::"
+	}
+
+	// labels allowed by the NodeRestriction admission plugin are set when the
+	// kubelet registers the node, and are merged with any node-labels
+	// provided in KubeletExtraArgs
+	extraArgs := make(map[string]string)
+	for k, v := range cfg.KubeletExtraArgs {
+		extraArgs[k] = v
+	}
+	allowed, _ := nodeutil.SplitLabels(cfg.Labels)
+	if labels := nodeutil.FormatLabels(allowed); labels != "" {
+		if extra := extraArgs["node-labels"]; extra != "" {
+			labels = extra + "," + labels
+		}
+		delete(extraArgs, "node-labels")
+		kubeletFlags["node-labels"] = labels
+	}
 
-	argList := computil.BuildArgumentListFromMap(kubeletFlags, cfg.KubeletExtraArgs)
+	argList := computil.BuildArgumentListFromMap(kubeletFlags, extraArgs)
 	envFileContent := fmt.Sprintf("%s=%q\n", KubeletEnvFileVariableName, strings.Join(argList, " "))
 	if err := os.MkdirAll(kubeletDir, 0700); err != nil {
 		return err
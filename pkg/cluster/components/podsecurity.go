@@ -0,0 +1,86 @@
+package components
+
+import (
+	"path/filepath"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/config/constants"
+)
+
+var (
+	// PodSecurityAdmissionMinVersion is the first version of Kubernetes where
+	// the PodSecurity admission plugin is enabled by default. Versions prior
+	// to this use PodSecurityPolicy instead.
+	PodSecurityAdmissionMinVersion = version.MustParseSemantic("v1.23.0")
+
+	// podSecurityV1MinVersion is the first version of Kubernetes that serves
+	// the v1 PodSecurityConfiguration API.
+	podSecurityV1MinVersion = version.MustParseSemantic("v1.25.0")
+)
+
+// UsePodSecurityAdmission determines if pod security should be enforced with
+// the PodSecurity admission plugin, rather than with PodSecurityPolicy, for
+// the provided Kubernetes version.
+func UsePodSecurityAdmission(kubernetesVersion string) bool {
+	v, err := version.ParseSemantic(kubernetesVersion)
+	if err != nil {
+		return false
+	}
+	return v.AtLeast(PodSecurityAdmissionMinVersion)
+}
+
+// PodSecurityAdmissionConfigFile returns the path of the admission
+// configuration file provided to the apiserver.
+func PodSecurityAdmissionConfigFile(cfg *config.ControlPlaneConfiguration) string {
+	return filepath.Join(cfg.NodeConfiguration.KubeDir, "admission/admission-config.yaml")
+}
+
+// NewPodSecurityAdmissionConfig creates the apiserver AdmissionConfiguration
+// for the PodSecurity admission plugin. The DefaultLevel is used for enforce,
+// audit and warn, while any namespaces with the privileged level are exempt
+// since static pods (e.g. kube-system) must be admitted before namespace
+// labels can be applied.
+func NewPodSecurityAdmissionConfig(cfg *config.ControlPlaneConfiguration) ([]byte, error) {
+	apiVersion := "pod-security.admission.config.k8s.io/v1beta1"
+	if v, err := version.ParseSemantic(cfg.NodeConfiguration.KubernetesVersion); err == nil && v.AtLeast(podSecurityV1MinVersion) {
+		apiVersion = "pod-security.admission.config.k8s.io/v1"
+	}
+	exempt := make([]string, 0)
+	for ns, level := range cfg.PodSecurity.Namespaces {
+		if level == constants.PodSecurityLevelPrivileged {
+			exempt = append(exempt, ns)
+		}
+	}
+	sort.Strings(exempt)
+	level := cfg.PodSecurity.DefaultLevel
+	return yaml.Marshal(map[string]interface{}{
+		"apiVersion": "apiserver.config.k8s.io/v1",
+		"kind":       "AdmissionConfiguration",
+		"plugins": []interface{}{
+			map[string]interface{}{
+				"name": "PodSecurity",
+				"configuration": map[string]interface{}{
+					"apiVersion": apiVersion,
+					"kind":       "PodSecurityConfiguration",
+					"defaults": map[string]string{
+						"enforce":         level,
+						"enforce-version": "latest",
+						"audit":           level,
+						"audit-version":   "latest",
+						"warn":            level,
+						"warn-version":    "latest",
+					},
+					"exemptions": map[string]interface{}{
+						"usernames":      []string{},
+						"runtimeClasses": []string{},
+						"namespaces":     exempt,
+					},
+				},
+			},
+		},
+	})
+}
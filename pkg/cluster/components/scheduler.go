@@ -13,6 +13,7 @@ import (
 
 	"github.com/criticalstack/crit/internal/config"
 	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	"github.com/criticalstack/crit/pkg/config/constants"
 	"github.com/criticalstack/crit/pkg/kubernetes/util/pointer"
 	"github.com/criticalstack/crit/pkg/log"
 )
@@ -56,7 +57,7 @@ func NewSchedulerStaticPod(cfg *config.ControlPlaneConfiguration) *corev1.Pod {
 			Containers: []corev1.Container{
 				{
 					Name:            "kube-scheduler",
-					Image:           fmt.Sprintf("k8s.gcr.io/kube-scheduler:v%s", cfg.NodeConfiguration.KubernetesVersion),
+					Image:           cfg.Image(constants.KubeScheduler),
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Command:         command,
 					VolumeMounts: []corev1.VolumeMount{
@@ -69,7 +70,7 @@ func NewSchedulerStaticPod(cfg *config.ControlPlaneConfiguration) *corev1.Pod {
 					LivenessProbe: &corev1.Probe{
 						Handler: corev1.Handler{
 							HTTPGet: &corev1.HTTPGetAction{
-								Host:   cfg.NodeConfiguration.HostIPv4,
+								Host:   cfg.AdvertiseAddress(),
 								Path:   "/healthz",
 								Port:   intstr.FromInt(10251),
 								Scheme: corev1.URISchemeHTTP,
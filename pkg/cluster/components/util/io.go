@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -21,3 +22,20 @@ func WriteKubeComponent(obj runtime.Object, path string) error {
 	}
 	return ioutil.WriteFile(path, data, 0600)
 }
+
+// ReadKubeComponent reads a static pod manifest.
+func ReadKubeComponent(path string) (*corev1.Pod, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := yamlutil.UnmarshalFromYaml(data, corev1.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, errors.Errorf("expected Pod in %s, received %T", path, obj)
+	}
+	return p, nil
+}
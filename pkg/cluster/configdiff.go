@@ -0,0 +1,235 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/internal/feature"
+	"github.com/criticalstack/crit/pkg/cluster/components"
+	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	"github.com/criticalstack/crit/pkg/kubernetes"
+	"github.com/criticalstack/crit/pkg/util/diff"
+)
+
+// ResolveControlPlaneConfig applies node overrides, feature gates and
+// runtime defaults to the config in the same way as RunControlPlane, without
+// running any checks or making changes to the host.
+func ResolveControlPlaneConfig(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
+	if err := ApplyNodeOverrides(ctx, cfg); err != nil {
+		return err
+	}
+	if err := feature.MutableGates.SetFromMap(cfg.FeatureGates); err != nil {
+		return err
+	}
+	setControlPlaneRuntimeDefaults(cfg)
+	setKubeletCgroupDriverDefault(ctx, &cfg.NodeConfiguration)
+	return nil
+}
+
+// KubeManifests returns the static pods written by WriteKubeManifests and,
+// when the BootstrapServer feature gate is enabled,
+// WriteBootstrapServerManifest, keyed by the path of their manifest.
+func KubeManifests(cfg *config.ControlPlaneConfiguration) (map[string]*corev1.Pod, error) {
+	apiserver, err := components.NewAPIServerStaticPod(cfg)
+	if err != nil {
+		return nil, err
+	}
+	manifestsDir := filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests")
+	pods := map[string]*corev1.Pod{
+		filepath.Join(manifestsDir, "kube-apiserver.yaml"):          apiserver,
+		filepath.Join(manifestsDir, "kube-controller-manager.yaml"): components.NewControllerManagerStaticPod(cfg),
+		filepath.Join(manifestsDir, "kube-scheduler.yaml"):          components.NewSchedulerStaticPod(cfg),
+	}
+	if feature.Gates.Enabled(feature.BootstrapServer) {
+		pods[filepath.Join(manifestsDir, "crit-bootstrap-server.yaml")] = components.NewBootstrapServerStaticPod(cfg)
+	}
+	return pods, nil
+}
+
+// GetStoredConfig returns the control plane config uploaded to the
+// crit-config ConfigMap by UploadInfo.
+func GetStoredConfig(ctx context.Context, client *clientset.Clientset) ([]byte, error) {
+	cm, err := kubernetes.GetConfigMap(client, ctx, CritConfigName)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := cm.Data["config"]
+	if !ok {
+		return nil, errors.Errorf("%s ConfigMap does not contain a config", CritConfigName)
+	}
+	return []byte(data), nil
+}
+
+// hostConfigFields are the fields of the control plane config that are
+// expected to differ between control plane nodes.
+var hostConfigFields = []string{
+	"node.hostname",
+	"node.hostIPv4",
+	"node.hostIPv6",
+}
+
+// DiffConfig returns the fields that differ between the stored control plane
+// config and the local config, ignoring the host-specific fields of the
+// NodeConfiguration. The stored config holds the resolved NodeConfiguration
+// of the last node to upload it, so the fields patched by NodeOverrides are
+// also ignored, since they are expected to differ between nodes.
+func DiffConfig(stored, local *config.ControlPlaneConfiguration) ([]diff.Change, error) {
+	changes, err := diff.Objects(stored, local)
+	if err != nil {
+		return nil, err
+	}
+	ignored := append([]string{}, hostConfigFields...)
+	for _, overrides := range [][]config.NodeOverride{stored.NodeOverrides, local.NodeOverrides} {
+		for _, o := range overrides {
+			ignored = append(ignored, overridePaths(o.NodeConfiguration.Raw)...)
+		}
+	}
+	result := make([]diff.Change, 0)
+	for _, c := range changes {
+		if !isIgnoredField(c.Path, ignored) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// overridePaths returns the paths of the fields set by the node patch of a
+// NodeOverride, using the same format as diff.Change.
+func overridePaths(patch []byte) []string {
+	var v interface{}
+	if err := json.Unmarshal(patch, &v); err != nil {
+		return nil
+	}
+	paths := make([]string, 0)
+	var walk func(path string, v interface{})
+	walk = func(path string, v interface{}) {
+		m, ok := v.(map[string]interface{})
+		if !ok || len(m) == 0 {
+			paths = append(paths, path)
+			return
+		}
+		for k, v := range m {
+			walk(diff.JoinPath(path, k), v)
+		}
+	}
+	walk("node", v)
+	return paths
+}
+
+// isIgnoredField returns true if the path is one of the ignored fields, or is
+// nested within one of them.
+func isIgnoredField(path string, ignored []string) bool {
+	for _, f := range ignored {
+		if path == f || strings.HasPrefix(path, f+".") || strings.HasPrefix(path, f+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// ManifestDiff is the difference between a static pod manifest on disk and
+// the static pod rendered from the local config.
+type ManifestDiff struct {
+	Path string
+
+	// Missing is true when the manifest does not exist on disk.
+	Missing bool
+
+	Changes []diff.Change
+}
+
+// DiffKubeManifests compares the static pod manifests on disk with those
+// rendered from the config, returning the manifests that differ sorted by
+// path. Container command and args are compared by flag, so that the order
+// of arguments does not matter.
+func DiffKubeManifests(cfg *config.ControlPlaneConfiguration) ([]ManifestDiff, error) {
+	pods, err := KubeManifests(cfg)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(pods))
+	for path := range pods {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	diffs := make([]ManifestDiff, 0)
+	for _, path := range paths {
+		current, err := computil.ReadKubeComponent(path)
+		if os.IsNotExist(errors.Cause(err)) {
+			diffs = append(diffs, ManifestDiff{Path: path, Missing: true})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		changes, err := diffPods(current, pods[path])
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 {
+			diffs = append(diffs, ManifestDiff{Path: path, Changes: changes})
+		}
+	}
+	return diffs, nil
+}
+
+func diffPods(a, b *corev1.Pod) ([]diff.Change, error) {
+	av, err := podValue(a)
+	if err != nil {
+		return nil, err
+	}
+	bv, err := podValue(b)
+	if err != nil {
+		return nil, err
+	}
+	return diff.Values(av, bv), nil
+}
+
+// podValue returns the generic value of a Pod with the command and args of
+// each container as a map of flags, e.g. "--secure-port=6443" becomes
+// {"--secure-port": "6443"}. Arguments that are not flags are kept as keys
+// with an empty value.
+func podValue(p *corev1.Pod) (interface{}, error) {
+	v, err := diff.ToValue(p)
+	if err != nil {
+		return nil, err
+	}
+	spec, _ := v.(map[string]interface{})["spec"].(map[string]interface{})
+	for _, key := range []string{"initContainers", "containers"} {
+		containers, _ := spec[key].([]interface{})
+		for _, c := range containers {
+			c, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, field := range []string{"command", "args"} {
+				args, ok := c[field].([]interface{})
+				if !ok {
+					continue
+				}
+				flags := make(map[string]interface{})
+				for _, arg := range args {
+					s, _ := arg.(string)
+					parts := strings.SplitN(s, "=", 2)
+					if len(parts) == 2 && strings.HasPrefix(s, "-") {
+						flags[parts[0]] = parts[1]
+						continue
+					}
+					flags[s] = ""
+				}
+				c[field] = flags
+			}
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,271 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/kubernetes/remote"
+	"github.com/criticalstack/crit/pkg/log"
+	executil "github.com/criticalstack/crit/pkg/util/exec"
+	"github.com/criticalstack/crit/pkg/util/systemd"
+)
+
+// DefaultFailureReportLines is the default number of lines of the kubelet
+// journal and container logs included in a failure report.
+const DefaultFailureReportLines = 50
+
+// ContainerReport is the status and most recent logs of a static pod
+// container.
+type ContainerReport struct {
+	Name     string   `json:"name"`
+	ID       string   `json:"id,omitempty"`
+	State    string   `json:"state,omitempty"`
+	ExitCode int32    `json:"exitCode"`
+	Reason   string   `json:"reason,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	LogPath  string   `json:"logPath,omitempty"`
+	Logs     []string `json:"logs,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// FailureReport contains the diagnostics collected when a node fails to
+// bootstrap.
+type FailureReport struct {
+	Time       time.Time              `json:"time"`
+	Hostname   string                 `json:"hostname"`
+	Step       string                 `json:"step"`
+	Error      string                 `json:"error"`
+	Journal    []systemd.JournalEntry `json:"journal,omitempty"`
+	Containers []ContainerReport      `json:"containers,omitempty"`
+	Errors     []string               `json:"errors,omitempty"`
+}
+
+// Print writes the failure report in a human readable format.
+func (r *FailureReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "Failure report for %s (%s)\n", r.Step, r.Time.Format(time.RFC3339))
+	fmt.Fprintf(w, "  hostname: %s\n", r.Hostname)
+	fmt.Fprintf(w, "  error: %s\n", r.Error)
+	fmt.Fprintf(w, "\nkubelet.service journal (last %d entries):\n", len(r.Journal))
+	for _, e := range r.Journal {
+		fmt.Fprintf(w, "  %s %s\n", e.Time.Format(time.RFC3339), e.Message)
+	}
+	for _, c := range r.Containers {
+		fmt.Fprintf(w, "\ncontainer %s:\n", c.Name)
+		if c.Error != "" {
+			fmt.Fprintf(w, "  error: %s\n", c.Error)
+			continue
+		}
+		fmt.Fprintf(w, "  id: %s\n", c.ID)
+		fmt.Fprintf(w, "  state: %s\n", c.State)
+		fmt.Fprintf(w, "  exit code: %d\n", c.ExitCode)
+		if c.Reason != "" {
+			fmt.Fprintf(w, "  reason: %s\n", c.Reason)
+		}
+		if c.Message != "" {
+			fmt.Fprintf(w, "  message: %s\n", c.Message)
+		}
+		fmt.Fprintf(w, "  logs (%s):\n", c.LogPath)
+		for _, line := range c.Logs {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+	for _, err := range r.Errors {
+		fmt.Fprintf(w, "\ncannot collect diagnostics: %s\n", err)
+	}
+}
+
+// WriteFile writes the failure report as JSON, so that it can be attached to
+// a support bundle.
+func (r *FailureReport) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(ioutil.WriteFile(path, data, 0600))
+}
+
+func (c *Cluster) failureReportLines() int {
+	if c.rc == nil || c.rc.FailureReportLines <= 0 {
+		return DefaultFailureReportLines
+	}
+	return c.rc.FailureReportLines
+}
+
+// reportFailure collects the kubelet journal and the logs of the provided
+// static pod containers, as well as any static pod containers that have
+// exited with an error, and prints them as a failure report. The report is
+// also written to the failure report file, if one was provided.
+func (c *Cluster) reportFailure(cfg *config.NodeConfiguration, step string, reterr error, containers ...string) {
+	// the step context has usually expired by this point, so diagnostics are
+	// collected using a new context
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	lines := c.failureReportLines()
+	report := &FailureReport{
+		Time:     time.Now(),
+		Hostname: cfg.Hostname,
+		Step:     step,
+		Error:    reterr.Error(),
+	}
+	journal, err := systemd.JournalTail(ctx, "kubelet.service", lines)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("kubelet journal: %v", err))
+	}
+	report.Journal = journal
+
+	r, err := remote.NewRuntimeServiceClient(ctx, cfg.ContainerRuntime.CRISocket())
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("container runtime: %v", err))
+	} else {
+		failed, err := failedStaticPodContainers(ctx, r)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("container runtime: %v", err))
+		}
+		for _, name := range failed {
+			if !contains(containers, name) {
+				containers = append(containers, name)
+			}
+		}
+		for _, name := range containers {
+			report.Containers = append(report.Containers, containerReport(ctx, r, name, lines))
+		}
+	}
+
+	stderr := executil.NewPrefixWriter(os.Stderr, "\t")
+	defer stderr.Close()
+
+	report.Print(stderr)
+	if c.rc != nil && c.rc.FailureReportFile != "" {
+		if err := report.WriteFile(c.rc.FailureReportFile); err != nil {
+			log.Error("cannot write failure report", zap.Error(err))
+			return
+		}
+		log.Info("failure report written", zap.String("path", c.rc.FailureReportFile))
+	}
+}
+
+func contains(ss []string, match string) bool {
+	for _, s := range ss {
+		if s == match {
+			return true
+		}
+	}
+	return false
+}
+
+// failedStaticPodContainers returns the names of kube-system containers
+// whose most recent instance exited with an error.
+func failedStaticPodContainers(ctx context.Context, r *remote.RuntimeServiceClient) ([]string, error) {
+	resp, err := r.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+		Filter: &runtimeapi.ContainerFilter{
+			LabelSelector: map[string]string{
+				"io.kubernetes.pod.namespace": "kube-system",
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[string]*runtimeapi.Container)
+	for _, c := range resp.Containers {
+		name := c.GetMetadata().GetName()
+		if l, ok := latest[name]; !ok || c.CreatedAt > l.CreatedAt {
+			latest[name] = c
+		}
+	}
+	names := make([]string, 0)
+	for name, c := range latest {
+		if c.State != runtimeapi.ContainerState_CONTAINER_EXITED {
+			continue
+		}
+		status, err := r.GetContainerStatus(ctx, c.Id)
+		if err != nil || status.ExitCode == 0 {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func containerReport(ctx context.Context, r *remote.RuntimeServiceClient, name string, lines int) ContainerReport {
+	report := ContainerReport{Name: name}
+	container, err := r.GetLatestContainerByName(ctx, name)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.ID = container.Id
+	status, err := r.GetContainerStatus(ctx, container.Id)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.State = status.State.String()
+	report.ExitCode = status.ExitCode
+	report.Reason = status.Reason
+	report.Message = status.Message
+	report.LogPath = latestLogPath(status.LogPath)
+
+	var buf bytes.Buffer
+	if err := r.ReadLogs(ctx, report.LogPath, &buf); err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Logs = lastLines(buf.String(), lines)
+	return report
+}
+
+// latestLogPath returns the most recent log file in the container log
+// directory. In some environments, like cinder, the kubelet restarts the
+// container so quickly that the log path in the container status is no
+// longer available.
+func latestLogPath(path string) string {
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	dir := filepath.Dir(path)
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return path
+	}
+	var latest os.FileInfo
+	for _, info := range infos {
+		if latest == nil || info.ModTime().After(latest.ModTime()) {
+			latest = info
+		}
+	}
+	if latest == nil {
+		return path
+	}
+	return filepath.Join(dir, latest.Name())
+}
+
+func lastLines(s string, n int) []string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
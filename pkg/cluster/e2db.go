@@ -0,0 +1,58 @@
+package cluster
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/criticalstack/e2d/pkg/e2db"
+
+	"github.com/criticalstack/crit/pkg/trace"
+)
+
+// tracedDB wraps an e2db database so that every table transaction is recorded
+// as a client span. The e2db client does not accept a context, so the context
+// used to open the database is the parent of each span.
+type tracedDB struct {
+	*e2db.DB
+	ctx       context.Context
+	namespace string
+}
+
+func newTracedDB(ctx context.Context, cfg *e2db.Config) (*tracedDB, error) {
+	db, err := e2db.New(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedDB{DB: db, ctx: ctx, namespace: cfg.Namespace}, nil
+}
+
+// Table returns the table for the model, where transactions are traced.
+func (db *tracedDB) Table(model interface{}, opts ...e2db.TableOption) *tracedTable {
+	return &tracedTable{
+		Table: db.DB.Table(model, opts...),
+		ctx:   db.ctx,
+		attrs: []trace.Attribute{
+			trace.String("db.system", "etcd"),
+			trace.String("db.name", db.namespace),
+			trace.String("e2db.table", reflect.Indirect(reflect.ValueOf(model)).Type().Name()),
+		},
+	}
+}
+
+type tracedTable struct {
+	*e2db.Table
+	ctx   context.Context
+	attrs []trace.Attribute
+}
+
+// Tx runs the transaction within an e2db.Tx span. The span is available from
+// the context passed to fn, so that attributes can be added to it.
+func (t *tracedTable) Tx(fn func(ctx context.Context, tx *e2db.Tx) error) error {
+	ctx, span := trace.Start(t.ctx, "e2db.Tx", trace.SpanKindClient, t.attrs...)
+	err := t.Table.Tx(func(tx *e2db.Tx) error {
+		return fn(ctx, tx)
+	})
+	span.SetError(err)
+	span.Finish()
+	return err
+}
@@ -0,0 +1,184 @@
+package cluster
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+// Event types written to RuntimeConfig.Events.
+const (
+	EventStepStarted   = "step-started"
+	EventStepSucceeded = "step-succeeded"
+	EventStepFailed    = "step-failed"
+	EventSummary       = "summary"
+)
+
+// Event is written as a line of JSON for each workflow step that is started
+// and finished, followed by a summary once the workflow has finished.
+type Event struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+
+	// Step is the identifier of the workflow step, which is the same as the
+	// step field of log entries.
+	Step string `json:"step,omitempty"`
+
+	// DurationSeconds is the amount of time the step, or for the summary
+	// the whole workflow, took to run.
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+
+	Error   *EventError `json:"error,omitempty"`
+	Summary *Summary    `json:"summary,omitempty"`
+}
+
+// EventError describes the error returned by a failed step.
+type EventError struct {
+	Message string `json:"message"`
+
+	// Cause is the underlying error, if the error was wrapped with
+	// additional context.
+	Cause string `json:"cause,omitempty"`
+}
+
+func newEventError(err error) *EventError {
+	e := &EventError{Message: err.Error()}
+	if cause := errors.Cause(err); cause != err {
+		e.Cause = cause.Error()
+	}
+	return e
+}
+
+// Summary describes the node once the workflow has finished.
+type Summary struct {
+	// Role is either control-plane or worker.
+	Role      string `json:"role"`
+	NodeName  string `json:"nodeName"`
+	Endpoint  string `json:"endpoint"`
+	Succeeded bool   `json:"succeeded"`
+
+	// FailedStep is the identifier of the step that failed, if any.
+	FailedStep string `json:"failedStep,omitempty"`
+
+	// Artifacts are the paths of the files written by the workflow steps,
+	// such as certificates, kubeconfigs and static pod manifests.
+	Artifacts []string `json:"artifacts"`
+}
+
+const (
+	roleControlPlane = "control-plane"
+	roleWorker       = "worker"
+)
+
+// eventWriter writes events as newline-delimited JSON. A nil eventWriter
+// discards events.
+type eventWriter struct {
+	mu         sync.Mutex
+	w          io.Writer
+	start      time.Time
+	failedStep string
+	artifacts  map[string]bool
+}
+
+func newEventWriter(rc *RuntimeConfig) *eventWriter {
+	if rc == nil || rc.Events == nil {
+		return nil
+	}
+	return &eventWriter{w: rc.Events, start: time.Now(), artifacts: make(map[string]bool)}
+}
+
+func (e *eventWriter) write(ev *Event) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ev.Time = time.Now()
+	data, err := json.Marshal(ev)
+	if err != nil {
+		log.Debug("cannot encode event", zap.String("type", ev.Type), zap.Error(err))
+		return
+	}
+	if _, err := e.w.Write(append(data, '\n')); err != nil {
+		log.Debug("cannot write event", zap.String("type", ev.Type), zap.Error(err))
+	}
+}
+
+func (e *eventWriter) stepStarted(step string) {
+	e.write(&Event{Type: EventStepStarted, Step: step})
+}
+
+func (e *eventWriter) stepFinished(step string, d time.Duration, err error) {
+	if e == nil {
+		return
+	}
+	if err != nil {
+		e.mu.Lock()
+		e.failedStep = step
+		e.mu.Unlock()
+		e.write(&Event{Type: EventStepFailed, Step: step, DurationSeconds: d.Seconds(), Error: newEventError(err)})
+		return
+	}
+	e.write(&Event{Type: EventStepSucceeded, Step: step, DurationSeconds: d.Seconds()})
+}
+
+// wrote records the paths of files written by a workflow step, which are
+// included in the summary.
+func (e *eventWriter) wrote(paths ...string) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, path := range paths {
+		e.artifacts[path] = true
+	}
+}
+
+// summary writes the final summary of the workflow.
+func (e *eventWriter) summary(role string, cfg *config.NodeConfiguration, endpoint string, err error) {
+	if e == nil {
+		return
+	}
+	nodeName := kubeletNodeName(cfg)
+	if nodeName == "" {
+		nodeName, _ = os.Hostname()
+	}
+	s := &Summary{
+		Role:       role,
+		NodeName:   nodeName,
+		Endpoint:   endpoint,
+		Succeeded:  err == nil,
+		FailedStep: e.failedStep,
+		Artifacts:  e.writtenFiles(),
+	}
+	ev := &Event{Type: EventSummary, DurationSeconds: time.Since(e.start).Seconds(), Summary: s}
+	if err != nil {
+		ev.Error = newEventError(err)
+	}
+	e.write(ev)
+}
+
+// writtenFiles returns the sorted paths of the files recorded as written.
+func (e *eventWriter) writtenFiles() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	paths := make([]string, 0, len(e.artifacts))
+	for path := range e.artifacts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
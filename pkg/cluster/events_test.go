@@ -0,0 +1,161 @@
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	"github.com/criticalstack/crit/internal/config"
+	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	clusterutil "github.com/criticalstack/crit/pkg/cluster/util"
+	"github.com/criticalstack/crit/pkg/config/constants"
+)
+
+type testSteps struct {
+	ran []string
+}
+
+func (s *testSteps) FailStep(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
+	s.ran = append(s.ran, "fail-step")
+	return errors.Wrap(errors.New("connection refused"), "cannot reach etcd")
+}
+
+func (s *testSteps) SkippedStep(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
+	s.ran = append(s.ran, "skipped-step")
+	return nil
+}
+
+func readEvents(t *testing.T, data []byte) []*Event {
+	t.Helper()
+	events := make([]*Event, 0)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		var ev Event
+		if err := json.Unmarshal(s.Bytes(), &ev); err != nil {
+			t.Fatalf("cannot decode event %q: %v", s.Text(), err)
+		}
+		events = append(events, &ev)
+	}
+	return events
+}
+
+func TestRunStepsEvents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pkiDir := filepath.Join(dir, "pki")
+	if err := clusterutil.WriteClusterCA(pkiDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := clusterutil.WriteFrontProxyCA(pkiDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// existing certs are kept, so they are not artifacts of the workflow
+	if err := ioutil.WriteFile(filepath.Join(pkiDir, "apiserver-healthcheck-client.key"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.ControlPlaneConfiguration{
+		ControlPlaneEndpoint: computil.APIEndpoint{Host: "192.0.2.10", Port: 6443},
+		ServiceSubnet:        constants.DefaultServiceSubnet,
+		NodeConfiguration: config.NodeConfiguration{
+			KubeDir:  dir,
+			Hostname: "Control-Plane-1",
+			HostIPv4: "192.0.2.10",
+			KubeletConfiguration: &kubeletconfigv1beta1.KubeletConfiguration{
+				ClusterDomain: constants.DefaultClusterDomain,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	rc := &RuntimeConfig{Events: &buf}
+	c := New("", rc)
+	c.events = newEventWriter(rc)
+	steps := &testSteps{}
+	c.Add(c.CreateNodeCerts, steps.FailStep, steps.SkippedStep)
+	err = c.runSteps(context.Background(), func(ctx context.Context, fn interface{}) error {
+		return fn.(func(context.Context, *config.ControlPlaneConfiguration) error)(ctx, cfg)
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !reflect.DeepEqual(steps.ran, []string{"fail-step"}) {
+		t.Fatalf("expected steps after the failed step to not run, received %v", steps.ran)
+	}
+	c.events.summary(roleControlPlane, &cfg.NodeConfiguration, cfg.ControlPlaneEndpoint.String(), err)
+
+	events := readEvents(t, buf.Bytes())
+	expected := []struct {
+		typ  string
+		step string
+	}{
+		{EventStepStarted, "create-node-certs"},
+		{EventStepSucceeded, "create-node-certs"},
+		{EventStepStarted, "fail-step"},
+		{EventStepFailed, "fail-step"},
+		{EventSummary, ""},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, received %d:\n%s", len(expected), len(events), buf.String())
+	}
+	for i, ev := range events {
+		if ev.Type != expected[i].typ || ev.Step != expected[i].step {
+			t.Errorf("expected event %d to be %s %q, received %s %q", i, expected[i].typ, expected[i].step, ev.Type, ev.Step)
+		}
+		if ev.Time.IsZero() {
+			t.Errorf("expected event %d to have a time", i)
+		}
+	}
+
+	failed := events[3]
+	if failed.Error == nil || failed.Error.Message != "cannot reach etcd: connection refused" || failed.Error.Cause != "connection refused" {
+		t.Errorf("expected failed step error with cause, received %+v", failed.Error)
+	}
+
+	summary := events[4]
+	if summary.Error == nil || summary.Error.Cause != "connection refused" {
+		t.Errorf("expected summary error with cause, received %+v", summary.Error)
+	}
+	if summary.Summary == nil {
+		t.Fatal("expected summary")
+	}
+	expectedSummary := &Summary{
+		Role:       roleControlPlane,
+		NodeName:   "control-plane-1",
+		Endpoint:   "192.0.2.10:6443",
+		Succeeded:  false,
+		FailedStep: "fail-step",
+		Artifacts: []string{
+			filepath.Join(pkiDir, "apiserver-kubelet-client.crt"),
+			filepath.Join(pkiDir, "apiserver-kubelet-client.key"),
+			filepath.Join(pkiDir, "apiserver.crt"),
+			filepath.Join(pkiDir, "apiserver.key"),
+			filepath.Join(pkiDir, "front-proxy-client.crt"),
+			filepath.Join(pkiDir, "front-proxy-client.key"),
+		},
+	}
+	if !reflect.DeepEqual(summary.Summary, expectedSummary) {
+		t.Errorf("expected summary %+v, received %+v", expectedSummary, summary.Summary)
+	}
+}
+
+func TestEventWriterNil(t *testing.T) {
+	var e *eventWriter
+	e.stepStarted("step")
+	e.stepFinished("step", 0, errors.New("failed"))
+	e.wrote("/etc/kubernetes/admin.conf")
+	e.summary(roleWorker, &config.NodeConfiguration{}, "", nil)
+}
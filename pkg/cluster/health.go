@@ -0,0 +1,195 @@
+package cluster
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	"github.com/criticalstack/crit/pkg/kubernetes/remote"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+const (
+	// DefaultComponentTimeout is the default amount of time to wait for all
+	// control plane components to become healthy.
+	DefaultComponentTimeout = 4 * time.Minute
+
+	// maxComponentRestarts is the number of container restarts after which a
+	// crash-looping component is considered failed, rather than waiting for
+	// the full timeout.
+	maxComponentRestarts = 3
+)
+
+// ComponentStatus is the health of a static pod component.
+type ComponentStatus struct {
+	Name        string
+	ContainerID string
+	State       string
+	ExitCode    int32
+	Restarts    int
+	Healthy     bool
+	Err         error
+}
+
+// componentWatcher tracks the containers and healthz endpoints of static pod
+// components. Every container created for a component is recorded, so that
+// restarts are counted even when the kubelet replaces the container between
+// checks.
+type componentWatcher struct {
+	r      *remote.RuntimeServiceClient
+	client *http.Client
+	pods   []*corev1.Pod
+	seen   map[string]map[string]bool
+	status map[string]*ComponentStatus
+}
+
+func newComponentWatcher(r *remote.RuntimeServiceClient, manifests []string) (*componentWatcher, error) {
+	w := &componentWatcher{
+		r: r,
+		client: &http.Client{
+			Timeout: 2 * time.Second,
+			Transport: &http.Transport{
+				// healthz endpoints are only being checked for availability,
+				// and components serve self-signed certificates by default
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+		seen:   make(map[string]map[string]bool),
+		status: make(map[string]*ComponentStatus),
+	}
+	for _, path := range manifests {
+		p, err := computil.ReadKubeComponent(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read static pod manifest %s", path)
+		}
+		if len(p.Spec.Containers) == 0 {
+			return nil, errors.Errorf("static pod manifest %s has no containers", path)
+		}
+		name := p.Spec.Containers[0].Name
+		w.pods = append(w.pods, p)
+		w.seen[name] = make(map[string]bool)
+		w.status[name] = &ComponentStatus{Name: name}
+	}
+	return w, nil
+}
+
+// Check updates and returns the status of all components.
+func (w *componentWatcher) Check(ctx context.Context) []ComponentStatus {
+	statuses := make([]ComponentStatus, 0)
+	for _, p := range w.pods {
+		s := w.status[p.Spec.Containers[0].Name]
+		healthy := s.Healthy
+		w.check(ctx, p, s)
+		if s.Healthy != healthy {
+			if s.Healthy {
+				log.Info("component is healthy", zap.String("component", s.Name), zap.Int("restarts", s.Restarts))
+			} else {
+				log.Warn("component is unhealthy", zap.String("component", s.Name), zap.Error(s.Err))
+			}
+		}
+		statuses = append(statuses, *s)
+	}
+	return statuses
+}
+
+func (w *componentWatcher) check(ctx context.Context, p *corev1.Pod, s *ComponentStatus) {
+	s.Healthy = false
+	c, err := w.r.GetLatestContainerByName(ctx, s.Name)
+	if err != nil {
+		s.State = "Waiting"
+		s.Err = errors.New("container has not been created")
+		return
+	}
+	if !w.seen[s.Name][c.Id] {
+		w.seen[s.Name][c.Id] = true
+		if n := len(w.seen[s.Name]) - 1; n > 0 {
+			s.Restarts = n
+			log.Warn("component restarted", zap.String("component", s.Name), zap.Int("restarts", n), zap.Int32("exitCode", s.ExitCode))
+		}
+	}
+	s.ContainerID = c.Id
+	status, err := w.r.GetContainerStatus(ctx, c.Id)
+	if err != nil {
+		s.Err = err
+		return
+	}
+	s.State = strings.TrimPrefix(status.State.String(), "CONTAINER_")
+	s.ExitCode = status.ExitCode
+	if status.State != runtimeapi.ContainerState_CONTAINER_RUNNING {
+		s.Err = errors.Errorf("container is %s with exit code %d", strings.ToLower(s.State), status.ExitCode)
+		return
+	}
+	if err := w.healthz(ctx, p); err != nil {
+		s.Err = err
+		return
+	}
+	s.Err = nil
+	s.Healthy = true
+}
+
+// healthz checks the component using the HTTP liveness probe of its static
+// pod manifest. Components without one are healthy once running.
+func (w *componentWatcher) healthz(ctx context.Context, p *corev1.Pod) error {
+	probe := p.Spec.Containers[0].LivenessProbe
+	if probe == nil || probe.HTTPGet == nil {
+		return nil
+	}
+	action := probe.HTTPGet
+	host := action.Host
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	scheme := strings.ToLower(string(action.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+	url := fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(action.Port.IntValue())), action.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "healthz check failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("healthz check %s returned status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+func printComponentStatuses(out io.Writer, statuses []ComponentStatus) {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "COMPONENT\tSTATE\tRESTARTS\tHEALTHY\tERROR")
+	for _, s := range statuses {
+		var msg string
+		if s.Err != nil {
+			msg = s.Err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%t\t%s\n", s.Name, s.State, s.Restarts, s.Healthy, msg)
+	}
+}
+
+func (c *Cluster) componentTimeout() time.Duration {
+	if c.rc == nil || c.rc.ComponentTimeout <= 0 {
+		return DefaultComponentTimeout
+	}
+	return c.rc.ComponentTimeout
+}
@@ -0,0 +1,78 @@
+package cluster
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/internal/feature"
+	"github.com/criticalstack/crit/pkg/config/constants"
+)
+
+// ControlPlaneImages returns the container images required to bootstrap a
+// control plane node with the provided configuration. Crit feature gates must
+// already be set, since they determine whether some components are used.
+func ControlPlaneImages(cfg *config.ControlPlaneConfiguration) []string {
+	components := []string{
+		constants.KubeAPIServer,
+		constants.KubeControllerManager,
+		constants.KubeScheduler,
+		constants.CoreDNS,
+		constants.Pause,
+	}
+	if !cfg.KubeProxyConfiguration.Disabled {
+		components = append(components, constants.KubeProxy)
+	}
+	if feature.Gates.Enabled(feature.BootstrapServer) {
+		components = append(components, constants.CritBootstrapServer)
+	}
+	if cfg.KubeAPIServerConfiguration.ExtraArgs["anonymous-auth"] == "false" {
+		components = append(components, constants.HealthcheckProxy)
+	}
+	images := make([]string, 0)
+	for _, c := range components {
+		images = append(images, cfg.Image(c))
+	}
+	sort.Strings(images)
+	return images
+}
+
+// WorkerImages returns the container images required to bootstrap a worker
+// node with the provided configuration.
+func WorkerImages(cfg *config.WorkerConfiguration) []string {
+	images := []string{
+		cfg.Image(constants.KubeProxy),
+		cfg.Image(constants.Pause),
+	}
+	sort.Strings(images)
+	return images
+}
+
+// RequiredImages returns the container images required to bootstrap a node
+// for either a control plane or worker configuration, along with the
+// container runtime used by the node. The crit feature gates found in the
+// configuration are also set.
+func RequiredImages(obj runtime.Object) ([]string, constants.ContainerRuntime, error) {
+	var images []string
+	var cr constants.ContainerRuntime
+	switch cfg := obj.(type) {
+	case *config.ControlPlaneConfiguration:
+		if err := feature.MutableGates.SetFromMap(cfg.FeatureGates); err != nil {
+			return nil, "", err
+		}
+		images, cr = ControlPlaneImages(cfg), cfg.NodeConfiguration.ContainerRuntime
+	case *config.WorkerConfiguration:
+		if err := feature.MutableGates.SetFromMap(cfg.FeatureGates); err != nil {
+			return nil, "", err
+		}
+		images, cr = WorkerImages(cfg), cfg.NodeConfiguration.ContainerRuntime
+	default:
+		return nil, "", errors.Errorf("received invalid configuration type: %T", obj)
+	}
+	if !cr.IsValid() {
+		return nil, "", errors.Errorf("unknown container runtime %q, must be one of %v", cr, constants.ContainerRuntimes)
+	}
+	return images, cr, nil
+}
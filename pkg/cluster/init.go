@@ -2,15 +2,14 @@ package cluster
 
 import (
 	"context"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
-	"sort"
 	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 
@@ -42,6 +41,9 @@ func (c *Cluster) WriteKubeConfigs(ctx context.Context, cfg *config.ControlPlane
 			return err
 		}
 	}
+	for filename := range clusterutil.KubeConfigSpecs(cfg) {
+		c.events.wrote(filepath.Join(cfg.NodeConfiguration.KubeDir, filename))
+	}
 	return nil
 }
 
@@ -51,79 +53,73 @@ func (c *Cluster) WriteKubeManifests(ctx context.Context, cfg *config.ControlPla
 	if err != nil {
 		return err
 	}
-	if err := computil.WriteKubeComponent(p, filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests/kube-apiserver.yaml")); err != nil {
-		return err
+	pods := map[string]*corev1.Pod{
+		"kube-apiserver":          p,
+		"kube-controller-manager": components.NewControllerManagerStaticPod(cfg),
+		"kube-scheduler":          components.NewSchedulerStaticPod(cfg),
 	}
-	if err := computil.WriteKubeComponent(components.NewControllerManagerStaticPod(cfg), filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests/kube-controller-manager.yaml")); err != nil {
-		return err
+	for _, name := range controlPlaneComponents {
+		path := filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests", name+".yaml")
+		if err := computil.WriteKubeComponent(pods[name], path); err != nil {
+			return err
+		}
+		c.events.wrote(path)
 	}
-	return computil.WriteKubeComponent(components.NewSchedulerStaticPod(cfg), filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests/kube-scheduler.yaml"))
+	return nil
 }
 
 func (c *Cluster) WriteBootstrapServerManifest(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
 	log.Info("bootstrap-server-manifest", zap.String("description", "write bootstrap server static pod manifest to disk"))
-	return computil.WriteKubeComponent(components.NewBootstrapServerStaticPod(cfg), filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests/crit-bootstrap-server.yaml"))
+	path := filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests/crit-bootstrap-server.yaml")
+	if err := computil.WriteKubeComponent(components.NewBootstrapServerStaticPod(cfg), path); err != nil {
+		return err
+	}
+	c.events.wrote(path)
+	return nil
+}
+
+// controlPlaneComponents are the static pods written by WriteKubeManifests.
+var controlPlaneComponents = []string{
+	"kube-apiserver",
+	"kube-controller-manager",
+	"kube-scheduler",
 }
 
 func (c *Cluster) WaitClusterAvailable(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
 	log.Info("cluster-available", zap.String("description", "wait for cluster to become available"))
-	ctx, cancel := context.WithTimeout(ctx, 4*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, c.componentTimeout())
 	defer cancel()
 
 	r, err := remote.NewRuntimeServiceClient(ctx, cfg.NodeConfiguration.ContainerRuntime.CRISocket())
 	if err != nil {
 		return err
 	}
-
-	// The apiserver container is only ever queried once, because the
-	// presumption is made that it should not have to restart during
-	// initial bootstrapping. Should this no longer be the case in the
-	// future, this will need to be adapted to account for scenarios where
-	// the intended flow of the apiserver allows for 1 or more container
-	// restarts.
-	var container *runtimeapi.Container
-	if err := wait.PollImmediateUntil(500*time.Millisecond, func() (bool, error) {
-		var err error
-		container, err = r.GetContainerByName(ctx, "kube-apiserver")
-		if err != nil {
-			return false, nil
-		}
-		return true, nil
-	}, ctx.Done()); err != nil {
-		return err
+	manifests := make([]string, 0)
+	for _, name := range controlPlaneComponents {
+		manifests = append(manifests, filepath.Join(cfg.NodeConfiguration.KubeDir, "manifests", name+".yaml"))
 	}
-	status, err := r.GetContainerStatus(ctx, container.GetId())
+	w, err := newComponentWatcher(r, manifests)
 	if err != nil {
 		return err
 	}
 
-	// While waiting for the apiserver to start, the status of the
-	// container is checked and short circuits should the container be
-	// unavailable or stopped with error.
-	errCh := make(chan error, 1)
-	go func() {
-		defer close(errCh)
-
-		//st := time.Now()
-		errCh <- wait.PollImmediateUntil(500*time.Millisecond, func() (bool, error) {
-			status, err := r.GetContainerStatus(ctx, container.GetId())
-			if err != nil {
-				return false, errors.Wrap(err, "kube-apiserver container exited")
-			}
-
-			if status.ExitCode != 0 {
-				return false, errors.Errorf("kube-apiserver container exited with code: %d", status.ExitCode)
-			}
-			return false, nil
-		}, ctx.Done())
-	}()
-
 	// The apiserver container logs will only stream logs when verbose
 	// output is requested from the user. For those not familiar with the
 	// output of the apiserver, these logs can be confusing and unhelpful
 	// in most situations.
 	if c.rc.Verbose {
 		go func() {
+			var status *runtimeapi.ContainerStatus
+			if err := wait.PollImmediateUntil(500*time.Millisecond, func() (bool, error) {
+				container, err := r.GetContainerByName(ctx, "kube-apiserver")
+				if err != nil {
+					return false, nil
+				}
+				status, err = r.GetContainerStatus(ctx, container.GetId())
+				return err == nil, nil
+			}, ctx.Done()); err != nil {
+				return
+			}
 			stdout := executil.NewPrefixWriter(os.Stdout, "\t")
 			defer stdout.Close()
 
@@ -133,56 +129,73 @@ func (c *Cluster) WaitClusterAvailable(ctx context.Context, cfg *config.ControlP
 		}()
 	}
 
-	ticker := time.NewTicker(500 * time.Millisecond)
+	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
+	var statuses []ComponentStatus
 	for {
 		select {
 		case <-ticker.C:
-			// Finally, check for the apiserver to start reporting as healthy.
-			status := 0
-			c.Client().Discovery().RESTClient().Get().AbsPath("/healthz").Do(ctx).StatusCode(&status)
-			if status == http.StatusOK {
-				return nil
+			statuses = w.Check(ctx)
+			if err := componentsFailed(statuses); err != nil {
+				return c.componentsFailure(cfg, statuses, err)
 			}
-		case reterr := <-errCh:
-			// already printing the logs, so just exit
-			if c.rc.Verbose {
-				return reterr
+			if !componentsHealthy(statuses) {
+				continue
 			}
-			stdout := executil.NewPrefixWriter(os.Stdout, "\t")
-			defer stdout.Close()
 
-			// We have to stop the kubelet and range over the container log
-			// path directory. This is for cases like cinder, where the
-			// systemd service restarts so quickly (every 1s) that the
-			// stored log path is no longer available.
-			if err := systemd.StopUnit("kubelet.service"); err != nil {
-				return err
-			}
-			dir := filepath.Dir(status.GetLogPath())
-			infos, err := ioutil.ReadDir(dir)
-			if err != nil {
-				return err
-			}
-			files := make([]string, 0)
-			for _, info := range infos {
-				files = append(files, filepath.Join(dir, info.Name()))
-			}
-			sort.Sort(sort.Reverse(sort.StringSlice(files)))
-			for _, file := range files {
-				if err := r.ReadLogs(ctx, file, stdout); err != nil {
-					log.Debug("cannot read log path",
-						zap.Error(err),
-						zap.String("path", file),
-					)
-					continue
+			// Finally, check for the apiserver to start reporting as healthy
+			// through the control plane endpoint.
+			status := 0
+			c.Client().Discovery().RESTClient().Get().AbsPath("/healthz").Do(ctx).StatusCode(&status)
+			if status == http.StatusOK {
+				for _, s := range statuses {
+					log.Info("component status", zap.String("component", s.Name), zap.String("state", s.State), zap.Int("restarts", s.Restarts))
 				}
-				return reterr
+				return nil
 			}
-			return reterr
 		case <-ctx.Done():
-			return ctx.Err()
+			return c.componentsFailure(cfg, statuses, errors.Wrap(ctx.Err(), "timed out waiting for control plane components"))
+		}
+	}
+}
+
+func componentsHealthy(statuses []ComponentStatus) bool {
+	for _, s := range statuses {
+		if !s.Healthy {
+			return false
+		}
+	}
+	return len(statuses) > 0
+}
+
+// componentsFailed returns an error if any component is crash-looping.
+func componentsFailed(statuses []ComponentStatus) error {
+	for _, s := range statuses {
+		if s.Restarts > maxComponentRestarts {
+			return errors.Errorf("%s container restarted %d times, last exit code: %d", s.Name, s.Restarts, s.ExitCode)
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) componentsFailure(cfg *config.ControlPlaneConfiguration, statuses []ComponentStatus, reterr error) error {
+	stderr := executil.NewPrefixWriter(os.Stderr, "\t")
+	printComponentStatuses(stderr, statuses)
+	stderr.Close()
+
+	// We have to stop the kubelet before collecting the container logs. This
+	// is for cases like cinder, where the systemd service restarts so quickly
+	// (every 1s) that the stored log path is no longer available.
+	if err := systemd.StopUnit("kubelet.service"); err != nil {
+		log.Error("cannot stop kubelet", zap.Error(err))
+	}
+	unhealthy := make([]string, 0)
+	for _, s := range statuses {
+		if !s.Healthy {
+			unhealthy = append(unhealthy, s.Name)
 		}
 	}
+	c.reportFailure(&cfg.NodeConfiguration, "cluster-available", reterr, unhealthy...)
+	return reterr
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/criticalstack/crit/pkg/kubeconfig"
 	"github.com/criticalstack/crit/pkg/kubernetes"
 	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/trace"
 	executil "github.com/criticalstack/crit/pkg/util/exec"
 	netutil "github.com/criticalstack/crit/pkg/util/net"
 	"github.com/criticalstack/crit/pkg/util/systemd"
@@ -46,12 +47,18 @@ func (c *Cluster) WriteKubeletConfigs(ctx context.Context, cfg *config.NodeConfi
 	if err := components.WriteKubeletDynamicEnvFile(cfg, false, DefaultKubeletDir); err != nil {
 		return err
 	}
+	c.events.wrote(filepath.Join(DefaultKubeletDir, components.KubeletEnvFileName))
 	cfg.KubeletConfiguration.Authentication.X509.ClientCAFile = filepath.Join(cfg.KubeDir, "pki/ca.crt")
 	if cfg.KubeletConfiguration.StaticPodPath == "" {
 		cfg.KubeletConfiguration.StaticPodPath = filepath.Join(cfg.KubeDir, "manifests")
 	}
 	cfg.KubeletConfiguration.RotateCertificates = true
-	return components.WriteKubeletConfigFile(cfg.KubeletConfiguration, filepath.Join(DefaultKubeletDir, "config.yaml"))
+	path := filepath.Join(DefaultKubeletDir, "config.yaml")
+	if err := components.WriteKubeletConfigFile(cfg.KubeletConfiguration, path); err != nil {
+		return err
+	}
+	c.events.wrote(path)
+	return nil
 }
 
 func (c *Cluster) StopKubelet(ctx context.Context, cfg *config.NodeConfiguration) error {
@@ -91,7 +98,9 @@ func (c *Cluster) StartKubelet(ctx context.Context, cfg *config.NodeConfiguratio
 		defer stderr.Close()
 
 		stderr.Write([]byte(kubeletFailureMessage))
-		return errors.New("Attempt to start the kubelet.service was not successful")
+		reterr := errors.New("Attempt to start the kubelet.service was not successful")
+		c.reportFailure(cfg, "start-kubelet", reterr)
+		return reterr
 	}
 	return nil
 }
@@ -106,6 +115,7 @@ func (c *Cluster) WriteBootstrapKubeletConfig(ctx context.Context, cfg *config.W
 	if err != nil {
 		return errors.Wrap(err, "failed to create API client configuration from kubeconfig")
 	}
+	clientConfig.Wrap(trace.NewTransport)
 	client, err := clientset.NewForConfig(clientConfig)
 	if err != nil {
 		return err
@@ -141,5 +151,10 @@ func (c *Cluster) WriteBootstrapKubeletConfig(ctx context.Context, cfg *config.W
 			cfg.NodeConfiguration.KubeletConfiguration.ClusterDNS = []string{dnsIP.String()}
 		}
 	}
-	return kubeconfig.WriteToFile(bootstrapKubeletConf, filepath.Join(cfg.NodeConfiguration.KubeDir, "bootstrap-kubelet.conf"))
+	path := filepath.Join(cfg.NodeConfiguration.KubeDir, "bootstrap-kubelet.conf")
+	if err := kubeconfig.WriteToFile(bootstrapKubeletConf, path); err != nil {
+		return err
+	}
+	c.events.wrote(path)
+	return nil
 }
@@ -62,6 +62,12 @@ func Execute(path string, v interface{}) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	return ExecuteTemplate(path, data, v)
+}
+
+// ExecuteTemplate applies the provided template data using the same template
+// functions available to the embedded files in this package.
+func ExecuteTemplate(path string, data []byte, v interface{}) ([]byte, error) {
 	filename := filepath.Base(path)
 	name := strings.TrimSuffix(filename, filepath.Ext(filename))
 	t, err := template.New(name).Funcs(template.FuncMap{
@@ -0,0 +1,233 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/log"
+	netutil "github.com/criticalstack/crit/pkg/util/net"
+)
+
+// ApplyNodeOverrides patches the NodeConfiguration with every NodeOverride
+// that matches the host, in order. The config is defaulted again afterwards,
+// since an override can unset defaulted fields. Commands that act on the
+// config of a node should call this before using it, so that they see the
+// same NodeConfiguration as crit up.
+func ApplyNodeOverrides(ctx context.Context, obj runtime.Object) error {
+	var overrides []config.NodeOverride
+	var node *config.NodeConfiguration
+	switch cfg := obj.(type) {
+	case *config.ControlPlaneConfiguration:
+		overrides, node = cfg.NodeOverrides, &cfg.NodeConfiguration
+	case *config.WorkerConfiguration:
+		overrides, node = cfg.NodeOverrides, &cfg.NodeConfiguration
+	default:
+		return errors.Errorf("received invalid configuration type: %T", obj)
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	if errs := validateNodeOverrides(overrides); len(errs) > 0 {
+		return errs[0]
+	}
+	m := &nodeMatcher{node: node}
+	applied := false
+	for i, o := range overrides {
+		ok, err := m.matches(ctx, &o)
+		if err != nil {
+			return errors.Wrapf(err, "nodeOverrides[%d]", i)
+		}
+		if !ok {
+			continue
+		}
+		if err := patchNodeConfiguration(node, o.NodeConfiguration.Raw); err != nil {
+			return errors.Wrapf(err, "nodeOverrides[%d]", i)
+		}
+		tags := make([]string, 0, len(o.InstanceTags))
+		for k, v := range o.InstanceTags {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+		log.Info("applied node override",
+			zap.Int("index", i),
+			zap.String("hostname", o.Hostname),
+			zap.String("cidr", o.CIDR),
+			zap.Strings("instance_tags", tags),
+		)
+		applied = true
+	}
+	if applied {
+		clientsetscheme.Scheme.Default(obj)
+	}
+	return nil
+}
+
+// validateNodeOverrides checks the selectors and patch of each NodeOverride,
+// without inspecting the host.
+func validateNodeOverrides(overrides []config.NodeOverride) (errs []error) {
+	for i, o := range overrides {
+		if o.Hostname == "" && o.CIDR == "" && len(o.InstanceTags) == 0 {
+			errs = append(errs, errors.Errorf("nodeOverrides[%d]: must provide at least one of hostname, cidr or instanceTags", i))
+		}
+		if _, err := path.Match(o.Hostname, ""); err != nil {
+			errs = append(errs, errors.Errorf("nodeOverrides[%d]: invalid hostname pattern %q", i, o.Hostname))
+		}
+		if o.CIDR != "" {
+			if _, _, err := net.ParseCIDR(o.CIDR); err != nil {
+				errs = append(errs, errors.Errorf("nodeOverrides[%d]: invalid cidr %q", i, o.CIDR))
+			}
+		}
+		if err := patchNodeConfiguration(&config.NodeConfiguration{}, o.NodeConfiguration.Raw); err != nil {
+			errs = append(errs, errors.Wrapf(err, "nodeOverrides[%d]", i))
+		}
+	}
+	return errs
+}
+
+func patchNodeConfiguration(node *config.NodeConfiguration, patch []byte) error {
+	if len(patch) == 0 {
+		return errors.New("must provide node")
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(patch, &m); err != nil || m == nil {
+		return errors.New("node must be an object")
+	}
+	orig, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	data, err := strategicpatch.StrategicMergePatch(orig, patch, config.NodeConfiguration{})
+	if err != nil {
+		return errors.Wrap(err, "cannot apply node patch")
+	}
+	var patched config.NodeConfiguration
+	if err := json.Unmarshal(data, &patched); err != nil {
+		return errors.Wrap(err, "cannot apply node patch")
+	}
+	*node = patched
+	return nil
+}
+
+// nodeMatcher matches the selectors of a NodeOverride against the host.
+// Interface addresses and instance tags are only looked up when an override
+// needs them, and only once.
+type nodeMatcher struct {
+	node  *config.NodeConfiguration
+	addrs []net.IP
+	tags  map[string]string
+}
+
+// matches returns true if every selector provided by the NodeOverride
+// matches the host.
+func (m *nodeMatcher) matches(ctx context.Context, o *config.NodeOverride) (bool, error) {
+	if o.Hostname != "" {
+		hostname := m.node.Hostname
+		if hostname == "" {
+			hostname, _ = os.Hostname()
+		}
+		if ok, _ := path.Match(strings.ToLower(o.Hostname), strings.ToLower(hostname)); !ok {
+			return false, nil
+		}
+	}
+	if o.CIDR != "" {
+		_, ipnet, err := net.ParseCIDR(o.CIDR)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid cidr %q", o.CIDR)
+		}
+		addrs, err := m.hostAddrs()
+		if err != nil {
+			return false, err
+		}
+		found := false
+		for _, ip := range addrs {
+			if ipnet.Contains(ip) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	if len(o.InstanceTags) > 0 {
+		if m.tags == nil {
+			tags, err := getInstanceTags(ctx)
+			if err != nil {
+				return false, errors.Wrap(err, "cannot get instance tags")
+			}
+			m.tags = tags
+		}
+		for k, v := range o.InstanceTags {
+			if tv, ok := m.tags[k]; !ok || tv != v {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// hostAddrs returns the host IP addresses provided by the NodeConfiguration
+// along with the addresses of every network interface.
+func (m *nodeMatcher) hostAddrs() ([]net.IP, error) {
+	if m.addrs != nil {
+		return m.addrs, nil
+	}
+	addrs := make([]net.IP, 0)
+	for _, ip := range m.node.HostIPs() {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			addrs = append(addrs, parsed)
+		}
+	}
+	ifaces, err := netutil.HostInterfaces(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, iface := range ifaces {
+		addrs = append(addrs, iface.Addrs...)
+	}
+	m.addrs = addrs
+	return addrs, nil
+}
+
+// getInstanceTags returns the tags of the EC2 instance from the instance
+// metadata service. Tags are only available when instance metadata tags have
+// been enabled for the instance.
+var getInstanceTags = func(ctx context.Context) (map[string]string, error) {
+	sess, err := session.NewSession(aws.NewConfig())
+	if err != nil {
+		return nil, err
+	}
+	svc := ec2metadata.New(sess)
+	keys, err := svc.GetMetadataWithContext(ctx, "tags/instance")
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for _, k := range strings.Split(keys, "\n") {
+		if k = strings.TrimSpace(k); k == "" {
+			continue
+		}
+		v, err := svc.GetMetadataWithContext(ctx, fmt.Sprintf("tags/instance/%s", k))
+		if err != nil {
+			return nil, err
+		}
+		tags[k] = v
+	}
+	return tags, nil
+}
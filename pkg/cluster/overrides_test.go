@@ -0,0 +1,184 @@
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/criticalstack/crit/internal/config"
+)
+
+func TestNodeMatcherMatches(t *testing.T) {
+	getInstanceTagsOrig := getInstanceTags
+	defer func() { getInstanceTags = getInstanceTagsOrig }()
+
+	cases := []struct {
+		name     string
+		override config.NodeOverride
+		tags     map[string]string
+		tagsErr  error
+		expected bool
+		err      bool
+	}{
+		{
+			name:     "hostname glob",
+			override: config.NodeOverride{Hostname: "worker-*"},
+			expected: true,
+		},
+		{
+			name:     "hostname glob is case insensitive",
+			override: config.NodeOverride{Hostname: "WORKER-?"},
+			expected: true,
+		},
+		{
+			name:     "hostname glob mismatch",
+			override: config.NodeOverride{Hostname: "control-plane-*"},
+			expected: false,
+		},
+		{
+			name:     "cidr contains host address",
+			override: config.NodeOverride{CIDR: "192.0.2.0/24"},
+			expected: true,
+		},
+		{
+			name:     "cidr does not contain host address",
+			override: config.NodeOverride{CIDR: "198.51.100.0/24"},
+			expected: false,
+		},
+		{
+			name:     "invalid cidr",
+			override: config.NodeOverride{CIDR: "192.0.2.0"},
+			err:      true,
+		},
+		{
+			name:     "instance tags",
+			override: config.NodeOverride{InstanceTags: map[string]string{"role": "ingress"}},
+			tags:     map[string]string{"role": "ingress", "env": "production"},
+			expected: true,
+		},
+		{
+			name:     "instance tags mismatch",
+			override: config.NodeOverride{InstanceTags: map[string]string{"role": "ingress", "env": "staging"}},
+			tags:     map[string]string{"role": "ingress", "env": "production"},
+			expected: false,
+		},
+		{
+			name:     "instance tags unavailable",
+			override: config.NodeOverride{InstanceTags: map[string]string{"role": "ingress"}},
+			tagsErr:  errors.New("metadata service unavailable"),
+			err:      true,
+		},
+		{
+			name: "all selectors must match",
+			override: config.NodeOverride{
+				Hostname:     "worker-*",
+				CIDR:         "192.0.2.0/24",
+				InstanceTags: map[string]string{"role": "database"},
+			},
+			tags:     map[string]string{"role": "ingress"},
+			expected: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getInstanceTags = func(ctx context.Context) (map[string]string, error) {
+				return tc.tags, tc.tagsErr
+			}
+			m := &nodeMatcher{
+				node: &config.NodeConfiguration{
+					Hostname: "worker-1",
+					HostIPv4: "192.0.2.10",
+				},
+			}
+			ok, err := m.matches(context.Background(), &tc.override)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tc.expected {
+				t.Fatalf("expected %t, received %t", tc.expected, ok)
+			}
+		})
+	}
+}
+
+func TestPatchNodeConfiguration(t *testing.T) {
+	cases := []struct {
+		name     string
+		node     config.NodeConfiguration
+		patch    string
+		expected config.NodeConfiguration
+		err      bool
+	}{
+		{
+			name: "maps are merged",
+			node: config.NodeConfiguration{
+				KubernetesVersion: "1.18.5",
+				Labels:            map[string]string{"env": "production"},
+				KubeletExtraArgs:  map[string]string{"v": "2"},
+			},
+			patch: `{"labels":{"role":"ingress"},"kubeletExtraArgs":{"max-pods":"200"}}`,
+			expected: config.NodeConfiguration{
+				KubernetesVersion: "1.18.5",
+				Labels:            map[string]string{"env": "production", "role": "ingress"},
+				KubeletExtraArgs:  map[string]string{"v": "2", "max-pods": "200"},
+			},
+		},
+		{
+			name: "fields are replaced",
+			node: config.NodeConfiguration{
+				KubernetesVersion: "1.18.5",
+				HostIPv4:          "192.0.2.10",
+			},
+			patch: `{"hostIPv4":"198.51.100.10"}`,
+			expected: config.NodeConfiguration{
+				KubernetesVersion: "1.18.5",
+				HostIPv4:          "198.51.100.10",
+			},
+		},
+		{
+			name: "fields are removed",
+			node: config.NodeConfiguration{
+				Labels: map[string]string{"env": "production", "role": "ingress"},
+			},
+			patch: `{"labels":{"role":null}}`,
+			expected: config.NodeConfiguration{
+				Labels: map[string]string{"env": "production"},
+			},
+		},
+		{
+			name: "empty patch",
+			err:  true,
+		},
+		{
+			name:  "patch is not an object",
+			patch: `["hostIPv4"]`,
+			err:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := tc.node
+			err := patchNodeConfiguration(&node, []byte(tc.patch))
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(node, tc.expected) {
+				t.Fatalf("expected %+v, received %+v", tc.expected, node)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package cluster
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/cluster/components"
+	"github.com/criticalstack/crit/pkg/config/constants"
+	"github.com/criticalstack/crit/pkg/kubernetes"
+	"github.com/criticalstack/crit/pkg/kubernetes/dynamic"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+// WritePodSecurityAdmissionConfig writes the admission configuration file for
+// the PodSecurity admission plugin. This must happen prior to writing the
+// apiserver static pod manifest, and is only necessary for versions of
+// Kubernetes where PodSecurityPolicy is no longer used.
+func (c *Cluster) WritePodSecurityAdmissionConfig(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
+	if !cfg.PodSecurity.Enabled || !components.UsePodSecurityAdmission(cfg.NodeConfiguration.KubernetesVersion) {
+		return nil
+	}
+	log.Info("pod-security-admission-config", zap.String("description", "write PodSecurity admission configuration to disk"))
+	data, err := components.NewPodSecurityAdmissionConfig(cfg)
+	if err != nil {
+		return err
+	}
+	path := components.PodSecurityAdmissionConfigFile(cfg)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	c.events.wrote(path)
+	return nil
+}
+
+// ApplyPodSecurity configures the per-namespace pod security levels. When
+// using the PodSecurity admission plugin, namespaces are labeled with the
+// configured level. Otherwise, the privileged and restricted
+// PodSecurityPolicies are applied and bound to the namespaces.
+func (c *Cluster) ApplyPodSecurity(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
+	if !cfg.PodSecurity.Enabled {
+		log.Debug("pod security disabled")
+		return nil
+	}
+	log.Info("pod-security", zap.String("description", "apply pod security standards to namespaces"))
+	if components.UsePodSecurityAdmission(cfg.NodeConfiguration.KubernetesVersion) {
+		for ns, level := range cfg.PodSecurity.Namespaces {
+			if err := kubernetes.UpdateNamespaceLabels(c.Client(), ctx, ns, map[string]string{
+				"pod-security.kubernetes.io/enforce": level,
+				"pod-security.kubernetes.io/audit":   level,
+				"pod-security.kubernetes.io/warn":    level,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, name := range []string{"psp-privileged.yaml", "psp-restricted.yaml"} {
+		data, err := Execute(name, cfg)
+		if err != nil {
+			return err
+		}
+		if err := dynamic.Apply(ctx, c.Config(), data); err != nil {
+			return err
+		}
+	}
+
+	// The psp-restricted template binds the restricted policy to all service
+	// accounts and authenticated users, so only a privileged default level
+	// requires an additional cluster-wide binding.
+	if cfg.PodSecurity.DefaultLevel == constants.PodSecurityLevelPrivileged {
+		if err := kubernetes.UpdateClusterRoleBinding(c.Client(), ctx, &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "psp:privileged:default",
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     "psp:privileged",
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:     rbacv1.GroupKind,
+					APIGroup: rbacv1.GroupName,
+					Name:     "system:serviceaccounts",
+				},
+				{
+					Kind:     rbacv1.GroupKind,
+					APIGroup: rbacv1.GroupName,
+					Name:     "system:authenticated",
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	for ns, level := range cfg.PodSecurity.Namespaces {
+		if level != constants.PodSecurityLevelPrivileged {
+			continue
+		}
+		if err := kubernetes.UpdateNamespaceLabels(c.Client(), ctx, ns, nil); err != nil {
+			return err
+		}
+		if err := kubernetes.UpdateRoleBinding(c.Client(), ctx, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "psp:privileged",
+				Namespace: ns,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     "psp:privileged",
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:     rbacv1.GroupKind,
+					APIGroup: rbacv1.GroupName,
+					Name:     "system:serviceaccounts:" + ns,
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/criticalstack/crit/internal/config"
 	"github.com/criticalstack/crit/pkg/cluster/bootstrap"
+	"github.com/criticalstack/crit/pkg/config/constants"
 	"github.com/criticalstack/crit/pkg/kubernetes"
 	nodeutil "github.com/criticalstack/crit/pkg/kubernetes/util/node"
 	yamlutil "github.com/criticalstack/crit/pkg/kubernetes/yaml"
@@ -25,14 +26,37 @@ func (c *Cluster) EnableCSRApprover(ctx context.Context, cfg *config.ControlPlan
 	return bootstrap.ApplyCSRApproverRBAC(c.Client(), ctx)
 }
 
+// MarkControlPlane applies the control plane taints and labels, along with
+// any taints from NodeConfiguration, to the control plane node.
 func (c *Cluster) MarkControlPlane(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
-	log.Info("mark-control-plane", zap.String("description", "add taint to control plane node"))
-	return nodeutil.PatchNodeWithContext(ctx, c.Client(), cfg.NodeConfiguration.Hostname, func(n *corev1.Node) {
-		nodeutil.AddTaint(n, corev1.Taint{
-			Key:    "node-role.kubernetes.io/master",
-			Effect: corev1.TaintEffectNoSchedule,
-		})
-		n.ObjectMeta.Labels["node-role.kubernetes.io/master"] = ""
+	log.Info("mark-control-plane", zap.String("description", "add taints and labels to control plane node"))
+	taints := make([]corev1.Taint, 0)
+	taints = append(taints, cfg.ControlPlaneNode.Taints...)
+	taints = append(taints, cfg.NodeConfiguration.Taints...)
+	labels := map[string]string{
+		constants.LabelNodeRoleControlPlane: "",
+	}
+	if cfg.ControlPlaneNode.LegacyMasterLabel == nil || *cfg.ControlPlaneNode.LegacyMasterLabel {
+		labels[constants.LabelNodeRoleMaster] = ""
+	}
+	return nodeutil.PatchNodeWithContext(ctx, c.Client(), kubeletNodeName(&cfg.NodeConfiguration), func(n *corev1.Node) {
+		for _, t := range taints {
+			nodeutil.AddTaint(n, t)
+		}
+		nodeutil.SetLabelsAndAnnotations(n, labels, nil)
+	})
+}
+
+// LabelNode applies the labels that the kubelet is not allowed to set, along
+// with any annotations, after the control plane node has registered.
+func (c *Cluster) LabelNode(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
+	log.Info("label-node", zap.String("description", "apply restricted labels and annotations to control plane node"))
+	_, restricted := nodeutil.SplitLabels(cfg.NodeConfiguration.Labels)
+	if len(restricted) == 0 && len(cfg.NodeConfiguration.Annotations) == 0 {
+		return nil
+	}
+	return nodeutil.PatchNodeWithContext(ctx, c.Client(), kubeletNodeName(&cfg.NodeConfiguration), func(n *corev1.Node) {
+		nodeutil.SetLabelsAndAnnotations(n, restricted, cfg.NodeConfiguration.Annotations)
 	})
 }
 
@@ -0,0 +1,207 @@
+package cluster
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/internal/feature"
+	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	"github.com/criticalstack/crit/pkg/cluster/preflight"
+	"github.com/criticalstack/crit/pkg/config/constants"
+	"github.com/criticalstack/crit/pkg/kubernetes/remote"
+	"github.com/criticalstack/crit/pkg/log"
+	executil "github.com/criticalstack/crit/pkg/util/exec"
+	fmtutil "github.com/criticalstack/crit/pkg/util/fmt"
+)
+
+const (
+	defaultKubeletPort = 10250
+	defaultEtcdPort    = 2379
+)
+
+func nodePreflightChecks(cfg *config.NodeConfiguration, endpoint computil.APIEndpoint) []preflight.Checker {
+	failSwapOn := true
+	if kc := cfg.KubeletConfiguration; kc != nil && kc.FailSwapOn != nil {
+		failSwapOn = *kc.FailSwapOn
+	}
+	kubeletPort := defaultKubeletPort
+	cgroupDriver := ""
+	if kc := cfg.KubeletConfiguration; kc != nil {
+		if kc.Port != 0 {
+			kubeletPort = int(kc.Port)
+		}
+		cgroupDriver = kc.CgroupDriver
+	}
+	checks := []preflight.Checker{
+		preflight.SwapCheck{FailSwapOn: failSwapOn},
+		preflight.BridgeNetfilterCheck{},
+		preflight.SysctlCheck{Key: "net.bridge.bridge-nf-call-iptables", Value: "1"},
+		preflight.SysctlCheck{Key: "net.ipv4.ip_forward", Value: "1"},
+	}
+	if cfg.HostIPv6 != "" {
+		checks = append(checks,
+			preflight.SysctlCheck{Key: "net.bridge.bridge-nf-call-ip6tables", Value: "1"},
+			preflight.SysctlCheck{Key: "net.ipv6.conf.all.forwarding", Value: "1"},
+		)
+	}
+	checks = append(checks,
+		preflight.RuntimeSocketCheck{Runtime: cfg.ContainerRuntime},
+		preflight.CgroupDriverCheck{
+			KubeletCgroupDriver: cgroupDriver,
+			Runtime:             cfg.ContainerRuntime,
+			RuntimeCgroupDriver: runtimeCgroupDriver,
+		},
+		preflight.PortCheck{Port: kubeletPort, Processes: []string{"kubelet"}},
+		preflight.DiskSpaceCheck{Path: "/var/lib", MinFree: preflight.DefaultMinFreeDiskSpace},
+		preflight.ManifestsCheck{KubeDir: cfg.KubeDir},
+		newHostRouteCheck(cfg, endpoint),
+	)
+	return checks
+}
+
+func newHostRouteCheck(cfg *config.NodeConfiguration, endpoint computil.APIEndpoint) preflight.HostRouteCheck {
+	port := endpoint.Port
+	if port == 0 {
+		port = DefaultKubeAPIServerPort
+	}
+	return preflight.HostRouteCheck{
+		HostIPs: cfg.HostIPs(),
+		Host:    endpoint.Host,
+		Port:    port,
+	}
+}
+
+// runtimeCgroupDriver queries the container runtime for its cgroup driver,
+// falling back to the container runtime configuration files when the
+// runtime is not running or does not report it.
+func runtimeCgroupDriver(ctx context.Context, h *preflight.Host, cr constants.ContainerRuntime) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	driver, err := remote.RuntimeCgroupDriver(ctx, cr)
+	if err != nil {
+		log.Debug("cannot query container runtime cgroup driver", zap.String("runtime", string(cr)), zap.Error(err))
+	}
+	if driver != "" {
+		return driver, nil
+	}
+	return preflight.RuntimeConfigCgroupDriver(ctx, h, cr)
+}
+
+// setKubeletCgroupDriverDefault sets the kubelet cgroup driver to match the
+// container runtime when it has not been explicitly configured. A mismatch
+// between the two prevents the kubelet from starting, so an explicit value
+// is checked by the CgroupDriver preflight check instead.
+func setKubeletCgroupDriverDefault(ctx context.Context, cfg *config.NodeConfiguration) {
+	if cfg.KubeletConfiguration == nil || cfg.KubeletConfiguration.CgroupDriver != "" {
+		return
+	}
+	driver, err := runtimeCgroupDriver(ctx, &preflight.Host{}, cfg.ContainerRuntime)
+	if err != nil || driver == "" {
+		log.Warn("cannot determine container runtime cgroup driver, defaulting to cgroupfs", zap.String("runtime", string(cfg.ContainerRuntime)), zap.Error(err))
+		driver = remote.CgroupDriverCgroupfs
+	}
+	log.Debug("setting kubelet cgroup driver", zap.String("cgroupDriver", driver))
+	cfg.KubeletConfiguration.CgroupDriver = driver
+}
+
+// ControlPlanePreflightChecks returns the host checks for a control plane
+// node.
+func ControlPlanePreflightChecks(cfg *config.ControlPlaneConfiguration) []preflight.Checker {
+	checks := nodePreflightChecks(&cfg.NodeConfiguration, cfg.ControlPlaneEndpoint)
+	checks = append(checks, preflight.PortCheck{
+		Port:      cfg.KubeAPIServerConfiguration.BindPort,
+		Processes: []string{"kube-apiserver"},
+	})
+	if feature.Gates.Enabled(feature.BootstrapServer) {
+		checks = append(checks, preflight.PortCheck{
+			Port: cfg.CritBootstrapServerConfiguration.BindPort,
+			// process names are truncated to 15 characters
+			Processes: []string{"bootstrap-serve"},
+		})
+	}
+
+	// etcd must already be running when it is colocated with the control
+	// plane
+	for _, ep := range cfg.EtcdConfiguration.Endpoints {
+		if port, ok := localEtcdPort(cfg, ep); ok {
+			checks = append(checks, preflight.ListeningCheck{Port: port, Service: "etcd"})
+		}
+	}
+	return checks
+}
+
+func localEtcdPort(cfg *config.ControlPlaneConfiguration, endpoint string) (int, bool) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return 0, false
+	}
+	port := defaultEtcdPort
+	if p, err := strconv.Atoi(u.Port()); err == nil {
+		port = p
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1", cfg.NodeConfiguration.HostIPv4, cfg.NodeConfiguration.HostIPv6:
+		return port, true
+	}
+	return 0, false
+}
+
+// WorkerPreflightChecks returns the host checks for a worker node.
+func WorkerPreflightChecks(cfg *config.WorkerConfiguration) []preflight.Checker {
+	return nodePreflightChecks(&cfg.NodeConfiguration, cfg.ControlPlaneEndpoint)
+}
+
+func runPreflightChecks(ctx context.Context, checks []preflight.Checker, ignore []string) error {
+	names := make(map[string]bool)
+	for _, c := range checks {
+		names[strings.ToLower(c.Name())] = true
+	}
+	for _, name := range ignore {
+		if name != preflight.IgnoreAll && !names[strings.ToLower(name)] {
+			log.Warn("ignoring unknown preflight check", zap.String("check", name))
+		}
+	}
+	problems := preflight.Run(ctx, &preflight.Host{}, checks, ignore)
+	for _, p := range problems {
+		if p.Severity == preflight.SeverityWarning {
+			log.Warn("preflight check", zap.String("check", p.Check), zap.Error(p.Err))
+		}
+	}
+	if errs := problems.Errors(); len(errs) > 0 {
+		stderr := executil.NewPrefixWriter(os.Stderr, "\t")
+		defer stderr.Close()
+
+		stderr.Write([]byte(fmtutil.FormatErrors(errs)))
+		return errors.New("failed preflight checks, errors can be ignored with --ignore-preflight-errors")
+	}
+	return nil
+}
+
+// Preflight runs the configuration validation and host checks for the node
+// without bootstrapping it.
+func Preflight(ctx context.Context, rc *RuntimeConfig, obj runtime.Object) error {
+	switch cfg := obj.(type) {
+	case *config.ControlPlaneConfiguration:
+		if err := feature.MutableGates.SetFromMap(cfg.FeatureGates); err != nil {
+			return err
+		}
+		return New("", rc).ControlPlanePreCheck(ctx, cfg)
+	case *config.WorkerConfiguration:
+		if err := feature.MutableGates.SetFromMap(cfg.FeatureGates); err != nil {
+			return err
+		}
+		return New("", rc).WorkerPreCheck(ctx, cfg)
+	default:
+		return errors.Errorf("received invalid configuration type: %T", obj)
+	}
+}
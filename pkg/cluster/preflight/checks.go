@@ -0,0 +1,433 @@
+package preflight
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	netutils "k8s.io/utils/net"
+
+	"github.com/criticalstack/crit/pkg/config/constants"
+	netutil "github.com/criticalstack/crit/pkg/util/net"
+)
+
+// SwapCheck ensures that swap is disabled, since the kubelet will not start
+// with swap enabled unless FailSwapOn is false.
+type SwapCheck struct {
+	FailSwapOn bool
+}
+
+func (SwapCheck) Name() string { return "Swap" }
+
+func (c SwapCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	data, err := ioutil.ReadFile(h.Path("/proc/swaps"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return []error{errors.Wrap(err, "cannot read swaps")}, nil
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	// the first line is always the header
+	if len(lines) < 2 {
+		return nil, nil
+	}
+	err = errors.New("swap is enabled, disable it with \"swapoff -a\" and remove any swap entries from /etc/fstab")
+	if !c.FailSwapOn {
+		return []error{err}, nil
+	}
+	return nil, []error{err}
+}
+
+// BridgeNetfilterCheck ensures that the br_netfilter kernel module is
+// loaded, which is required for iptables to see bridged traffic.
+type BridgeNetfilterCheck struct{}
+
+func (BridgeNetfilterCheck) Name() string { return "BridgeNetfilter" }
+
+func (BridgeNetfilterCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	for _, path := range []string{"/sys/module/br_netfilter", "/proc/sys/net/bridge"} {
+		if _, err := os.Stat(h.Path(path)); err == nil {
+			return nil, nil
+		}
+	}
+	return nil, []error{errors.New("br_netfilter kernel module is not loaded, load it with \"modprobe br_netfilter\"")}
+}
+
+// SysctlCheck ensures that a kernel parameter has the expected value.
+type SysctlCheck struct {
+	Key   string
+	Value string
+}
+
+func (c SysctlCheck) Name() string { return "Sysctl-" + c.Key }
+
+func (c SysctlCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	path := h.Path("/proc/sys", strings.ReplaceAll(c.Key, ".", "/"))
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, []error{errors.Errorf("cannot read sysctl %s: %v", c.Key, err)}
+	}
+	if v := strings.TrimSpace(string(data)); v != c.Value {
+		return nil, []error{errors.Errorf("sysctl %s is %q, set it to %q with \"sysctl -w %s=%s\"", c.Key, v, c.Value, c.Key, c.Value)}
+	}
+	return nil, nil
+}
+
+// PortCheck ensures that a port is not already in use. A port in use by one
+// of the expected processes is only a warning, since this usually means the
+// node is already bootstrapped.
+type PortCheck struct {
+	Port      int
+	Processes []string
+}
+
+func (c PortCheck) Name() string { return fmt.Sprintf("Port-%d", c.Port) }
+
+func (c PortCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	inode, err := listeningSocket(h, c.Port)
+	if err != nil {
+		return []error{err}, nil
+	}
+	if inode == "" {
+		return nil, nil
+	}
+	name := socketProcess(h, inode)
+	for _, p := range c.Processes {
+		if name == p {
+			return []error{errors.Errorf("port %d is already in use by %s", c.Port, name)}, nil
+		}
+	}
+	if name != "" {
+		return nil, []error{errors.Errorf("port %d is in use by %s", c.Port, name)}
+	}
+	return nil, []error{errors.Errorf("port %d is in use", c.Port)}
+}
+
+// ListeningCheck ensures that a service that must be started before
+// bootstrapping is listening on a port, such as etcd.
+type ListeningCheck struct {
+	Port    int
+	Service string
+}
+
+func (c ListeningCheck) Name() string { return fmt.Sprintf("Listening-%d", c.Port) }
+
+func (c ListeningCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	inode, err := listeningSocket(h, c.Port)
+	if err != nil {
+		return []error{err}, nil
+	}
+	if inode == "" {
+		return []error{errors.Errorf("nothing is listening on port %d, %s may not be running", c.Port, c.Service)}, nil
+	}
+	return nil, nil
+}
+
+const tcpListen = "0A"
+
+// listeningSocket returns the inode of the socket listening on the port by
+// reading /proc/net/tcp and /proc/net/tcp6. An empty string is returned if
+// nothing is listening.
+func listeningSocket(h *Host, port int) (string, error) {
+	for _, path := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		f, err := os.Open(h.Path(path))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", errors.Wrapf(err, "cannot read %s", path)
+		}
+		s := bufio.NewScanner(f)
+		for s.Scan() {
+			// sl local_address rem_address st tx_queue:rx_queue tr:tm->when
+			// retrnsmt uid timeout inode
+			fields := strings.Fields(s.Text())
+			if len(fields) < 10 || fields[3] != tcpListen {
+				continue
+			}
+			i := strings.LastIndex(fields[1], ":")
+			if i < 0 {
+				continue
+			}
+			p, err := strconv.ParseInt(fields[1][i+1:], 16, 32)
+			if err != nil || int(p) != port {
+				continue
+			}
+			f.Close()
+			return fields[9], nil
+		}
+		f.Close()
+	}
+	return "", nil
+}
+
+// socketProcess returns the name of the process holding the socket inode, or
+// an empty string if it cannot be determined.
+func socketProcess(h *Host, inode string) string {
+	pids, err := ioutil.ReadDir(h.Path("/proc"))
+	if err != nil {
+		return ""
+	}
+	target := fmt.Sprintf("socket:[%s]", inode)
+	for _, pid := range pids {
+		if _, err := strconv.Atoi(pid.Name()); err != nil {
+			continue
+		}
+		fds, err := ioutil.ReadDir(h.Path("/proc", pid.Name(), "fd"))
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(h.Path("/proc", pid.Name(), "fd", fd.Name()))
+			if err != nil || link != target {
+				continue
+			}
+			comm, err := ioutil.ReadFile(h.Path("/proc", pid.Name(), "comm"))
+			if err != nil {
+				return ""
+			}
+			return strings.TrimSpace(string(comm))
+		}
+	}
+	return ""
+}
+
+// RuntimeSocketCheck ensures that the container runtime is running by
+// checking for its CRI socket.
+type RuntimeSocketCheck struct {
+	Runtime constants.ContainerRuntime
+}
+
+func (RuntimeSocketCheck) Name() string { return "RuntimeSocket" }
+
+func (c RuntimeSocketCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	if !c.Runtime.IsValid() {
+		return nil, []error{errors.Errorf("unknown container runtime %q, must be one of %v", c.Runtime, constants.ContainerRuntimes)}
+	}
+	path := strings.TrimPrefix(c.Runtime.CRISocket(), "unix://")
+	fi, err := os.Stat(h.Path(path))
+	if err != nil {
+		return nil, []error{errors.Errorf("container runtime socket %s not found, ensure %s is running", path, c.Runtime)}
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return nil, []error{errors.Errorf("container runtime socket %s is not a socket", path)}
+	}
+	return nil, nil
+}
+
+// CgroupDriverFunc determines the cgroup driver used by the container
+// runtime. An empty string is returned if it cannot be determined.
+type CgroupDriverFunc func(ctx context.Context, h *Host, cr constants.ContainerRuntime) (string, error)
+
+// CgroupDriverCheck ensures that the kubelet and container runtime use the
+// same cgroup driver.
+type CgroupDriverCheck struct {
+	KubeletCgroupDriver string
+	Runtime             constants.ContainerRuntime
+
+	// RuntimeCgroupDriver determines the cgroup driver of the container
+	// runtime. If not set, the container runtime configuration files are
+	// used.
+	RuntimeCgroupDriver CgroupDriverFunc
+}
+
+func (CgroupDriverCheck) Name() string { return "CgroupDriver" }
+
+func (c CgroupDriverCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	fn := c.RuntimeCgroupDriver
+	if fn == nil {
+		fn = RuntimeConfigCgroupDriver
+	}
+	driver, err := fn(ctx, h, c.Runtime)
+	if err != nil {
+		return []error{errors.Wrap(err, "cannot determine container runtime cgroup driver")}, nil
+	}
+	if driver == "" {
+		return []error{errors.Errorf("cannot determine %s cgroup driver", c.Runtime)}, nil
+	}
+	kubeletDriver := c.KubeletCgroupDriver
+	if kubeletDriver == "" {
+		kubeletDriver = "cgroupfs"
+	}
+	if kubeletDriver != driver {
+		return nil, []error{errors.Errorf("kubelet cgroup driver %q does not match %s cgroup driver %q, set node.kubelet.cgroupDriver to %q", kubeletDriver, c.Runtime, driver, driver)}
+	}
+	return nil, nil
+}
+
+var (
+	containerdSystemdCgroupRe = regexp.MustCompile(`(?m)^\s*SystemdCgroup\s*=\s*true`)
+	crioCgroupfsRe            = regexp.MustCompile(`(?m)^\s*cgroup_manager\s*=\s*"cgroupfs"`)
+)
+
+// RuntimeConfigCgroupDriver determines the cgroup driver of the container
+// runtime from its configuration files.
+func RuntimeConfigCgroupDriver(ctx context.Context, h *Host, cr constants.ContainerRuntime) (string, error) {
+	switch cr {
+	case constants.Containerd:
+		data, err := ioutil.ReadFile(h.Path("/etc/containerd/config.toml"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return "cgroupfs", nil
+			}
+			return "", err
+		}
+		if containerdSystemdCgroupRe.Match(data) {
+			return "systemd", nil
+		}
+		return "cgroupfs", nil
+	case constants.Docker:
+		data, err := ioutil.ReadFile(h.Path("/etc/docker/daemon.json"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return "cgroupfs", nil
+			}
+			return "", err
+		}
+		var daemon struct {
+			ExecOpts []string `json:"exec-opts"`
+		}
+		if err := json.Unmarshal(data, &daemon); err != nil {
+			return "", err
+		}
+		for _, opt := range daemon.ExecOpts {
+			if strings.TrimSpace(opt) == "native.cgroupdriver=systemd" {
+				return "systemd", nil
+			}
+		}
+		return "cgroupfs", nil
+	case constants.CRIO:
+		data, err := ioutil.ReadFile(h.Path("/etc/crio/crio.conf"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return "systemd", nil
+			}
+			return "", err
+		}
+		if crioCgroupfsRe.Match(data) {
+			return "cgroupfs", nil
+		}
+		return "systemd", nil
+	}
+	return "", nil
+}
+
+// DefaultMinFreeDiskSpace is the minimum free disk space before a warning is
+// reported.
+const DefaultMinFreeDiskSpace = 10 * 1024 * 1024 * 1024
+
+// DiskSpaceCheck ensures that the filesystem of a path has enough free disk
+// space for container images and etcd.
+type DiskSpaceCheck struct {
+	Path    string
+	MinFree uint64
+}
+
+func (c DiskSpaceCheck) Name() string { return "DiskSpace" }
+
+func (c DiskSpaceCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	path := h.Path(c.Path)
+
+	// the path may not exist yet, so the nearest existing parent directory
+	// is used
+	for {
+		if _, err := os.Stat(path); err == nil || path == filepath.Dir(path) {
+			break
+		}
+		path = filepath.Dir(path)
+	}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return []error{errors.Wrapf(err, "cannot determine free disk space for %s", c.Path)}, nil
+	}
+	free := st.Bavail * uint64(st.Bsize)
+	if free < c.MinFree {
+		return []error{errors.Errorf("%s has %dMiB of free disk space, at least %dMiB is recommended", c.Path, free/1024/1024, c.MinFree/1024/1024)}, nil
+	}
+	return nil, nil
+}
+
+// ManifestsCheck reports any leftover static pod manifests, which could
+// indicate that the node was previously bootstrapped.
+type ManifestsCheck struct {
+	KubeDir string
+}
+
+func (ManifestsCheck) Name() string { return "Manifests" }
+
+func (c ManifestsCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	dir := filepath.Join(c.KubeDir, "manifests")
+	files, err := ioutil.ReadDir(h.Path(dir))
+	if err != nil {
+		return nil, nil
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		warnings = append(warnings, errors.Errorf("static pod manifest %s already exists and will be overwritten", filepath.Join(dir, f.Name())))
+	}
+	return warnings, nil
+}
+
+// HostRouteCheck ensures that the host addresses have a route to the control
+// plane endpoint. A host address that is not routable usually indicates that
+// the wrong network interface was selected.
+type HostRouteCheck struct {
+	HostIPs []string
+	Host    string
+	Port    int32
+}
+
+func (HostRouteCheck) Name() string { return "HostRoute" }
+
+func (c HostRouteCheck) Check(ctx context.Context, h *Host) (warnings, errs []error) {
+	if c.Host == "" || len(c.HostIPs) == 0 {
+		return nil, nil
+	}
+	endpoint := net.JoinHostPort(c.Host, strconv.Itoa(int(c.Port)))
+	var endpointIPs []net.IP
+	if ip := net.ParseIP(c.Host); ip != nil {
+		endpointIPs = []net.IP{ip}
+	} else {
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", c.Host)
+		if err != nil {
+			return []error{errors.Wrapf(err, "cannot resolve ControlPlaneEndpoint %s, skipping host address route check", c.Host)}, nil
+		}
+		endpointIPs = ips
+	}
+	checked := false
+	for _, hostIP := range c.HostIPs {
+		ipv6 := netutils.IsIPv6String(hostIP)
+		for _, endpointIP := range endpointIPs {
+			if netutils.IsIPv6(endpointIP) != ipv6 {
+				continue
+			}
+			checked = true
+			if err := netutil.IsRoutable(hostIP, endpointIP.String(), c.Port); err != nil {
+				errs = append(errs, errors.Errorf("host address %s is not routable to ControlPlaneEndpoint %s: %v", hostIP, endpoint, err))
+				break
+			}
+			if src, err := netutil.SourceIPFor(endpointIP.String(), c.Port, ipv6); err == nil && src != hostIP {
+				warnings = append(warnings, errors.Errorf("the host routes to ControlPlaneEndpoint %s from %s rather than the host address %s", endpoint, src, hostIP))
+			}
+			break
+		}
+	}
+	if !checked {
+		errs = append(errs, errors.Errorf("host addresses %v do not match the IP family of ControlPlaneEndpoint %s", c.HostIPs, endpoint))
+	}
+	return warnings, errs
+}
@@ -0,0 +1,106 @@
+// Package preflight contains the host checks that are run before
+// bootstrapping a node.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+type Severity string
+
+const (
+	// SeverityWarning is used for problems that are unlikely to prevent a
+	// node from bootstrapping, but may cause issues later.
+	SeverityWarning Severity = "warning"
+
+	// SeverityError is used for problems that will prevent a node from
+	// bootstrapping.
+	SeverityError Severity = "error"
+)
+
+// IgnoreAll can be provided to Run to report the errors of all checks as
+// warnings.
+const IgnoreAll = "all"
+
+// Host is the host being checked. Any paths read by checks are relative to
+// Root, so that checks can be run against a fake /proc and /sys tree.
+type Host struct {
+	Root string
+}
+
+// Path returns the path on the host, relative to Root.
+func (h *Host) Path(elem ...string) string {
+	root := h.Root
+	if root == "" {
+		root = "/"
+	}
+	return filepath.Join(append([]string{root}, elem...)...)
+}
+
+// Checker is a single preflight check. The name of the check is used to
+// ignore its errors.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context, h *Host) (warnings, errs []error)
+}
+
+// Problem is a warning or error reported by a check.
+type Problem struct {
+	Check    string
+	Severity Severity
+	Err      error
+}
+
+func (p Problem) Error() string {
+	return fmt.Sprintf("[%s] %v", p.Check, p.Err)
+}
+
+type Problems []Problem
+
+// Warnings returns all problems with warning severity.
+func (p Problems) Warnings() []error {
+	return p.filter(SeverityWarning)
+}
+
+// Errors returns all problems with error severity.
+func (p Problems) Errors() []error {
+	return p.filter(SeverityError)
+}
+
+func (p Problems) filter(s Severity) []error {
+	errs := make([]error, 0)
+	for _, problem := range p {
+		if problem.Severity == s {
+			errs = append(errs, problem)
+		}
+	}
+	return errs
+}
+
+// Run runs all checks against the host and returns the problems found. The
+// errors of any checks named in ignore are reported as warnings. Names are
+// matched case-insensitively.
+func Run(ctx context.Context, h *Host, checks []Checker, ignore []string) Problems {
+	ignored := make(map[string]bool)
+	for _, name := range ignore {
+		ignored[strings.ToLower(name)] = true
+	}
+	problems := make(Problems, 0)
+	for _, c := range checks {
+		warnings, errs := c.Check(ctx, h)
+		for _, err := range warnings {
+			problems = append(problems, Problem{Check: c.Name(), Severity: SeverityWarning, Err: err})
+		}
+		severity := SeverityError
+		if ignored[IgnoreAll] || ignored[strings.ToLower(c.Name())] {
+			severity = SeverityWarning
+		}
+		for _, err := range errs {
+			problems = append(problems, Problem{Check: c.Name(), Severity: severity, Err: err})
+		}
+	}
+	return problems
+}
@@ -0,0 +1,128 @@
+package preflight
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/criticalstack/crit/pkg/config/constants"
+)
+
+func writeFile(t *testing.T, h *Host, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(h.Path(path)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(h.Path(path), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newHost(t *testing.T) *Host {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "preflight")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return &Host{Root: dir}
+}
+
+const procNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:280A 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:0CEA 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 23456 1 0000000000000000 100 0 0 10 0
+   2: 0100007F:1F90 0100007F:C350 01 00000000:00000000 00:00000000 00000000     0        0 34567 1 0000000000000000 100 0 0 10 0
+`
+
+func TestChecks(t *testing.T) {
+	h := newHost(t)
+	writeFile(t, h, "/proc/swaps", "Filename\tType\tSize\tUsed\tPriority\n/swapfile file 1048572 0 -2\n")
+	writeFile(t, h, "/proc/sys/net/ipv4/ip_forward", "0\n")
+	writeFile(t, h, "/proc/sys/net/bridge/bridge-nf-call-iptables", "1\n")
+	writeFile(t, h, "/proc/net/tcp", procNetTCP)
+	writeFile(t, h, "/proc/100/comm", "kubelet\n")
+	if err := os.MkdirAll(h.Path("/proc/100/fd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("socket:[12345]", h.Path("/proc/100/fd/3")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, h, "/etc/containerd/config.toml", "[plugins.cri.containerd.runtimes.runc.options]\n  SystemdCgroup = true\n")
+	writeFile(t, h, "/etc/kubernetes/manifests/kube-apiserver.yaml", "")
+
+	cases := []struct {
+		name     string
+		check    Checker
+		warnings int
+		errs     int
+	}{
+		{"swap enabled", SwapCheck{FailSwapOn: true}, 0, 1},
+		{"swap allowed", SwapCheck{FailSwapOn: false}, 1, 0},
+		{"br_netfilter loaded", BridgeNetfilterCheck{}, 0, 0},
+		{"sysctl set", SysctlCheck{Key: "net.bridge.bridge-nf-call-iptables", Value: "1"}, 0, 0},
+		{"sysctl not set", SysctlCheck{Key: "net.ipv4.ip_forward", Value: "1"}, 0, 1},
+		{"sysctl missing", SysctlCheck{Key: "net.ipv6.conf.all.forwarding", Value: "1"}, 0, 1},
+		{"port free", PortCheck{Port: 6443}, 0, 0},
+		{"port used by expected process", PortCheck{Port: 10250, Processes: []string{"kubelet"}}, 1, 0},
+		{"port used by other process", PortCheck{Port: 10250, Processes: []string{"kube-apiserver"}}, 0, 1},
+		{"port used by unknown process", PortCheck{Port: 3306}, 0, 1},
+		{"port not listening", PortCheck{Port: 8080}, 0, 0},
+		{"etcd listening", ListeningCheck{Port: 3306, Service: "etcd"}, 0, 0},
+		{"etcd not listening", ListeningCheck{Port: 2379, Service: "etcd"}, 1, 0},
+		{"runtime socket missing", RuntimeSocketCheck{Runtime: constants.Containerd}, 0, 1},
+		{"runtime unknown", RuntimeSocketCheck{Runtime: "rkt"}, 0, 1},
+		{"cgroup driver matches", CgroupDriverCheck{KubeletCgroupDriver: "systemd", Runtime: constants.Containerd}, 0, 0},
+		{"cgroup driver mismatch", CgroupDriverCheck{KubeletCgroupDriver: "cgroupfs", Runtime: constants.Containerd}, 0, 1},
+		{"cgroup driver default", CgroupDriverCheck{Runtime: constants.Docker}, 0, 0},
+		{"disk space", DiskSpaceCheck{Path: "/var/lib", MinFree: 1}, 0, 0},
+		{"manifests exist", ManifestsCheck{KubeDir: "/etc/kubernetes"}, 1, 0},
+		{"manifests missing", ManifestsCheck{KubeDir: "/var/lib/kubernetes"}, 0, 0},
+		{"host route", HostRouteCheck{HostIPs: []string{"127.0.0.1"}, Host: "127.0.0.1", Port: 6443}, 0, 0},
+		{"host route not routable", HostRouteCheck{HostIPs: []string{"192.0.2.1"}, Host: "127.0.0.1", Port: 6443}, 0, 1},
+		{"host route family mismatch", HostRouteCheck{HostIPs: []string{"::1"}, Host: "127.0.0.1", Port: 6443}, 0, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings, errs := tc.check.Check(context.Background(), h)
+			if len(warnings) != tc.warnings {
+				t.Errorf("expected %d warnings, received %v", tc.warnings, warnings)
+			}
+			if len(errs) != tc.errs {
+				t.Errorf("expected %d errors, received %v", tc.errs, errs)
+			}
+		})
+	}
+}
+
+func TestRunIgnore(t *testing.T) {
+	h := newHost(t)
+	writeFile(t, h, "/proc/swaps", "Filename\tType\tSize\tUsed\tPriority\n/swapfile file 1048572 0 -2\n")
+	checks := []Checker{
+		SwapCheck{FailSwapOn: true},
+		SysctlCheck{Key: "net.ipv4.ip_forward", Value: "1"},
+	}
+
+	cases := []struct {
+		name     string
+		ignore   []string
+		warnings int
+		errs     int
+	}{
+		{"none", nil, 0, 2},
+		{"single", []string{"swap"}, 1, 1},
+		{"all", []string{IgnoreAll}, 2, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			problems := Run(context.Background(), h, checks, tc.ignore)
+			if n := len(problems.Warnings()); n != tc.warnings {
+				t.Errorf("expected %d warnings, received %d", tc.warnings, n)
+			}
+			if n := len(problems.Errors()); n != tc.errs {
+				t.Errorf("expected %d errors, received %d", tc.errs, n)
+			}
+		})
+	}
+}
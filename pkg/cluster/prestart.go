@@ -3,16 +3,23 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/version"
+	netutils "k8s.io/utils/net"
 
 	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/cluster/components"
+	"github.com/criticalstack/crit/pkg/config/constants"
+	nodeutil "github.com/criticalstack/crit/pkg/kubernetes/util/node"
 	"github.com/criticalstack/crit/pkg/log"
 	executil "github.com/criticalstack/crit/pkg/util/exec"
 	fmtutil "github.com/criticalstack/crit/pkg/util/fmt"
@@ -26,15 +33,77 @@ const (
 	DefaultKubeAPIServerPort       = 6443
 )
 
-func setNodeRuntimeDefaults(cfg *config.NodeConfiguration) {
-	if cfg.HostIPv4 == "" {
-		cfg.HostIPv4, _ = netutil.DetectHostIPv4()
+// setNodeHostDefaults selects the host addresses and hostname of the node
+// from the host, when they have not been provided.
+func setNodeHostDefaults(cfg *config.NodeConfiguration) {
+	if cfg.HostIPv4 == "" && cfg.HostIPv6 == "" {
+		cfg.HostIPv4 = detectHostIP(cfg, false)
+		if cfg.HostIPv4 == "" {
+			cfg.HostIPv6 = detectHostIP(cfg, true)
+		}
 	}
 	if cfg.Hostname == "" {
 		cfg.Hostname, _ = os.Hostname()
 	}
 }
 
+// detectHostIP selects the host address of the requested IP family using the
+// NodeAddress policy. The rule that selected the address is logged, since a
+// wrong host address affects every certificate and advertised address.
+func detectHostIP(cfg *config.NodeConfiguration, ipv6 bool) string {
+	family := "IPv4"
+	if ipv6 {
+		family = "IPv6"
+	}
+	na := cfg.NodeAddress
+	policy := na.Policy
+	var ip, iface string
+	var err error
+	switch policy {
+	case constants.NodeAddressPolicyInterface:
+		iface = na.Interface
+		ip, err = netutil.InterfaceIP(na.Interface, ipv6)
+	case constants.NodeAddressPolicyCIDR:
+		ip, iface, err = netutil.CIDRInterfaceIP(na.CIDRs, ipv6, na.ExcludeVirtual)
+	case constants.NodeAddressPolicyFirstInterface:
+		ip, iface, err = netutil.FirstInterfaceIP(ipv6, na.ExcludeVirtual)
+	default:
+		ip, iface, err = netutil.DefaultRouteIP(ipv6, na.ExcludeVirtual)
+		if err != nil {
+			log.Debug("cannot select host address from default route", zap.String("family", family), zap.Error(err))
+			policy = constants.NodeAddressPolicyFirstInterface
+			ip, iface, err = netutil.FirstInterfaceIP(ipv6, na.ExcludeVirtual)
+		}
+	}
+	if err != nil {
+		log.Warn("cannot select host address", zap.String("family", family), zap.String("policy", policy), zap.Error(err))
+		return ""
+	}
+	log.Info("selected host address",
+		zap.String("family", family),
+		zap.String("address", ip),
+		zap.String("interface", iface),
+		zap.String("policy", policy),
+		zap.Bool("excludeVirtual", na.ExcludeVirtual),
+	)
+	return ip
+}
+
+// setPauseImageRuntimeDefault provides the kubelet with the pause image when
+// it has been changed from the default. Remote container runtimes will also
+// need their sandbox image configured to match.
+func setPauseImageRuntimeDefault(cfg *config.NodeConfiguration, images *config.ImagesConfiguration, imageRepository, image string) {
+	if !images.CustomPauseImage(imageRepository) {
+		return
+	}
+	if cfg.KubeletExtraArgs == nil {
+		cfg.KubeletExtraArgs = make(map[string]string)
+	}
+	if _, ok := cfg.KubeletExtraArgs["pod-infra-container-image"]; !ok {
+		cfg.KubeletExtraArgs["pod-infra-container-image"] = image
+	}
+}
+
 var (
 	// MinKubeVersion indicates the lowest version that crit will provision
 	MinKubeVersion = version.MustParseSemantic("v1.14.0")
@@ -55,13 +124,47 @@ func validateNodeConfiguration(cfg *config.NodeConfiguration) (errs []error) {
 	if v != nil && v.Minor() > MaxKubeVersion.Minor() {
 		log.Warn("The KubernetesVersion is newer than expected. Older versions of crit will be capable of bootstrapping newer versions of Kubernetes, but may produce undesired behavior", zap.String("KubernetesVersion", cfg.KubernetesVersion))
 	}
+	if !cfg.ContainerRuntime.IsValid() {
+		errs = append(errs, errors.Errorf("invalid ContainerRuntime: %#v, must be one of %v", cfg.ContainerRuntime, constants.ContainerRuntimes))
+	}
+	errs = append(errs, validateNodeAddress(&cfg.NodeAddress)...)
+	errs = append(errs, validateHostIPs(cfg)...)
+	errs = append(errs, validateNodeMetadata(cfg)...)
 	return
 }
 
+// validateNodeHost checks the parts of the NodeConfiguration that depend on
+// the host, which are only known once the host defaults have been set.
+func validateNodeHost(cfg *config.NodeConfiguration) (errs []error) {
+	if cfg.HostIPv4 == "" && cfg.HostIPv6 == "" {
+		errs = append(errs, errors.New("cannot detect host IP address, must provide HostIPv4 or HostIPv6"))
+	}
+	return errs
+}
+
+func validateNodeMetadata(cfg *config.NodeConfiguration) (errs []error) {
+	for k, v := range cfg.Labels {
+		for _, msg := range validation.IsQualifiedName(k) {
+			errs = append(errs, errors.Errorf("invalid label key %q: %s", k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(v) {
+			errs = append(errs, errors.Errorf("invalid value for label %q: %s", k, msg))
+		}
+	}
+	for k := range cfg.Annotations {
+		for _, msg := range validation.IsQualifiedName(strings.ToLower(k)) {
+			errs = append(errs, errors.Errorf("invalid annotation key %q: %s", k, msg))
+		}
+	}
+	return errs
+}
+
 func (c *Cluster) ControlPlanePreCheck(ctx context.Context, cfg *config.ControlPlaneConfiguration) error {
 	log.Info("precheck-control-plane", zap.String("description", "perform host system configuration checks"))
 	setControlPlaneRuntimeDefaults(cfg)
+	setKubeletCgroupDriverDefault(ctx, &cfg.NodeConfiguration)
 	errs := validateControlPlaneConfiguration(cfg)
+	errs = append(errs, validateControlPlaneHost(cfg)...)
 	if len(errs) > 0 {
 		stderr := executil.NewPrefixWriter(os.Stderr, "\t")
 		defer stderr.Close()
@@ -69,23 +172,45 @@ func (c *Cluster) ControlPlanePreCheck(ctx context.Context, cfg *config.ControlP
 		stderr.Write([]byte(fmtutil.FormatErrors(errs)))
 		return errors.New("failed precheck")
 	}
-	return nil
+	return runPreflightChecks(ctx, ControlPlanePreflightChecks(cfg), c.ignorePreflightErrors())
 }
 
 func setControlPlaneRuntimeDefaults(cfg *config.ControlPlaneConfiguration) {
-	// some defaults are derived from NodeConfiguration defaults, so this must
-	// run first
-	setNodeRuntimeDefaults(&cfg.NodeConfiguration)
+	setControlPlaneHostDefaults(cfg)
+	setControlPlaneDefaults(cfg)
+}
 
-	if cfg.ControlPlaneEndpoint.Host == "" {
-		log.Warn("ControlPlaneEndpoint is being set implicitly to the host IPv4. It is recommended to use a Load Balancer or DNS for this value to ensure that cluster services, like kube-proxy, will always be able to connect to the control plane.")
-		cfg.ControlPlaneEndpoint.Host = cfg.NodeConfiguration.HostIPv4
+func setControlPlaneHostDefaults(cfg *config.ControlPlaneConfiguration) {
+	// only detect host addresses for the IP families used by the cluster
+	// subnets, so that an IPv6-only cluster is not given an IPv4 node address
+	for _, subnet := range append(cfg.PodSubnets, cfg.ServiceSubnets...) {
+		if netutils.IsIPv6CIDRString(subnet) {
+			if cfg.NodeConfiguration.HostIPv6 == "" {
+				cfg.NodeConfiguration.HostIPv6 = detectHostIP(&cfg.NodeConfiguration, true)
+			}
+			continue
+		}
+		if cfg.NodeConfiguration.HostIPv4 == "" {
+			cfg.NodeConfiguration.HostIPv4 = detectHostIP(&cfg.NodeConfiguration, false)
+		}
+	}
+
+	setNodeHostDefaults(&cfg.NodeConfiguration)
+}
+
+// setControlPlaneDefaults sets the defaults that only depend upon the
+// configuration. Some of these are derived from the host defaults, so the
+// host defaults must be set first when bootstrapping a node.
+func setControlPlaneDefaults(cfg *config.ControlPlaneConfiguration) {
+	if cfg.ControlPlaneEndpoint.Host == "" && cfg.AdvertiseAddress() != "" {
+		log.Warn("ControlPlaneEndpoint is being set implicitly to the host IP. It is recommended to use a Load Balancer or DNS for this value to ensure that cluster services, like kube-proxy, will always be able to connect to the control plane.")
+		cfg.ControlPlaneEndpoint.Host = cfg.AdvertiseAddress()
 	}
 	if cfg.ControlPlaneEndpoint.Port == 0 {
 		cfg.ControlPlaneEndpoint.Port = int32(cfg.KubeAPIServerConfiguration.BindPort)
 	}
 	if len(cfg.EtcdConfiguration.Endpoints) == 0 {
-		cfg.EtcdConfiguration.Endpoints = append(cfg.EtcdConfiguration.Endpoints, fmt.Sprintf("https://%s:2379", cfg.ControlPlaneEndpoint.Host))
+		cfg.EtcdConfiguration.Endpoints = append(cfg.EtcdConfiguration.Endpoints, fmt.Sprintf("https://%s", net.JoinHostPort(cfg.ControlPlaneEndpoint.Host, "2379")))
 	}
 	if strings.HasPrefix(cfg.EtcdConfiguration.Endpoints[0], "https://") {
 		if cfg.EtcdConfiguration.CAFile == "" {
@@ -101,8 +226,20 @@ func setControlPlaneRuntimeDefaults(cfg *config.ControlPlaneConfiguration) {
 			cfg.EtcdConfiguration.CAKey = filepath.Join(cfg.NodeConfiguration.KubeDir, "pki/etcd/ca.key")
 		}
 	}
+	setPauseImageRuntimeDefault(&cfg.NodeConfiguration, &cfg.Images, cfg.ImageRepository, cfg.Image(constants.Pause))
 	if cfg.KubeProxyConfiguration.Config.ClusterCIDR == "" {
-		cfg.KubeProxyConfiguration.Config.ClusterCIDR = cfg.PodSubnet
+		cfg.KubeProxyConfiguration.Config.ClusterCIDR = strings.Join(cfg.PodSubnets, ",")
+	}
+	if !cfg.IsDualStack() && netutils.IsIPv6CIDRString(cfg.PodSubnet) {
+		if cfg.KubeProxyConfiguration.Config.BindAddress == "0.0.0.0" {
+			cfg.KubeProxyConfiguration.Config.BindAddress = "::"
+		}
+		if cfg.KubeProxyConfiguration.Config.HealthzBindAddress == "0.0.0.0:10256" {
+			cfg.KubeProxyConfiguration.Config.HealthzBindAddress = "[::]:10256"
+		}
+	}
+	if cfg.IsDualStack() && components.RequiresIPv6DualStackFeatureGate(cfg.NodeConfiguration.KubernetesVersion) {
+		setIPv6DualStackFeatureGates(cfg)
 	}
 	if cfg.NodeConfiguration.KubeletConfiguration.ClusterDNS == nil {
 		dnsIP, err := netutil.GetDNSIP(cfg.ServiceSubnet)
@@ -115,8 +252,94 @@ func setControlPlaneRuntimeDefaults(cfg *config.ControlPlaneConfiguration) {
 	}
 }
 
+// setIPv6DualStackFeatureGates enables the IPv6DualStack feature gate for
+// every component, unless it has been explicitly set for that component.
+func setIPv6DualStackFeatureGates(cfg *config.ControlPlaneConfiguration) {
+	gates := []*map[string]bool{
+		&cfg.KubeAPIServerConfiguration.FeatureGates,
+		&cfg.KubeControllerManagerConfiguration.FeatureGates,
+		&cfg.KubeProxyConfiguration.Config.FeatureGates,
+		&cfg.NodeConfiguration.KubeletConfiguration.FeatureGates,
+	}
+	for _, fg := range gates {
+		if *fg == nil {
+			*fg = make(map[string]bool)
+		}
+		if _, ok := (*fg)[components.IPv6DualStackFeatureGate]; !ok {
+			(*fg)[components.IPv6DualStackFeatureGate] = true
+		}
+	}
+}
+
+func validateSubnets(name string, subnets []string) (errs []error) {
+	if len(subnets) > 2 {
+		return append(errs, errors.Errorf("%s cannot have more than 2 subnets: %#v", name, subnets))
+	}
+	for _, subnet := range subnets {
+		if _, _, err := net.ParseCIDR(subnet); err != nil {
+			errs = append(errs, errors.Errorf("invalid %s CIDR: %#v", name, subnet))
+		}
+	}
+	if len(errs) > 0 || len(subnets) < 2 {
+		return errs
+	}
+	if ok, _ := netutils.IsDualStackCIDRStrings(subnets); !ok {
+		errs = append(errs, errors.Errorf("%s must contain one IPv4 and one IPv6 subnet: %#v", name, subnets))
+	}
+	return errs
+}
+
+func validateControlPlaneNetworking(cfg *config.ControlPlaneConfiguration) (errs []error) {
+	errs = append(errs, validateSubnets("PodSubnets", cfg.PodSubnets)...)
+	errs = append(errs, validateSubnets("ServiceSubnets", cfg.ServiceSubnets)...)
+	if len(cfg.PodSubnets) > 0 && cfg.PodSubnet != cfg.PodSubnets[0] {
+		errs = append(errs, errors.Errorf("PodSubnet %#v must match the first PodSubnets entry: %#v", cfg.PodSubnet, cfg.PodSubnets[0]))
+	}
+	if len(cfg.ServiceSubnets) > 0 && cfg.ServiceSubnet != cfg.ServiceSubnets[0] {
+		errs = append(errs, errors.Errorf("ServiceSubnet %#v must match the first ServiceSubnets entry: %#v", cfg.ServiceSubnet, cfg.ServiceSubnets[0]))
+	}
+	return errs
+}
+
+func validateNodeAddress(cfg *config.NodeAddressConfiguration) (errs []error) {
+	switch cfg.Policy {
+	case constants.NodeAddressPolicyDefaultRoute, constants.NodeAddressPolicyFirstInterface:
+	case constants.NodeAddressPolicyInterface:
+		if cfg.Interface == "" {
+			errs = append(errs, errors.New("must provide Interface for NodeAddress policy \"Interface\""))
+		}
+	case constants.NodeAddressPolicyCIDR:
+		if len(cfg.CIDRs) == 0 {
+			errs = append(errs, errors.New("must provide CIDRs for NodeAddress policy \"CIDR\""))
+		}
+		for _, cidr := range cfg.CIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				errs = append(errs, errors.Errorf("invalid NodeAddress CIDR: %#v", cidr))
+			}
+		}
+	default:
+		errs = append(errs, errors.Errorf("invalid NodeAddress policy: %#v", cfg.Policy))
+	}
+	return errs
+}
+
+func validateHostIPs(cfg *config.NodeConfiguration) (errs []error) {
+	if cfg.HostIPv4 != "" {
+		if ip := net.ParseIP(cfg.HostIPv4); ip == nil || ip.To4() == nil {
+			errs = append(errs, errors.Errorf("invalid HostIPv4: %#v", cfg.HostIPv4))
+		}
+	}
+	if cfg.HostIPv6 != "" {
+		if ip := net.ParseIP(cfg.HostIPv6); ip == nil || ip.To4() != nil {
+			errs = append(errs, errors.Errorf("invalid HostIPv6: %#v", cfg.HostIPv6))
+		}
+	}
+	return errs
+}
+
 func validateControlPlaneConfiguration(cfg *config.ControlPlaneConfiguration) (errs []error) {
 	errs = append(errs, validateNodeConfiguration(&cfg.NodeConfiguration)...)
+	errs = append(errs, validateControlPlaneNetworking(cfg)...)
 
 	for _, ep := range cfg.EtcdConfiguration.Endpoints {
 		if !strings.HasPrefix(ep, "http") {
@@ -128,6 +351,35 @@ func validateControlPlaneConfiguration(cfg *config.ControlPlaneConfiguration) (e
 			errs = append(errs, errors.Errorf("invalid etcd endpoint url: %#v", ep))
 		}
 	}
+	if oidc := cfg.KubeAPIServerConfiguration.OIDC; oidc != nil {
+		if u, err := url.Parse(oidc.IssuerURL); err != nil || u.Scheme != "https" {
+			errs = append(errs, errors.Errorf("invalid OIDC IssuerURL, must be https: %#v", oidc.IssuerURL))
+		}
+		if oidc.ClientID == "" {
+			errs = append(errs, errors.New("must provide ClientID for OIDC configuration"))
+		}
+	}
+	embeddedAddons, err := EmbeddedAddons()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	isEmbeddedAddon := make(map[string]bool)
+	for _, name := range embeddedAddons {
+		isEmbeddedAddon[name] = true
+	}
+	for _, addon := range cfg.Addons {
+		switch {
+		case addon.Name == "" && addon.Path == "":
+			errs = append(errs, errors.New("addon must specify either name or path"))
+		case addon.Name != "" && addon.Path != "":
+			errs = append(errs, errors.Errorf("addon %q cannot specify both name and path", addon.Name))
+		case addon.Name != "" && !isEmbeddedAddon[addon.Name]:
+			errs = append(errs, errors.Errorf("unknown addon %q, must be one of %v", addon.Name, embeddedAddons))
+		}
+	}
+	if cfg.PodSecurity.Enabled {
+		errs = append(errs, validatePodSecurityConfiguration(cfg)...)
+	}
 	switch strings.ToLower(string(cfg.KubeProxyConfiguration.Config.Mode)) {
 	case "iptables", "ipvs":
 	default:
@@ -136,10 +388,70 @@ func validateControlPlaneConfiguration(cfg *config.ControlPlaneConfiguration) (e
 	return
 }
 
+// validateControlPlaneHost checks the parts of the ControlPlaneConfiguration
+// that depend on the host, such as the host addresses selected for each
+// subnet and the local addon paths.
+func validateControlPlaneHost(cfg *config.ControlPlaneConfiguration) (errs []error) {
+	errs = append(errs, validateNodeHost(&cfg.NodeConfiguration)...)
+	for _, subnet := range append(cfg.PodSubnets, cfg.ServiceSubnets...) {
+		switch {
+		case netutils.IsIPv6CIDRString(subnet) && cfg.NodeConfiguration.HostIPv6 == "":
+			errs = append(errs, errors.Errorf("must provide HostIPv6 for IPv6 subnet: %#v", subnet))
+		case !netutils.IsIPv6CIDRString(subnet) && cfg.NodeConfiguration.HostIPv4 == "":
+			errs = append(errs, errors.Errorf("must provide HostIPv4 for IPv4 subnet: %#v", subnet))
+		}
+	}
+	for _, addon := range cfg.Addons {
+		if addon.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(addon.Path); err != nil {
+			errs = append(errs, errors.Errorf("cannot find addon path: %#v", addon.Path))
+		}
+	}
+	return errs
+}
+
+func validatePodSecurityConfiguration(cfg *config.ControlPlaneConfiguration) (errs []error) {
+	usePSA := components.UsePodSecurityAdmission(cfg.NodeConfiguration.KubernetesVersion)
+	if v, err := version.ParseSemantic(cfg.NodeConfiguration.KubernetesVersion); err == nil && v.Minor() > MaxKubeVersion.Minor() {
+		// The PodSecurity admission plugin is only used for versions newer
+		// than MaxKubeVersion, so neither mode has been tested with these
+		// versions.
+		if usePSA {
+			log.Warn("The PodSecurity admission plugin is used for this KubernetesVersion, which is newer than the highest supported version", zap.String("KubernetesVersion", cfg.NodeConfiguration.KubernetesVersion), zap.String("MaxKubeVersion", MaxKubeVersion.String()))
+		} else {
+			log.Warn("PodSecurityPolicy is deprecated for this KubernetesVersion and will be removed in v1.25", zap.String("KubernetesVersion", cfg.NodeConfiguration.KubernetesVersion))
+		}
+	}
+	levels := map[string]string{"": cfg.PodSecurity.DefaultLevel}
+	for ns, level := range cfg.PodSecurity.Namespaces {
+		levels[ns] = level
+	}
+	for ns, level := range levels {
+		switch level {
+		case constants.PodSecurityLevelPrivileged, constants.PodSecurityLevelRestricted:
+		case constants.PodSecurityLevelBaseline:
+			if !usePSA {
+				log.Warn("baseline pod security level is not available with PodSecurityPolicy, restricted will be used instead", zap.String("namespace", ns))
+			}
+		default:
+			if ns == "" {
+				errs = append(errs, errors.Errorf("invalid PodSecurity DefaultLevel: %#v", level))
+				continue
+			}
+			errs = append(errs, errors.Errorf("invalid PodSecurity level for namespace %q: %#v", ns, level))
+		}
+	}
+	return
+}
+
 func (c *Cluster) WorkerPreCheck(ctx context.Context, cfg *config.WorkerConfiguration) error {
 	log.Info("precheck-worker", zap.String("description", "perform host system configuration checks"))
 	setWorkerRuntimeDefaults(cfg)
+	setKubeletCgroupDriverDefault(ctx, &cfg.NodeConfiguration)
 	errs := validateWorkerConfiguration(cfg)
+	errs = append(errs, validateNodeHost(&cfg.NodeConfiguration)...)
 	if len(errs) > 0 {
 		stderr := executil.NewPrefixWriter(os.Stderr, "\t")
 		defer stderr.Close()
@@ -147,16 +459,57 @@ func (c *Cluster) WorkerPreCheck(ctx context.Context, cfg *config.WorkerConfigur
 		stderr.Write([]byte(fmtutil.FormatErrors(errs)))
 		return errors.New("failed precheck")
 	}
-	return nil
+	return runPreflightChecks(ctx, WorkerPreflightChecks(cfg), c.ignorePreflightErrors())
 }
 
 func setWorkerRuntimeDefaults(cfg *config.WorkerConfiguration) {
-	// some defaults are derived from NodeConfiguration defaults, so this must
-	// run first
-	setNodeRuntimeDefaults(&cfg.NodeConfiguration)
+	setWorkerHostDefaults(cfg)
+	setWorkerDefaults(cfg)
+}
+
+func setWorkerHostDefaults(cfg *config.WorkerConfiguration) {
+	node := &cfg.NodeConfiguration
+	switch {
+	case node.HostIPv6 != "":
+	case netutils.IsIPv6String(cfg.ControlPlaneEndpoint.Host):
+		// an IPv6 control plane endpoint indicates the node should use its
+		// IPv6 address
+		node.HostIPv6 = detectHostIP(node, true)
+	case node.HostIPv4 == "" && useWorkerDualStack(node):
+		// workers do not know the cluster subnets, so both host addresses
+		// are used whenever the kubelet accepts them, giving workers the same
+		// dual-stack node IPs as control plane nodes
+		node.HostIPv4 = detectHostIP(node, false)
+		node.HostIPv6 = detectHostIP(node, true)
+	}
+
+	setNodeHostDefaults(node)
+}
 
+// useWorkerDualStack determines if both host addresses of a worker should be
+// detected. The kubelet must accept a node IP for each IP family, and have
+// the IPv6DualStack feature gate enabled, either explicitly or by default for
+// its version. The host must also have an IPv6 address.
+func useWorkerDualStack(cfg *config.NodeConfiguration) bool {
+	if !components.UseDualStackNodeIPs(cfg) {
+		return false
+	}
+	enabled, ok := cfg.KubeletConfiguration.FeatureGates[components.IPv6DualStackFeatureGate]
+	if !ok {
+		enabled = !components.RequiresIPv6DualStackFeatureGate(cfg.KubernetesVersion)
+	}
+	if !enabled {
+		return false
+	}
+	_, _, err := netutil.FirstInterfaceIP(true, cfg.NodeAddress.ExcludeVirtual)
+	return err == nil
+}
+
+// setWorkerDefaults sets the defaults that only depend upon the
+// configuration.
+func setWorkerDefaults(cfg *config.WorkerConfiguration) {
 	if cfg.BootstrapServerURL == "" && cfg.ControlPlaneEndpoint.Host != "" {
-		cfg.BootstrapServerURL = fmt.Sprintf("https://%s:%d", cfg.ControlPlaneEndpoint.Host, DefaultBootstrapServerBindPort)
+		cfg.BootstrapServerURL = fmt.Sprintf("https://%s", net.JoinHostPort(cfg.ControlPlaneEndpoint.Host, strconv.Itoa(DefaultBootstrapServerBindPort)))
 	}
 	if cfg.ControlPlaneEndpoint.Port == 0 {
 		cfg.ControlPlaneEndpoint.Port = DefaultKubeAPIServerPort
@@ -165,6 +518,7 @@ func setWorkerRuntimeDefaults(cfg *config.WorkerConfiguration) {
 	if cfg.CACert == "" {
 		cfg.CACert = filepath.Join(cfg.NodeConfiguration.KubeDir, "pki/ca.crt")
 	}
+	setPauseImageRuntimeDefault(&cfg.NodeConfiguration, &cfg.Images, cfg.ImageRepository, cfg.Image(constants.Pause))
 }
 
 func validateWorkerConfiguration(cfg *config.WorkerConfiguration) (errs []error) {
@@ -176,5 +530,8 @@ func validateWorkerConfiguration(cfg *config.WorkerConfiguration) (errs []error)
 	if cfg.BootstrapServerURL == "" && cfg.BootstrapToken == "" {
 		errs = append(errs, errors.New("must provide either BootstrapServerURL or BootstrapToken for WorkerConfiguration"))
 	}
+	if _, restricted := nodeutil.SplitLabels(cfg.NodeConfiguration.Labels); cfg.BootstrapToken != "" && (len(restricted) > 0 || len(cfg.NodeConfiguration.Annotations) > 0) {
+		log.Warn("restricted labels and annotations are only applied to worker nodes joining with the bootstrap server", zap.String("labels", nodeutil.FormatLabels(restricted)))
+	}
 	return
 }
@@ -0,0 +1,208 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/trace"
+)
+
+// DefaultNodeReadyTimeout is the default amount of time to wait for a node
+// to register and become Ready.
+const DefaultNodeReadyTimeout = 5 * time.Minute
+
+// NodeReadyStage is the stage a node has reached while joining the cluster.
+type NodeReadyStage string
+
+const (
+	// NodeReadyStageTLSBootstrap is waiting for the kubelet to request a
+	// client certificate.
+	NodeReadyStageTLSBootstrap NodeReadyStage = "TLSBootstrap"
+
+	// NodeReadyStageBootstrapTokenRejected means the apiserver did not
+	// accept the bootstrap token, usually because it has expired or was
+	// deleted.
+	NodeReadyStageBootstrapTokenRejected NodeReadyStage = "BootstrapTokenRejected"
+
+	// NodeReadyStageCSRPending means the kubelet client certificate signing
+	// request has not been approved.
+	NodeReadyStageCSRPending NodeReadyStage = "CSRPending"
+
+	// NodeReadyStageCSRDenied means the kubelet client certificate signing
+	// request was denied.
+	NodeReadyStageCSRDenied NodeReadyStage = "CSRDenied"
+
+	// NodeReadyStageRegistration is waiting for the kubelet to register the
+	// Node.
+	NodeReadyStageRegistration NodeReadyStage = "Registration"
+
+	// NodeReadyStageCNINotReady means the Node is registered but NotReady,
+	// because the pod network has not been initialized.
+	NodeReadyStageCNINotReady NodeReadyStage = "CNINotReady"
+
+	// NodeReadyStageNotReady means the Node is registered but NotReady.
+	NodeReadyStageNotReady NodeReadyStage = "NotReady"
+
+	// NodeReadyStageReady means the Node is registered and Ready.
+	NodeReadyStageReady NodeReadyStage = "Ready"
+)
+
+func (c *Cluster) nodeReadyTimeout() time.Duration {
+	if c.rc == nil || c.rc.NodeReadyTimeout <= 0 {
+		return DefaultNodeReadyTimeout
+	}
+	return c.rc.NodeReadyTimeout
+}
+
+// kubeletNodeName returns the name the kubelet registers the Node with.
+func kubeletNodeName(cfg *config.NodeConfiguration) string {
+	if name, ok := cfg.KubeletExtraArgs["hostname-override"]; ok {
+		return name
+	}
+	return strings.ToLower(cfg.Hostname)
+}
+
+// WaitNodeReady waits for the kubelet to complete TLS bootstrapping, register
+// the Node and for the Node to become Ready. If the Node does not become
+// Ready, the stage that stalled is reported.
+func (c *Cluster) WaitNodeReady(ctx context.Context, cfg *config.WorkerConfiguration) error {
+	log.Info("wait-node-ready", zap.String("description", "wait for the node to register and become ready"))
+	ctx, cancel := context.WithTimeout(ctx, c.nodeReadyTimeout())
+	defer cancel()
+
+	nodeName := kubeletNodeName(&cfg.NodeConfiguration)
+	var stage NodeReadyStage
+	var reason string
+	if err := wait.PollImmediateUntil(2*time.Second, func() (bool, error) {
+		s, r := c.nodeReadyStage(ctx, &cfg.NodeConfiguration, nodeName)
+		if s != stage || r != reason {
+			log.Info("waiting for node", zap.String("node", nodeName), zap.String("stage", string(s)), zap.String("reason", r))
+		}
+		stage, reason = s, r
+		return stage == NodeReadyStageReady, nil
+	}, ctx.Done()); err != nil {
+		reterr := errors.Errorf("node %q did not become ready, stalled at stage %s: %s", nodeName, stage, reason)
+		c.reportFailure(&cfg.NodeConfiguration, "wait-node-ready", reterr)
+		return reterr
+	}
+	log.Info("node is ready", zap.String("node", nodeName))
+	return nil
+}
+
+func (c *Cluster) nodeReadyStage(ctx context.Context, cfg *config.NodeConfiguration, nodeName string) (NodeReadyStage, string) {
+	// kubelet.conf is written by the kubelet once TLS bootstrapping has
+	// completed
+	if _, err := os.Stat(c.kubeConfigFile); err != nil {
+		return tlsBootstrapStage(ctx, filepath.Join(cfg.KubeDir, "bootstrap-kubelet.conf"))
+	}
+	restConfig, err := clientcmd.BuildConfigFromFlags("", c.kubeConfigFile)
+	if err != nil {
+		return NodeReadyStageRegistration, err.Error()
+	}
+	restConfig.Wrap(trace.NewTransport)
+	client, err := clientset.NewForConfig(restConfig)
+	if err != nil {
+		return NodeReadyStageRegistration, err.Error()
+	}
+	node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return NodeReadyStageRegistration, "node has not been registered"
+		}
+		return NodeReadyStageRegistration, err.Error()
+	}
+	return nodeConditionStage(node)
+}
+
+func nodeConditionStage(node *corev1.Node) (NodeReadyStage, string) {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeNetworkUnavailable && cond.Status == corev1.ConditionTrue {
+			return NodeReadyStageCNINotReady, fmt.Sprintf("%s: %s", cond.Reason, cond.Message)
+		}
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type != corev1.NodeReady {
+			continue
+		}
+		if cond.Status == corev1.ConditionTrue {
+			return NodeReadyStageReady, ""
+		}
+		msg := strings.ToLower(cond.Message)
+		if strings.Contains(msg, "network plugin") || strings.Contains(msg, "cni") || strings.Contains(msg, "networkready=false") {
+			return NodeReadyStageCNINotReady, fmt.Sprintf("%s, ensure a CNI is installed", cond.Message)
+		}
+		return NodeReadyStageNotReady, fmt.Sprintf("%s: %s", cond.Reason, cond.Message)
+	}
+	return NodeReadyStageNotReady, "node has not reported a Ready condition"
+}
+
+// tlsBootstrapStage uses the bootstrap token to determine why the kubelet
+// has not received a client certificate.
+func tlsBootstrapStage(ctx context.Context, bootstrapKubeconfig string) (NodeReadyStage, string) {
+	kc, err := clientcmd.LoadFromFile(bootstrapKubeconfig)
+	if err != nil {
+		return NodeReadyStageTLSBootstrap, err.Error()
+	}
+	restConfig, err := clientcmd.NewDefaultClientConfig(*kc, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return NodeReadyStageTLSBootstrap, err.Error()
+	}
+	restConfig.Wrap(trace.NewTransport)
+	client, err := clientset.NewForConfig(restConfig)
+	if err != nil {
+		return NodeReadyStageTLSBootstrap, err.Error()
+	}
+	csrs, err := client.CertificatesV1beta1().CertificateSigningRequests().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsUnauthorized(err) {
+			return NodeReadyStageBootstrapTokenRejected, "the bootstrap token was rejected, it may have expired or been deleted"
+		}
+		return NodeReadyStageTLSBootstrap, err.Error()
+	}
+
+	// CSRs created with a bootstrap token are requested by the user
+	// system:bootstrap:<token-id>
+	username := ""
+	for _, auth := range kc.AuthInfos {
+		if parts := strings.SplitN(auth.Token, ".", 2); len(parts) == 2 {
+			username = "system:bootstrap:" + parts[0]
+		}
+	}
+	var latest *certificatesv1beta1.CertificateSigningRequest
+	for i, csr := range csrs.Items {
+		if csr.Spec.Username != username {
+			continue
+		}
+		if latest == nil || csr.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = &csrs.Items[i]
+		}
+	}
+	if latest == nil {
+		return NodeReadyStageTLSBootstrap, "waiting for the kubelet to request a client certificate"
+	}
+	for _, cond := range latest.Status.Conditions {
+		switch cond.Type {
+		case certificatesv1beta1.CertificateDenied:
+			return NodeReadyStageCSRDenied, fmt.Sprintf("certificate signing request %s was denied: %s", latest.Name, cond.Message)
+		case certificatesv1beta1.CertificateApproved:
+			return NodeReadyStageTLSBootstrap, fmt.Sprintf("certificate signing request %s was approved, waiting for the certificate to be issued", latest.Name)
+		}
+	}
+	return NodeReadyStageCSRPending, fmt.Sprintf("certificate signing request %s is pending approval", latest.Name)
+}
@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+
+	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/trace"
+)
+
+// stepID returns the identifier of a workflow function, which is the name of
+// the function in kebab case, e.g. WriteKubeManifests is identified as
+// write-kube-manifests. The identifier only changes if the function is
+// renamed, so it can be relied upon by systems that consume the logs.
+func stepID(fn interface{}) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+
+	// method values are named like pkg.(*Cluster).WriteKubeManifests-fm
+	name = strings.TrimSuffix(name, "-fm")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return kebabCase(name)
+}
+
+// kebabCase converts a camel case name to kebab case, keeping acronyms
+// together, e.g. UploadETCDSecrets becomes upload-etcd-secrets.
+func kebabCase(s string) string {
+	runes := []rune(s)
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				sb.WriteRune('-')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+// runSteps runs each workflow function added to the cluster in order,
+// stopping at the first error. Entries logged while a step is running
+// include its identifier in the step field, an event is written when each
+// step is started and finished, and each step is recorded as a span.
+func (c *Cluster) runSteps(ctx context.Context, run func(ctx context.Context, fn interface{}) error) error {
+	for _, fn := range c.fns {
+		id := stepID(fn)
+		restore := log.With(zap.String("step", id))
+		c.events.stepStarted(id)
+		ctx, span := trace.Start(ctx, id, trace.SpanKindInternal, trace.String("crit.step", id))
+		start := time.Now()
+		err := run(ctx, fn)
+		span.SetError(err)
+		span.Finish()
+		c.events.stepFinished(id, time.Since(start), err)
+		restore()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,457 @@
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/cluster/components"
+	"github.com/criticalstack/crit/pkg/config/constants"
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	"github.com/criticalstack/crit/pkg/kubernetes"
+	"github.com/criticalstack/crit/pkg/kubernetes/pki"
+	"github.com/criticalstack/crit/pkg/kubernetes/remote"
+	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/util/systemd"
+)
+
+// DefaultSupportBundleLines is the default number of lines of each journal
+// and container log included in a support bundle.
+const DefaultSupportBundleLines = 1000
+
+// SupportBundleOptions determines what is collected in a support bundle.
+type SupportBundleOptions struct {
+	// Config is the crit config used to bootstrap the node. It is optional,
+	// since the node may have failed before the config could be loaded.
+	Config runtime.Object
+
+	// KubeDir and ContainerRuntime are used when a config is not provided.
+	KubeDir          string
+	ContainerRuntime constants.ContainerRuntime
+
+	// Lines is the number of lines of each journal and container log.
+	Lines int
+}
+
+// WriteSupportBundle collects diagnostics for the node and writes them to w
+// as a gzipped tarball. Everything that can be collected locally is included
+// even when the cluster or container runtime are unavailable, and any
+// collection errors are written to errors.txt in the bundle.
+func WriteSupportBundle(ctx context.Context, w io.Writer, opts *SupportBundleOptions) error {
+	b, err := newSupportBundle(ctx, w, opts)
+	if err != nil {
+		return err
+	}
+	b.collectConfig()
+	b.collectFiles()
+	b.collectCertificates()
+	b.collectJournal(ctx)
+	b.collectContainers(ctx)
+	b.collectCluster(ctx)
+	b.collectEtcdHealth(ctx)
+	return b.Close()
+}
+
+type supportBundle struct {
+	gw *gzip.Writer
+	tw *tar.Writer
+
+	prefix  string
+	modTime time.Time
+	opts    *SupportBundleOptions
+	node    *config.NodeConfiguration
+	errs    []string
+}
+
+func newSupportBundle(ctx context.Context, w io.Writer, opts *SupportBundleOptions) (*supportBundle, error) {
+	o := *opts
+	if o.Lines <= 0 {
+		o.Lines = DefaultSupportBundleLines
+	}
+	node := &config.NodeConfiguration{
+		KubeDir:          o.KubeDir,
+		ContainerRuntime: o.ContainerRuntime,
+	}
+	if o.Config != nil {
+		if err := ApplyNodeOverrides(ctx, o.Config); err != nil {
+			log.Warn("cannot apply node overrides", zap.Error(err))
+		}
+	}
+	switch cfg := o.Config.(type) {
+	case *config.ControlPlaneConfiguration:
+		setControlPlaneRuntimeDefaults(cfg)
+		node = &cfg.NodeConfiguration
+	case *config.WorkerConfiguration:
+		setWorkerRuntimeDefaults(cfg)
+		node = &cfg.NodeConfiguration
+	case nil:
+	default:
+		return nil, errors.Errorf("received invalid configuration type: %T", o.Config)
+	}
+	if node.KubeDir == "" {
+		node.KubeDir = constants.DefaultKubeDir
+	}
+	if node.ContainerRuntime == "" {
+		node.ContainerRuntime = constants.Containerd
+	}
+	if node.Hostname == "" {
+		node.Hostname, _ = os.Hostname()
+	}
+	now := time.Now()
+	gw := gzip.NewWriter(w)
+	return &supportBundle{
+		gw:      gw,
+		tw:      tar.NewWriter(gw),
+		prefix:  fmt.Sprintf("crit-support-bundle-%s-%s", node.Hostname, now.UTC().Format("20060102T150405Z")),
+		modTime: now,
+		opts:    &o,
+		node:    node,
+	}, nil
+}
+
+func (b *supportBundle) errorf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	log.Debug("support bundle", zap.String("error", msg))
+	b.errs = append(b.errs, msg)
+}
+
+func (b *supportBundle) add(name string, data []byte) {
+	hdr := &tar.Header{
+		Name:    filepath.Join(b.prefix, name),
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: b.modTime,
+	}
+	if err := b.tw.WriteHeader(hdr); err != nil {
+		b.errorf("%s: %v", name, err)
+		return
+	}
+	if _, err := b.tw.Write(data); err != nil {
+		b.errorf("%s: %v", name, err)
+	}
+}
+
+func (b *supportBundle) addJSON(name string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.errorf("%s: %v", name, err)
+		return
+	}
+	b.add(name, data)
+}
+
+func (b *supportBundle) addFile(name, path string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			b.errorf("%s: %v", name, err)
+		}
+		return
+	}
+	b.add(name, data)
+}
+
+// addRedactedFile adds a file generated from the config, replacing the values
+// of any flags that contain credentials.
+func (b *supportBundle) addRedactedFile(name, path string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			b.errorf("%s: %v", name, err)
+		}
+		return
+	}
+	b.add(name, configutil.RedactFlags(data))
+}
+
+func (b *supportBundle) Close() error {
+	if len(b.errs) > 0 {
+		b.add("errors.txt", []byte(strings.Join(b.errs, "\n")+"\n"))
+	}
+	if err := b.tw.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(b.gw.Close())
+}
+
+// collectConfig adds the resolved crit config, with secrets redacted.
+func (b *supportBundle) collectConfig() {
+	if b.opts.Config == nil {
+		return
+	}
+	data, err := configutil.Marshal(configutil.Redact(b.opts.Config))
+	if err != nil {
+		b.errorf("config: %v", err)
+		return
+	}
+	b.add("config.yaml", data)
+}
+
+// collectFiles adds the static pod manifests and kubelet config. Component
+// flags containing credentials are redacted, and kubeconfigs are not included
+// since they may contain embedded client keys.
+func (b *supportBundle) collectFiles() {
+	manifests, err := filepath.Glob(filepath.Join(b.node.KubeDir, "manifests", "*"))
+	if err != nil {
+		b.errorf("manifests: %v", err)
+	}
+	for _, path := range manifests {
+		b.addRedactedFile(filepath.Join("manifests", filepath.Base(path)), path)
+	}
+	b.addFile("kubelet/config.yaml", filepath.Join(DefaultKubeletDir, "config.yaml"))
+	b.addRedactedFile(filepath.Join("kubelet", components.KubeletEnvFileName), filepath.Join(DefaultKubeletDir, components.KubeletEnvFileName))
+}
+
+type certificateMetadata struct {
+	Path         string    `json:"path"`
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SerialNumber string    `json:"serialNumber"`
+	NotBefore    time.Time `json:"notBefore"`
+	NotAfter     time.Time `json:"notAfter"`
+	IsCA         bool      `json:"isCA"`
+	DNSNames     []string  `json:"dnsNames,omitempty"`
+	IPAddresses  []string  `json:"ipAddresses,omitempty"`
+}
+
+// collectCertificates adds the metadata of all certificates in the pki
+// directory. Private keys are never read.
+func (b *supportBundle) collectCertificates() {
+	dir := filepath.Join(b.node.KubeDir, "pki")
+	certs := make([]certificateMetadata, 0)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".crt" {
+			return nil
+		}
+		cert, err := pki.ReadCertFromFile(path)
+		if err != nil {
+			b.errorf("certificate %s: %v", path, err)
+			return nil
+		}
+		m := certificateMetadata{
+			Path:         path,
+			Subject:      cert.Subject.String(),
+			Issuer:       cert.Issuer.String(),
+			SerialNumber: cert.SerialNumber.String(),
+			NotBefore:    cert.NotBefore,
+			NotAfter:     cert.NotAfter,
+			IsCA:         cert.IsCA,
+			DNSNames:     cert.DNSNames,
+		}
+		for _, ip := range cert.IPAddresses {
+			m.IPAddresses = append(m.IPAddresses, ip.String())
+		}
+		certs = append(certs, m)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		b.errorf("certificates: %v", err)
+	}
+	b.addJSON("certificates.json", certs)
+}
+
+func (b *supportBundle) collectJournal(ctx context.Context) {
+	for _, unit := range []string{"kubelet.service", string(b.node.ContainerRuntime) + ".service"} {
+		entries, err := systemd.JournalTail(ctx, unit, b.opts.Lines)
+		if err != nil {
+			b.errorf("%s journal: %v", unit, err)
+			continue
+		}
+		var buf bytes.Buffer
+		for _, e := range entries {
+			fmt.Fprintf(&buf, "%s %s\n", e.Time.Format(time.RFC3339Nano), e.Message)
+		}
+		b.add(filepath.Join("journal", unit+".log"), buf.Bytes())
+	}
+}
+
+// collectContainers adds the status and logs of all containers known to the
+// container runtime.
+func (b *supportBundle) collectContainers(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	r, err := remote.NewRuntimeServiceClient(ctx, b.node.ContainerRuntime.CRISocket())
+	if err != nil {
+		b.errorf("container runtime: %v", err)
+		return
+	}
+	resp, err := r.ListContainers(ctx, &runtimeapi.ListContainersRequest{})
+	if err != nil {
+		b.errorf("container runtime: %v", err)
+		return
+	}
+	statuses := make([]*runtimeapi.ContainerStatus, 0)
+	for _, c := range resp.Containers {
+		status, err := r.GetContainerStatus(ctx, c.Id)
+		if err != nil {
+			b.errorf("container %s: %v", c.Id, err)
+			continue
+		}
+		statuses = append(statuses, status)
+		if status.LogPath == "" {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := r.ReadLogs(ctx, status.LogPath, &buf); err != nil {
+			b.errorf("container %s logs: %v", c.Id, err)
+			continue
+		}
+		name := fmt.Sprintf("%s_%s_%s.log", c.Labels["io.kubernetes.pod.name"], c.GetMetadata().GetName(), c.Id)
+		b.add(filepath.Join("containers", name), []byte(strings.Join(lastLines(buf.String(), b.opts.Lines), "\n")+"\n"))
+	}
+	b.addJSON("containers/status.json", statuses)
+}
+
+type nodeConditions struct {
+	Name       string                 `json:"name"`
+	Conditions []corev1.NodeCondition `json:"conditions"`
+}
+
+// collectCluster adds the crit-config ConfigMap and the node conditions,
+// using the admin kubeconfig if available, otherwise the kubelet
+// kubeconfig.
+func (b *supportBundle) collectCluster(ctx context.Context) {
+	var kubeconfig string
+	for _, name := range []string{"admin.conf", "kubelet.conf"} {
+		path := filepath.Join(b.node.KubeDir, name)
+		if _, err := os.Stat(path); err == nil {
+			kubeconfig = path
+			break
+		}
+	}
+	if kubeconfig == "" {
+		b.errorf("cluster: cannot find kubeconfig in %s", b.node.KubeDir)
+		return
+	}
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		b.errorf("cluster: %v", err)
+		return
+	}
+	restConfig.Timeout = 10 * time.Second
+	client, err := clientset.NewForConfig(restConfig)
+	if err != nil {
+		b.errorf("cluster: %v", err)
+		return
+	}
+	cm, err := kubernetes.GetConfigMap(client, ctx, CritConfigName)
+	if err != nil {
+		b.errorf("%s: %v", CritConfigName, err)
+	} else {
+		obj, err := configutil.Unmarshal([]byte(cm.Data["config"]))
+		if err != nil {
+			b.errorf("%s: %v", CritConfigName, err)
+		} else if data, err := configutil.Marshal(configutil.Redact(obj)); err != nil {
+			b.errorf("%s: %v", CritConfigName, err)
+		} else {
+			b.add("cluster/crit-config.yaml", data)
+		}
+	}
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.errorf("nodes: %v", err)
+		return
+	}
+	conditions := make([]nodeConditions, 0)
+	for _, n := range nodes.Items {
+		conditions = append(conditions, nodeConditions{Name: n.Name, Conditions: n.Status.Conditions})
+	}
+	b.addJSON("cluster/nodes.json", conditions)
+}
+
+type etcdHealth struct {
+	Endpoint string          `json:"endpoint"`
+	Health   json.RawMessage `json:"health,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// collectEtcdHealth adds the health of each etcd endpoint for control plane
+// nodes.
+func (b *supportBundle) collectEtcdHealth(ctx context.Context) {
+	cfg, ok := b.opts.Config.(*config.ControlPlaneConfiguration)
+	if !ok {
+		return
+	}
+	ec := cfg.EtcdConfiguration
+	tlsConfig := &tls.Config{}
+	if ec.CAFile != "" {
+		data, err := ioutil.ReadFile(ec.CAFile)
+		if err != nil {
+			b.errorf("etcd: %v", err)
+			return
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(data)
+	}
+	if ec.CertFile != "" && ec.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(ec.CertFile, ec.KeyFile)
+		if err != nil {
+			b.errorf("etcd: %v", err)
+			return
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	client := &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	results := make([]etcdHealth, 0)
+	for _, ep := range ec.Endpoints {
+		result := etcdHealth{Endpoint: ep}
+		data, err := getURL(ctx, client, strings.TrimSuffix(ep, "/")+"/health")
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Health = data
+		}
+		results = append(results, result)
+	}
+	b.addJSON("etcd/health.json", results)
+}
+
+func getURL(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(data) {
+		return nil, errors.Errorf("%s returned status %d: %s", url, resp.StatusCode, data)
+	}
+	return data, nil
+}
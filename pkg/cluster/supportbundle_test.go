@@ -0,0 +1,81 @@
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSupportBundleRedactsManifests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "supportbundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "manifests"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	manifest := `apiVersion: v1
+kind: Pod
+metadata:
+  name: kube-apiserver
+spec:
+  containers:
+  - command:
+    - kube-apiserver
+    - --oidc-client-secret=s3cr3t
+    - --token-auth-file=/etc/kubernetes/tokens.csv
+`
+	if err := ioutil.WriteFile(filepath.Join(dir, "manifests", "kube-apiserver.yaml"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	b, err := newSupportBundle(context.Background(), &buf, &SupportBundleOptions{KubeDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.collectFiles()
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			t.Fatal("expected manifest in support bundle")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(hdr.Name, "/manifests/kube-apiserver.yaml") {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "s3cr3t") {
+			t.Errorf("expected secret to be redacted, received:\n%s", data)
+		}
+		for _, s := range []string{"--oidc-client-secret=REDACTED", "--token-auth-file=/etc/kubernetes/tokens.csv"} {
+			if !strings.Contains(string(data), s) {
+				t.Errorf("expected manifest to contain %q, received:\n%s", s, data)
+			}
+		}
+		return
+	}
+}
@@ -22,24 +22,58 @@ func WriteAPIServerCertAndKey(cfg *config.ControlPlaneConfiguration) error {
 		log.Warn("apiserver cert/key already exists")
 		return nil
 	}
-
-	// advertise address
-	advertiseAddress := net.ParseIP(cfg.NodeConfiguration.HostIPv4)
-	if advertiseAddress == nil {
-		return errors.Errorf("error parsing LocalAPIEndpoint AdvertiseAddress %v: is not a valid textual representation of an IP address",
-			cfg.NodeConfiguration.HostIPv4)
+	certConfig, err := APIServerCertConfig(cfg)
+	if err != nil {
+		return err
 	}
-	_, svcSubnet, err := net.ParseCIDR(cfg.ServiceSubnet)
+	ca, err := pki.LoadCertificateAuthority(dir, "ca")
 	if err != nil {
 		return err
 	}
-	internalAPIServerVirtualIP, err := netutils.GetIndexedIP(svcSubnet, 1)
+	kp, err := ca.NewSignedKeyPair("apiserver", certConfig)
 	if err != nil {
-		return errors.Wrapf(err, "unable to get first IP address from the given CIDR (%s)", svcSubnet.String())
+		return err
+	}
+	return kp.WriteFiles(dir)
+}
+
+// APIServerCertConfig returns the config of the apiserver serving
+// certificate, including the SANs for the host, the kubernetes service, the
+// control plane endpoint and ExtraSANs.
+func APIServerCertConfig(cfg *config.ControlPlaneConfiguration) (*pki.Config, error) {
+	// host addresses, including the advertise address
+	hostIPs := make([]net.IP, 0)
+	for _, addr := range cfg.NodeConfiguration.HostIPs() {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			return nil, errors.Errorf("error parsing host address %v: is not a valid textual representation of an IP address", addr)
+		}
+		hostIPs = append(hostIPs, ip)
+	}
+	if len(hostIPs) == 0 {
+		return nil, errors.New("must provide a host IPv4 or IPv6 address for the apiserver certificate")
+	}
+
+	// the first IP of each service subnet is used by the kubernetes service
+	serviceSubnets := cfg.ServiceSubnets
+	if len(serviceSubnets) == 0 {
+		serviceSubnets = []string{cfg.ServiceSubnet}
+	}
+	internalAPIServerVirtualIPs := make([]net.IP, 0)
+	for _, subnet := range serviceSubnets {
+		_, svcSubnet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, err
+		}
+		ip, err := netutils.GetIndexedIP(svcSubnet, 1)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get first IP address from the given CIDR (%s)", svcSubnet.String())
+		}
+		internalAPIServerVirtualIPs = append(internalAPIServerVirtualIPs, ip)
 	}
 	hostname, err := os.Hostname()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	certConfig := &pki.Config{
 		CommonName: "kube-apiserver",
@@ -52,10 +86,7 @@ func WriteAPIServerCertAndKey(cfg *config.ControlPlaneConfiguration) error {
 				"kubernetes.default.svc",
 				fmt.Sprintf("kubernetes.default.svc.%s", cfg.NodeConfiguration.KubeletConfiguration.ClusterDomain),
 			},
-			IPs: []net.IP{
-				internalAPIServerVirtualIP,
-				advertiseAddress,
-			},
+			IPs: append(internalAPIServerVirtualIPs, hostIPs...),
 		},
 	}
 
@@ -71,6 +102,9 @@ func WriteAPIServerCertAndKey(cfg *config.ControlPlaneConfiguration) error {
 		"localhost",
 		"127.0.0.1",
 	}
+	if cfg.NodeConfiguration.HostIPv6 != "" {
+		certSANs = append(certSANs, "::1")
+	}
 
 	certSANs = append(certSANs, cfg.KubeAPIServerConfiguration.ExtraSANs...)
 
@@ -88,15 +122,7 @@ func WriteAPIServerCertAndKey(cfg *config.ControlPlaneConfiguration) error {
 			)
 		}
 	}
-	ca, err := pki.LoadCertificateAuthority(dir, "ca")
-	if err != nil {
-		return err
-	}
-	kp, err := ca.NewSignedKeyPair("apiserver", certConfig)
-	if err != nil {
-		return err
-	}
-	return kp.WriteFiles(dir)
+	return certConfig, nil
 }
 
 func WriteAPIServerKubeletClientCertAndKey(cfg *config.ControlPlaneConfiguration) error {
@@ -58,3 +58,43 @@ func TestWriteAPIServerCertAndKey(t *testing.T) {
 		t.Fatalf("controlPlaneEndpoint was not added to SAN: %v", kp.Cert.DNSNames)
 	}
 }
+
+func TestWriteAPIServerCertAndKeyDualStack(t *testing.T) {
+	dir := filepath.Join("testdata", "dualstack")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteClusterCA(filepath.Join(dir, "pki")); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.ControlPlaneConfiguration{
+		ServiceSubnet:  constants.DefaultServiceSubnet,
+		ServiceSubnets: []string{constants.DefaultServiceSubnet, "fd00:10:96::/112"},
+		NodeConfiguration: config.NodeConfiguration{
+			KubeDir:  dir,
+			HostIPv4: "10.0.0.2",
+			HostIPv6: "fd00::2",
+			KubeletConfiguration: &kubeletconfigv1beta1.KubeletConfiguration{
+				ClusterDomain: constants.DefaultClusterDomain,
+			},
+		},
+	}
+
+	if err := WriteAPIServerCertAndKey(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	kp, err := pki.LoadKeyPair(filepath.Join(dir, "pki"), "apiserver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ips := make([]string, 0)
+	for _, ip := range kp.Cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	for _, expected := range []string{"10.254.0.1", "fd00:10:96::1", "10.0.0.2", "fd00::2", "::1"} {
+		if !contains(ips, expected) {
+			t.Fatalf("expected %q in SAN: %v", expected, ips)
+		}
+	}
+}
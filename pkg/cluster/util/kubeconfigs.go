@@ -3,8 +3,13 @@ package util
 import (
 	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+
 	"github.com/criticalstack/crit/internal/config"
 	"github.com/criticalstack/crit/pkg/kubeconfig"
 	"github.com/criticalstack/crit/pkg/kubernetes/pki"
@@ -48,21 +53,99 @@ func WriteKubeletConfig(cfg *config.ControlPlaneConfiguration, ca *pki.Certifica
 	return writeConfig(cfg, ca, KubeletCommonNamePrefix+cfg.NodeConfiguration.Hostname, KubeletFilename, []string{KubeletOrganization})
 }
 
-func writeConfig(cfg *config.ControlPlaneConfiguration, ca *pki.CertificateAuthority, cn, filename string, orgs []string) error {
-	kp, err := ca.NewSignedKeyPair(filename, &pki.Config{
+// KubeConfigSpec is the cluster and client identity of a kubeconfig.
+type KubeConfigSpec struct {
+	Server       string   `json:"server"`
+	ClusterName  string   `json:"clusterName"`
+	CommonName   string   `json:"commonName"`
+	Organization []string `json:"organization,omitempty"`
+}
+
+// KubeConfigSpecs returns the specs of the kubeconfigs written by
+// WriteAdminConfig, WriteControllerManagerConfig, WriteSchedulerConfig and
+// WriteKubeletConfig, keyed by filename.
+func KubeConfigSpecs(cfg *config.ControlPlaneConfiguration) map[string]*KubeConfigSpec {
+	return map[string]*KubeConfigSpec{
+		AdminFilename:             newSpec(cfg, AdminCommonName, []string{AdminOrganization}),
+		ControllerManagerFilename: newSpec(cfg, ControllerManagerCommonName, nil),
+		SchedulerFilename:         newSpec(cfg, SchedulerCommonName, nil),
+		KubeletFilename:           newSpec(cfg, KubeletCommonNamePrefix+cfg.NodeConfiguration.Hostname, []string{KubeletOrganization}),
+	}
+}
+
+func newSpec(cfg *config.ControlPlaneConfiguration, cn string, orgs []string) *KubeConfigSpec {
+	return &KubeConfigSpec{
+		Server:       fmt.Sprintf("https://%s", cfg.ControlPlaneEndpoint),
+		ClusterName:  cfg.ClusterName,
 		CommonName:   cn,
-		Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 		Organization: orgs,
+	}
+}
+
+// ReadKubeConfigSpec returns the spec of an existing kubeconfig, using the
+// cluster and client certificate of its current context.
+func ReadKubeConfigSpec(path string) (*KubeConfigSpec, error) {
+	kc, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	kctx, ok := kc.Contexts[kc.CurrentContext]
+	if !ok {
+		return nil, errors.Errorf("%s: cannot get current context %q", path, kc.CurrentContext)
+	}
+	cluster, ok := kc.Clusters[kctx.Cluster]
+	if !ok {
+		return nil, errors.Errorf("%s: cannot get cluster %q", path, kctx.Cluster)
+	}
+	authInfo, ok := kc.AuthInfos[kctx.AuthInfo]
+	if !ok {
+		return nil, errors.Errorf("%s: cannot get user %q", path, kctx.AuthInfo)
+	}
+	spec := &KubeConfigSpec{
+		Server:      cluster.Server,
+		ClusterName: kctx.Cluster,
+	}
+	data := authInfo.ClientCertificateData
+	if len(data) == 0 && authInfo.ClientCertificate != "" {
+		// the kubelet replaces the embedded client certificate with a
+		// rotated certificate file
+		data, err = ioutil.ReadFile(authInfo.ClientCertificate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(data) > 0 {
+		certs, err := certutil.ParseCertsPEM(data)
+		if err != nil {
+			return nil, errors.Wrap(err, path)
+		}
+		spec.CommonName = certs[0].Subject.CommonName
+		spec.Organization = certs[0].Subject.Organization
+	}
+	return spec, nil
+}
+
+func writeConfig(cfg *config.ControlPlaneConfiguration, ca *pki.CertificateAuthority, cn, filename string, orgs []string) error {
+	return WriteKubeConfig(newSpec(cfg, cn, orgs), ca, filepath.Join(cfg.NodeConfiguration.KubeDir, filename))
+}
+
+// WriteKubeConfig writes a kubeconfig with a new client certificate signed by
+// the CA.
+func WriteKubeConfig(spec *KubeConfigSpec, ca *pki.CertificateAuthority, path string) error {
+	kp, err := ca.NewSignedKeyPair(filepath.Base(path), &pki.Config{
+		CommonName:   spec.CommonName,
+		Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		Organization: spec.Organization,
 	})
 	if err != nil {
 		return err
 	}
 	return kubeconfig.WriteToFile(kubeconfig.NewForClient(
-		fmt.Sprintf("https://%s", cfg.ControlPlaneEndpoint),
-		cfg.ClusterName,
-		cn,
+		spec.Server,
+		spec.ClusterName,
+		spec.CommonName,
 		pki.EncodeCertPEM(ca.Cert),
 		pki.EncodeCertPEM(kp.Cert),
 		pki.MustEncodePrivateKeyPem(kp.Key),
-	), filepath.Join(cfg.NodeConfiguration.KubeDir, filename))
+	), path)
 }
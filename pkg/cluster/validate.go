@@ -0,0 +1,38 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/internal/feature"
+)
+
+// Validate applies the defaults of crit up that only depend upon the
+// configuration and returns every validation error for it. The host is not
+// inspected, so host addresses are not detected and the checks that depend
+// on them, along with the preflight checks, are left to crit up. NodeOverrides
+// are checked, but not applied, since matching them can depend on the host.
+func Validate(obj runtime.Object) []error {
+	switch cfg := obj.(type) {
+	case *config.ControlPlaneConfiguration:
+		errs := validateFeatureGates(cfg.FeatureGates)
+		errs = append(errs, validateNodeOverrides(cfg.NodeOverrides)...)
+		setControlPlaneDefaults(cfg)
+		return append(errs, validateControlPlaneConfiguration(cfg)...)
+	case *config.WorkerConfiguration:
+		errs := validateFeatureGates(cfg.FeatureGates)
+		errs = append(errs, validateNodeOverrides(cfg.NodeOverrides)...)
+		setWorkerDefaults(cfg)
+		return append(errs, validateWorkerConfiguration(cfg)...)
+	default:
+		return []error{errors.Errorf("received invalid configuration type: %T", obj)}
+	}
+}
+
+func validateFeatureGates(gates map[string]bool) []error {
+	if err := feature.MutableGates.SetFromMap(gates); err != nil {
+		return []error{errors.Wrap(err, "invalid FeatureGates")}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"strings"
+	"testing"
+
+	configutil "github.com/criticalstack/crit/pkg/config/util"
+	fmtutil "github.com/criticalstack/crit/pkg/util/fmt"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name: "valid control plane",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: ControlPlaneConfiguration
+controlPlaneEndpoint: 192.0.2.10:6443
+node:
+  kubernetesVersion: 1.18.5
+addons:
+- name: metrics-server
+`,
+		},
+		{
+			name: "valid worker",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+controlPlaneEndpoint: 192.0.2.10:6443
+node:
+  kubernetesVersion: 1.18.5
+`,
+		},
+		{
+			name: "unknown container runtime",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+controlPlaneEndpoint: 192.0.2.10:6443
+node:
+  kubernetesVersion: 1.18.5
+  containerRuntime: rkt
+`,
+			expected: []string{`invalid ContainerRuntime: "rkt"`},
+		},
+		{
+			name: "unknown addon",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: ControlPlaneConfiguration
+controlPlaneEndpoint: 192.0.2.10:6443
+node:
+  kubernetesVersion: 1.18.5
+addons:
+- name: unknown
+`,
+			expected: []string{`unknown addon "unknown"`},
+		},
+		{
+			name: "invalid OIDC",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: ControlPlaneConfiguration
+controlPlaneEndpoint: 192.0.2.10:6443
+node:
+  kubernetesVersion: 1.18.5
+kubeAPIServer:
+  oidc:
+    issuerURL: http://example.com
+`,
+			expected: []string{"invalid OIDC IssuerURL", "must provide ClientID"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := configutil.Unmarshal([]byte(tc.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs := Validate(cfg)
+			if len(tc.expected) == 0 {
+				if len(errs) > 0 {
+					t.Fatalf("expected no errors, received:\n%s", fmtutil.FormatErrors(errs))
+				}
+				return
+			}
+			msg := fmtutil.FormatErrors(errs)
+			for _, s := range tc.expected {
+				if !strings.Contains(msg, s) {
+					t.Errorf("expected error containing %q, received:\n%s", s, msg)
+				}
+			}
+		})
+	}
+}
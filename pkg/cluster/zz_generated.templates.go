@@ -28,41 +28,49 @@ var Files = func() http.FileSystem {
 			modTime:          time.Time{},
 			uncompressedSize: 5520,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\x5f\x8f\xdb\x36\x0c\x7f\xbf\x4f\x41\x38\xc0\x0a\x14\xf9\x83\x61\x18\x36\x64\x4f\xdd\x75\xe8\x06\x5c\x87\x22\x6d\xb7\x87\xe1\x1e\x14\x99\xb1\x85\xc8\x92\x26\xd2\x49\x73\x9f\x7e\x90\xe4\x38\x76\xe2\xe4\x72\x6d\xf7\x0f\xe8\xc3\xe1\x6c\x89\x26\x7f\xfc\x91\x22\xa9\x08\xa7\x7e\x43\x4f\xca\x9a\x39\x88\x3a\x57\x3c\x5d\x7f\x4f\x53\x65\x67\x9b\xaf\x6f\xd6\xca\xe4\x73\x78\x63\xb5\x92\xbb\x1b\x5f\x6b\xa4\xf9\x0d\xc0\x28\xfc\xc1\xad\x57\xac\xa4\xd0\x13\x62\x21\xd7\xe9\x53\x70\x49\x34\xec\xdf\xd9\x02\x9c\xcd\x41\x96\xc2\x14\x48\x20\x18\x16\xf8\x67\x8d\xc4\x0b\x24\x67\x0d\x21\x68\xdc\xa0\xbe\x01\x98\xa4\xa7\xf9\xb1\xc0\x0d\x00\x80\x47\xb2\xb5\x97\xc9\x74\x90\x2d\xbc\xad\xdd\x1c\xb2\x2c\xbe\x07\x53\x8b\x46\x04\x32\x67\x73\xca\x20\xb7\x48\xe6\x19\x43\x25\x58\x96\xe0\x93\x52\x02\xb6\x20\xcc\x0e\xa8\x5e\xee\x75\x82\x5d\x05\x8c\x34\x6e\x55\x6d\x4b\x25\x4b\x50\x04\xd2\x1a\x52\xc4\x68\x18\xb6\x8a\x4b\xe0\x12\x61\xf1\xe3\x8b\xdb\xc6\xc5\x69\xf3\xc5\x01\x1d\xfc\x91\xac\xdf\x47\xef\x5f\xda\x00\x40\xdb\x02\x44\xcd\x25\x9a\x40\x15\x63\xde\x03\x23\xd1\xb3\x50\x06\x8c\x35\x93\x16\xd1\xfb\xc5\x1d\x38\xc1\x25\x4d\xbb\xc4\xfc\x6a\x4d\x62\xa3\x26\xf4\xaf\x02\x01\xd1\x20\xed\x88\xb1\x9a\xf7\x4c\x44\x00\x10\x94\xee\x69\x79\xbf\xb8\x6b\xc9\xcb\x66\xc2\xa9\xe7\x19\x8c\xe0\x77\xa5\x73\x29\x7c\x9e\x58\x52\xa6\x98\xb6\x22\x9b\x94\x0f\x59\x1b\xc7\xe0\x7c\x83\x1c\x96\x36\xdf\x05\xde\xa4\x35\x2b\x55\x54\xc2\xcd\x08\xa5\x47\x6e\x03\xad\x0c\x38\x6f\x19\x65\xf0\xd7\x88\x0a\xc9\x09\x89\x34\x10\xe7\x47\xe2\x0b\x23\x90\xd6\x23\xbc\x78\xf3\x4b\x5a\x1c\xe2\xbc\x85\x41\xd9\x18\xb2\x04\x85\x1a\x0e\x46\xf0\xae\x54\x04\x21\x71\xc1\x1a\xbd\x03\xe1\x9c\x56\x18\xc9\x6f\x95\x04\xbc\xc1\xbf\x6c\x5d\x2f\x71\x92\x18\xcd\x0e\xb8\xa7\xad\x26\x04\xac\x1c\xef\x80\xd8\x2b\x53\x04\x7c\x52\x18\x58\x62\x88\x49\x1e\x54\x12\x6a\x94\x1c\xc3\xd9\x7e\x9e\x1f\x0c\x25\x4d\x07\x42\x02\xfa\xae\xcd\x31\x64\xb2\x77\xa6\x52\x2a\xc5\x20\xbc\x13\x6b\x34\xb0\xf2\xb6\x82\x57\xb7\x3f\x3d\x23\xa8\x04\x31\xfa\xde\xa9\x9b\x43\xc9\xec\x68\x3e\x9b\x15\x8a\xcb\x7a\x39\x95\xb6\x9a\x05\x03\xde\x20\x23\x75\x1f\x97\xda\x2e\x67\x49\xc5\x4c\xea\x3a\xfe\x2f\x24\xce\x0a\xa9\x66\x89\xd0\xda\xe3\xa4\x44\xed\xd0\x4f\xa9\x1c\xdd\x7d\xf7\xcd\xb7\x67\xd3\xb1\x9b\x89\xd1\x1f\xe7\xed\x87\x5d\x13\x82\x0d\xfa\x65\x14\xd8\x86\x2c\x6b\x16\x8f\x42\x3e\x14\xf4\x66\xe3\x28\xd8\x68\x72\x67\x95\xe1\x26\xd6\x7e\xa3\x24\xf6\x9e\x67\xc4\x82\xeb\x14\xff\x0b\x68\x03\x4c\x8d\x9c\xdd\xc3\x08\x34\x16\x42\xee\xa0\x59\x02\x95\x87\x93\xc4\xbb\x3e\xfa\x22\x08\x7f\x1a\x76\x63\xf3\x84\x35\x3e\x3c\x0e\xf4\xe4\x94\x27\x05\xf7\xff\x3a\xb0\x8e\x81\x6e\xd4\xa5\x35\xec\xad\xd6\xe8\x27\x95\x30\xa2\x40\x3f\x28\x46\xb2\xc4\xbc\xd6\xa7\xbb\x4d\x08\x85\x94\xb6\x36\x3c\xef\x9c\x8c\xf9\x3e\xec\x1d\x1b\xa7\x2c\x8c\x21\xab\x5d\x2e\x18\xf7\x05\xf0\xec\x41\xfb\x6c\x49\xf8\x48\x96\xed\xab\xb3\x53\xc1\x37\xf4\x7f\x47\xec\x5a\x27\x63\x00\xdb\xb7\x7d\x14\xfb\x8b\x2b\x65\x84\x56\x0f\xf8\x18\xf0\xa6\x24\x4c\x44\xcd\x96\xa4\xd0\xc3\xd0\xff\x31\xc2\xfb\x25\xbe\x4f\x7f\xb7\xd3\xfe\xfc\xe6\x05\xac\x30\x35\x33\xa8\x90\xbd\x92\x17\xba\xe8\xd3\xd2\xf8\xd8\x73\xad\xe8\x8a\xe0\xed\x41\xa4\x89\x2a\x3b\xc2\xcb\x25\x52\xe8\xab\x22\x9f\xc4\xee\x14\xfa\xf4\x30\xde\xc1\x66\x1e\x04\x67\x25\x0a\xcd\xe5\xc3\xf3\xc3\x4a\xd3\xbc\x0f\x0b\xb4\x15\x45\x81\xfe\xf9\x91\x75\xdc\xa0\x61\x6a\x07\x92\x61\xc3\x1f\x7f\x48\xa2\xf6\x26\x44\xa1\xb0\x80\x30\x79\x9c\x07\x53\x66\x82\x14\x5a\x53\xea\x68\xb1\xee\x80\xf0\x08\xa5\x2a\xca\xc9\xc6\xea\xba\x4a\xf2\x4d\x83\xd5\xc2\x17\x38\x86\xbc\xc5\xee\x9b\x01\x91\x60\x65\x3d\xe0\x07\x97\x86\x8d\x94\x8e\x5d\xad\xe7\x86\x8e\x93\x4e\x10\x7a\xc8\xa1\xcc\x86\x16\xb6\xd4\x58\x4d\x72\x0c\x73\x8c\xf5\x9d\xfd\x0b\x75\x6a\xf8\xd3\xa3\xa3\xd3\x9c\x99\x71\xe6\x3e\x47\x47\xec\xd5\xec\x71\x1a\x80\xbb\x25\x1c\xc0\x56\x8a\xdf\xb2\x28\xba\xba\xb3\x76\xd0\x96\xa8\x36\x98\x67\x97\x78\xba\xae\x11\xfd\x57\xdd\x1a\x41\x1e\x02\x8c\x32\x9c\x69\xc9\xca\x9a\x26\xf5\x9e\x98\x5b\x6d\x75\x4b\xfa\x94\x35\x8f\x27\xd7\x15\x19\xb3\xd7\xda\xa9\x3b\xc7\xcc\x1e\xe3\x7f\x32\x01\x6f\xd3\x50\x3c\x86\xdb\x58\x48\x5f\x0b\x47\xe3\x78\xbc\xde\xd9\x35\x9a\x05\x6e\x14\x6e\x13\x1f\x01\x44\xb8\x91\x10\x1a\x52\xac\x36\x08\x5f\xc1\x52\x19\xe1\x77\x90\x0b\x16\xe3\xa8\x8e\x6c\x1a\xa6\xe3\xe5\x86\xe3\xec\xfc\x1a\x59\x04\x81\xc4\x46\xaf\x94\xec\xb7\x3e\x2d\x1f\xf6\x73\x7d\x98\x91\x0f\xdd\xe0\xfe\x58\x4b\xe7\x22\xa4\xac\x69\xca\xee\xb0\x46\x0e\xbe\xfb\xe4\xfb\x93\x19\x7d\x85\x0c\x1e\x1d\xa5\x3c\xe9\x66\xd2\x0f\x40\x6b\xe5\x02\x29\xd5\xf4\x5c\x7e\x0c\xf6\x92\x31\x7c\xe4\x88\x7c\xd8\x10\x79\xa5\x28\xd4\x7e\x8f\x85\x22\xf6\x5d\x12\xb2\x53\x69\xa7\xf0\x03\x87\x40\x5b\x43\x97\xa4\xae\xd4\xe6\x68\x60\x75\x28\x1e\xc3\x62\xd6\xab\x87\x47\xa5\xe2\x34\xa2\x4c\x71\xba\xb9\x8c\xdc\x9d\x2c\x87\x2b\xb6\x5a\xc5\x8b\xf1\x79\x1f\x0f\x34\x9c\xee\x9d\x76\xf0\xfe\xbe\x41\xde\x5a\xbf\x0e\xd7\xe7\xb3\x22\x36\xc7\xb3\x9b\xe9\xea\x76\xba\xee\x97\x42\x4e\xaf\xe3\xa5\x99\xa8\x2f\x41\x20\x64\x56\xa6\x38\xef\x06\xb1\xf5\xa2\xe8\xc3\xbc\xfe\x38\xbc\xc4\x95\xa8\x35\xa7\x5c\x8f\x65\x73\x6d\xec\xd6\x84\x8b\xfb\x50\x91\xbc\xf4\xf3\xce\x97\x44\xff\x92\xe8\xff\xa7\x44\x17\x5a\x83\xe5\x12\xfd\xf0\x20\xdd\xeb\x7e\xd7\xd9\xf9\x2b\x00\x00\xff\xff\x83\x2b\x4c\x71\x90\x15\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\x5f\xaf\xdb\xb6\x0e\x7f\x3f\x9f\x82\x70\x80\x5b\xa0\xc8\x1f\x5c\x5c\x5c\x6c\xc8\x9e\xba\xd3\xa1\x1b\x70\x3a\x14\x69\xbb\x3d\x14\x79\x50\x24\xc6\x16\x22\x4b\x9a\x48\x27\x4d\x3f\xfd\x20\xcb\x71\xec\xc4\xc9\xc9\x69\x8b\xfd\x01\xfa\x70\x70\x1c\x91\x26\x7f\xfa\x91\x14\x29\x0b\xaf\x7f\xc3\x40\xda\xd9\x39\x88\x4a\x69\x9e\x6e\xbe\xa7\xa9\x76\xb3\xed\x7f\xef\x36\xda\xaa\x39\xbc\x71\x46\xcb\xfd\x5d\xa8\x0c\xd2\xfc\x0e\x60\x14\xff\xe0\x3e\x68\xd6\x52\x98\x09\xb1\x90\x9b\xf4\x2a\xf8\xa4\x1a\xe5\x0f\x2e\x07\xef\x14\xc8\x42\xd8\x1c\x09\x04\xc3\x02\xff\xa8\x90\x78\x81\xe4\x9d\x25\x04\x83\x5b\x34\x77\x00\x93\xf4\x34\x3f\x55\xb8\x03\x00\x08\x48\xae\x0a\x32\xb9\x8e\xba\x79\x70\x95\x9f\x43\x96\xd5\xe2\xe8\x6a\xd1\xa8\x40\xe6\x9d\xa2\x0c\x94\x43\xb2\xcf\x18\x4a\xc1\xb2\x80\x90\x8c\x12\xb0\x03\x61\xf7\x40\xd5\xea\x60\x13\xdc\x3a\x62\xa4\x71\x6b\x6a\x57\x68\x59\x80\x26\x90\xce\x92\x26\x46\xcb\xb0\xd3\x5c\x00\x17\x08\x8b\x1f\x5f\xdc\x37\x5b\x9c\x36\x6f\x1c\xd1\xc1\x87\xe4\x7d\x59\xb3\xf3\xd2\x45\x00\xc6\xe5\x20\x2a\x2e\xd0\x46\xaa\x18\x55\x0f\x8c\xc4\xc0\x42\x5b\xb0\xce\x4e\x5a\x44\xef\x17\x0f\xe0\x05\x17\x34\xed\x12\xf3\xab\xb3\x58\x7b\xac\x08\xc3\xab\x48\x40\xed\x90\xf6\xc4\x58\xce\x7b\x2e\x6a\x00\x10\x8d\x1e\x68\x79\xbf\x78\x68\xc9\xcb\x66\xc2\xeb\xe7\x19\x8c\xe0\x77\x6d\x94\x14\x41\x25\x96\xb4\xcd\xa7\xad\xca\x36\xe5\x43\xd6\xc6\x31\x6e\xbe\x41\x0e\x2b\xa7\xf6\x91\x37\xe9\xec\x5a\xe7\xa5\xf0\x33\x42\x19\x90\xdb\x40\x6b\x0b\x3e\x38\x46\x19\xf7\x6b\x45\x89\xe4\x85\x44\x1a\x88\xf3\x23\xf1\x85\x11\x48\x17\x10\x5e\xbc\xf9\x25\x2d\x0e\x71\xde\xc2\xa0\x6c\x0c\x59\x82\x42\x0d\x07\x23\x78\x57\x68\x82\x98\xb8\xe0\xac\xd9\x83\xf0\xde\x68\xac\xc9\x6f\x8d\x80\xb6\x75\x70\xb3\x4d\xb5\xc2\x49\x62\x34\x3b\xe2\x9e\xb6\x96\x10\xb0\xf4\xbc\x07\xe2\xa0\x6d\x1e\xf1\x49\x61\x61\x85\x50\x11\xaa\x68\x92\xd0\xa0\xe4\xc8\xfc\xa4\x7d\x5d\x1d\xd1\x26\x4b\xad\xa4\x0e\x60\xd7\xe7\x18\x32\xd9\xab\xa9\x7a\x17\xa9\xd8\xde\x89\x0d\x5a\x58\x07\x57\xc2\xab\xfb\x9f\x9e\x11\x94\x82\x18\x43\xaf\xea\xe6\x50\x30\x7b\x9a\xcf\x66\xb9\xe6\xa2\x5a\x4d\xa5\x2b\x67\xd1\x41\xb0\xc8\x48\xdd\xc7\x95\x71\xab\x59\x32\x31\x93\xa6\xaa\xff\xe7\x12\x67\xb9\xd4\xb3\x44\x68\x15\x70\x52\xa0\xf1\x18\xa6\x54\x8c\x1e\xbe\xfb\xdf\xff\x2f\xa6\x63\x37\x13\xa3\x8f\x89\x0f\xee\xe3\xbe\x09\xc1\x16\xc3\xaa\x56\xd8\xc5\x2c\x6b\x16\x5b\x4a\xe6\x4d\x44\xcf\x83\xde\x08\x4e\x82\x8d\x56\x79\xa7\x2d\x37\xb1\x0e\x5b\x2d\xb1\xf7\x3c\x23\x16\x5c\xa5\xf8\x5f\x41\x1b\x61\x1a\xe4\x6c\x09\x23\x30\x98\x0b\xb9\x87\x66\x09\xb4\x8a\x95\xc4\xfb\x3e\xfa\x1c\xf9\x4b\xb1\x5b\xa7\x12\xd6\xfa\xe1\x71\xa0\x67\x55\x5e\xbf\x97\x2d\xff\x76\x60\x1d\x07\xdd\xa8\x4b\x67\x39\x38\x63\x30\x4c\x4a\x61\x45\x8e\x61\x50\x8d\x64\x81\xaa\x32\xe7\xd2\x26\x84\x42\x4a\x57\x59\x6e\x94\x93\xe8\x10\xf6\x8e\x8f\x73\x16\xc6\x90\x55\x5e\x09\xc6\x6c\xf9\x48\xa1\x7d\xb5\x24\x5c\xde\x54\x13\xc2\xeb\xb8\x37\x0c\xd9\xf2\x1c\xf5\x97\xc6\xae\xdd\x64\x1d\xc0\xf6\xd7\x21\x8a\xfd\xc5\xb5\xb6\xc2\xe8\x4f\x78\x35\xb8\xf0\x21\x6b\x8e\x84\x89\xa8\xd8\x91\x14\x66\x18\xfa\x5f\x46\x78\xff\x88\xef\xd3\xdf\xed\xb4\x3f\xbf\x79\x01\x6b\x4c\xcd\x0c\x4a\xe4\xa0\xe5\x95\x2e\x4a\xf3\xd3\x0c\xbc\x96\xc6\xa7\x3b\x37\x9a\x6e\x08\xde\x01\x44\x9a\xa8\xb2\x13\xbc\x5c\x20\xc5\xbe\x2a\xd4\xa4\xee\x4e\xb1\x4f\x0f\xe3\x1d\x6c\xe6\x51\x71\x56\xa0\x30\x5c\x7c\x7a\x7e\x5c\x69\x9a\xf7\x71\x81\x76\x22\xcf\x31\x3c\x3f\xf1\x8e\x5b\xb4\x4c\xed\x40\x32\xec\xf8\xb3\x63\x96\xac\x37\x21\x8a\x07\x0b\x08\xab\xe2\xac\x05\x29\x33\x41\x0a\x63\x28\x75\xb4\x28\x26\x10\x01\xa1\xd0\x79\x31\xd9\x3a\x53\x95\x49\xbf\x69\xb0\x46\x84\x1c\xc7\xa0\x5a\xec\xa1\x19\x10\x09\xd6\x2e\x00\x7e\xf4\x69\xd8\x48\xf5\xdf\xb5\x7a\x69\xe8\x38\xeb\x04\x63\xe8\x1e\xb3\xb1\x85\xad\x0c\x96\x13\x85\x71\x8e\x71\xa1\x23\xbf\x72\x4e\x0d\xbf\x7a\x52\x3a\x4d\xcd\x8c\x33\xff\x35\x3a\x62\xef\xcc\x1e\xa7\x01\xf8\xf0\x33\x39\x76\xa5\xe6\xb7\x2c\xf2\xae\xed\xac\xe1\x62\x81\x12\xf5\x16\x55\x76\x8d\xa7\xdb\x1a\xd1\x3f\x75\x5b\x23\x50\x31\xc0\x28\x63\x4d\x4b\xd6\xce\x36\xa9\xf7\xc4\xdc\x6a\x4f\xb7\x64\x4f\x3b\xfb\x78\x72\xdd\x90\x31\x07\xab\x9d\x73\xe7\x94\xd9\x53\xfc\x4f\x26\xe0\x6d\x1a\x8a\xc7\x70\x5f\x1f\xa4\xaf\x85\xa7\x71\x5d\x5e\xef\xdc\x06\xed\x02\xb7\x1a\x77\x89\x8f\x08\x22\xde\x48\x08\x2d\x69\xd6\x5b\x84\xff\xc0\x4a\x5b\x11\xf6\xa0\x04\x8b\x78\x4d\x1a\x01\xb9\x34\x4c\x47\xb2\x04\xd7\xb3\xf3\x6b\x64\x11\x15\x52\xa9\xf5\x8e\x92\x83\xe8\xcb\xf2\xe1\x30\xd7\x8f\xa1\xdb\x0d\x96\xa7\x56\x3a\x17\x21\xed\x6c\x73\xec\x0e\x5b\xe4\xb8\xf7\x90\xf6\xfe\x64\x46\x5f\x21\x43\x40\x4f\x29\x4f\xba\x99\xf4\x03\xd0\x46\xfb\x48\x4a\xd9\xa3\xa1\x31\x73\xb9\x97\x8c\xe1\x33\x47\xe4\xa3\x40\xa8\x52\x53\xbc\xb8\x05\xcc\x35\x71\xe8\x92\x90\x9d\x6b\x7b\x8d\x1f\x39\x06\xda\x59\xba\xa6\x75\xa3\x35\x4f\x03\xab\x43\xf1\x18\x56\x73\x41\x7f\x7a\x54\xab\x9e\x46\xb4\xcd\xcf\x85\xab\x9a\xbb\xb3\xe5\x78\xc5\xd6\xeb\xe8\x1f\x2f\xef\xf1\x48\x43\x76\x43\x07\xef\xcb\x2d\xf2\xce\x85\x4d\xbc\x3e\x5f\x54\x71\x0a\x2f\x0a\xd3\xd7\x84\xf3\xf5\xb0\x12\x72\x7a\x1b\x2f\xcd\x44\x7d\x0d\x02\x21\xb3\xb6\xf9\xe5\x6d\x10\xbb\x20\xf2\x3e\xcc\xdb\xcb\xe1\x25\xae\x45\x65\x38\x55\x7f\xdd\x92\x37\xd6\xed\x6c\xbc\xb8\xd3\x13\x3f\xef\x7c\x4b\xf4\x6f\x89\xfe\x6f\x4a\x74\x61\x0c\x38\x2e\x30\x0c\x0f\xd2\xbd\xee\x77\x9b\x9f\x3f\x07\x00\x83\x2b\x4c\x71\x90\x15\x00\x00"),
 		},
 		"/coredns.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "coredns.yaml",
 			modTime:          time.Time{},
-			uncompressedSize: 5529,
+			uncompressedSize: 5514,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\x4b\x77\xdb\xb6\x12\xde\xeb\x57\xe0\xf0\x2e\xb2\x09\x69\xd1\x8a\x6c\x85\x3b\x5f\x3b\x27\xf1\xc9\x8d\xaf\x4e\xe4\x76\x93\x93\x05\x04\x0c\x45\x54\x20\x80\xe2\xe1\x58\x55\xfd\xdf\x7b\x40\xf0\x29\xd9\x96\xdc\xb4\x3b\xc3\x0b\x53\x98\xc1\x60\xe6\xc3\x37\x83\x47\x1c\xc7\xa3\xff\xa0\x85\x74\x9a\x40\x86\x88\xd4\x40\x85\x39\xb1\x50\x2a\x8e\x2d\x98\x13\x22\x45\xce\x56\x25\x56\xc9\x06\x97\x7c\x84\x15\xfb\x15\xb4\x61\x52\x64\xe8\x2e\x1d\xad\x99\xa0\x19\xba\xac\x74\xbe\x60\x35\x2a\xc1\x62\x8a\x2d\xce\x46\x08\x09\x5c\x76\x16\x47\x08\x71\xbc\x04\x6e\xbc\x04\x21\xac\x54\xb2\x76\x4b\xd0\x02\x2c\x98\x84\xc9\x93\x12\x0b\xbc\x02\x1a\x2f\x37\x19\x8a\x3e\x01\x2f\xa3\x27\x14\x99\x30\x16\x0b\xef\x6c\x54\xdb\x0e\x9a\x05\xf0\x32\x31\xc5\x09\x29\xb0\xb6\x9d\x30\x4e\x93\xf3\x24\x0d\x2a\xeb\x99\x89\xb1\x52\xbb\x23\x87\xf6\x09\x77\xc6\x82\x8e\x0d\xe8\x3b\x56\x4d\x63\xb5\x83\xc7\x34\x43\x80\xd1\xa5\xd4\x70\x75\xb3\x78\xca\xdf\x21\x0c\x0d\x38\x7e\x50\xce\x38\x64\xe8\xcf\xb8\x1a\x98\x64\xd3\x09\xda\x56\x9f\xbe\x81\xd6\x52\x9b\xf6\x67\x01\x98\xdb\xa2\x27\xf7\x8d\xe3\x12\xa8\x23\x6b\x34\xed\x14\x1f\xda\x2f\x0d\x98\x6e\xda\x5f\x9d\x4f\xa8\x0e\x30\xe1\x92\x60\x8e\x98\x88\x31\xa5\x3a\xc1\x5a\x61\xc4\xd4\x59\xf8\x18\x4e\xa4\x24\x35\x88\x09\x03\xc4\x69\x18\x48\x72\xcc\xb9\x2d\xb4\x74\xab\xe2\x71\x4b\x03\x6d\x6b\x39\x9a\x8c\x1f\xf1\x55\x69\x59\x82\x2d\xc0\x19\x34\x4e\xaa\xbf\xec\x7d\x3a\x9d\xb4\xf2\x5c\xea\x1f\x58\x53\x94\xa0\x13\xb0\xe4\x44\x83\x91\xfc\x2e\xf1\xd4\x6c\x55\x08\x26\x05\xf4\xad\x73\x29\x55\x0f\x0c\x2e\x31\xed\xc9\x30\x5d\x62\xee\x69\x34\x0a\x9e\x1c\x4c\x83\x00\x9a\x96\x1c\xf6\x13\x41\x2f\x31\x49\xb0\xb3\x85\xd4\xec\x0f\x6c\x99\x14\xc9\x7a\x56\x2d\x7e\x97\x22\x61\xfc\x57\xc9\xe1\x35\x49\x0e\x25\x89\x76\x1c\x4c\x36\x8a\x11\x56\xec\xa3\x96\x4e\x55\x80\xc4\x28\xf2\xc3\xfd\xe2\xfb\x55\xaa\xfb\x40\x50\x25\x99\xb0\xa6\xfa\x55\xfb\x13\x7e\x78\xda\x56\x1f\xde\xba\x51\x38\xf4\xdf\x81\x5e\xd6\x63\x39\x33\xb6\xfa\xf8\x81\x2d\x29\x5e\xc2\x81\x25\x13\x94\x89\xd5\xcf\x52\xe1\xbf\xc1\xcc\x2b\x23\x0e\x32\x42\x72\xf8\x0a\xb9\x47\xa1\xe1\xc4\x33\x58\x8f\x10\xda\xcf\xba\x5d\x64\x8d\x5b\xfe\x06\xc4\x56\x3c\x0b\xda\x8b\xe0\xfa\x05\x21\xd2\x09\xdb\x0e\xa0\x90\x63\xc7\x9b\xdf\x15\x8f\xb2\x2a\xa4\xd8\x6c\x8c\x85\xf2\x20\x6f\x6a\x48\x9e\xd9\x40\xeb\x99\x5f\x79\x70\x88\x07\x08\x61\x21\xa4\xad\x16\xbb\xc6\xa2\xdb\x3b\xbc\xba\x92\x95\xf7\x7e\xf7\x88\x1e\x11\x1b\xa2\xb1\xea\x9c\x32\x0a\x88\xb7\x62\x80\x03\xb1\x52\x3f\x85\xee\x53\xa0\x3d\x81\xc8\xe1\x28\x6a\x94\xae\xe7\x19\x8a\xb6\x5b\xc4\x04\x85\x7b\x94\xdc\x48\x0a\xe1\x2c\xe5\x74\xe0\xf3\x67\xb7\x04\x0e\x76\xd8\x59\xb3\xfa\xea\x66\x81\xc6\xe8\xe1\xc1\xcf\xe9\xc3\xae\x8b\xda\x36\x40\x30\x9d\xbc\xf5\xb1\x5b\x49\x24\xcf\xd0\x2f\x57\xf3\xb7\x35\xa3\x1c\x55\xf1\x74\xf2\xf0\xb4\xee\xed\x65\xab\x6b\x49\xa3\x6b\x37\x1e\xb6\xcb\xc6\xed\x83\x9c\xa7\x18\x4a\x29\x0c\xd8\x7d\xd6\x63\xa5\x4c\x57\x0d\xaf\x2a\xc5\x05\xd8\x57\xf2\x1f\xa2\xcd\xe3\x6c\x2d\xfd\xde\xf5\xbf\x1e\x3e\x2f\x09\xfc\xc9\xb8\x8e\x21\x71\xb3\xda\xb5\x1f\xbd\xe5\x0b\xa7\xd3\xbe\x4b\xcf\x4c\x74\xcc\x54\x2f\x8f\x6b\xaf\x4e\x54\x87\xc4\x02\xc8\xda\xb8\xb2\xbe\xd5\x64\xe8\xdd\xe4\x94\xe0\xb3\xd3\x19\xcc\xc6\x93\xf7\xa7\x64\x32\x4d\xd3\xf3\x73\x32\x4e\x69\x3e\x5d\x4e\x20\x3d\x7d\x47\x66\xe7\xd3\x09\x85\xd9\x94\x42\x7e\x76\xbe\x7c\x97\x03\x3e\x3b\x1b\xe3\xb3\x49\x3a\xa5\x29\x2c\x5b\xcb\x86\x14\x40\x1d\x07\x9d\x60\xae\x0a\xbc\xe3\x26\xd1\xcc\x32\x82\x79\xac\x24\xcd\xd0\x9b\x37\x47\x0e\xb3\x92\x83\xae\x63\x40\x6f\xbe\x6d\xa3\x35\x6c\xa2\x2c\xba\xac\xad\x5d\x50\x2a\x85\xf9\xbf\xe0\x9b\xe8\x2d\x8a\xa4\xf2\xba\x52\x47\x59\xf4\xe1\x9e\x19\x6b\xa2\x87\xef\x61\xa2\x86\x37\xd5\xf7\x60\x8f\xbb\xd9\xd9\xe0\x7c\xa3\xc2\xcc\x25\x67\x64\x93\xa1\xab\x81\x00\xe7\x39\x13\xcc\x6e\x3a\x38\x85\xa4\x70\xb1\xd7\xeb\x8f\x68\xbf\x3b\xa6\x81\x5e\x39\xcd\xc4\x6a\x11\x62\x64\x62\x75\xbd\x12\xb2\xed\xfe\x70\x0f\xc4\xf9\xd8\xb2\xc1\x1d\xc1\xdb\x5c\xd4\x04\xbf\x05\x5d\x9a\xa1\x38\x0e\x7c\xff\x70\xaf\x34\x18\x33\x5c\xdd\x46\x63\x0d\x9b\xac\xb2\x13\x57\xc7\xf5\xdd\x5a\xe1\x33\x74\x67\x0c\x42\x0d\x7a\x19\x0a\xe0\x75\x57\x13\x49\x2f\x84\x65\xff\x6c\x98\x71\xc8\x8e\xc5\x20\x91\xbb\x76\x64\x88\x75\x46\x3d\x13\xcb\xb5\xd8\x13\xde\x61\xee\x60\xcf\x62\x65\xd3\x1f\x69\xfa\xe9\xe6\x9b\x95\x4a\x72\xb9\xda\x7c\xae\x26\x1c\x20\x59\x48\x63\x7d\x9e\x8e\x1a\xcd\x8e\xac\xa3\x1d\x4f\xf7\x19\x3b\x3a\x02\xfa\x18\x41\x9e\x03\xb1\x19\xba\x91\x35\xba\xfd\xdb\xe7\xf1\xcb\x4c\xa4\xb0\x98\x09\xd0\xad\x67\x71\xbd\xc3\xec\xd7\x22\x56\xe2\x55\x4f\x70\x52\xff\xcf\xb6\x5b\x94\xd4\xd5\xba\xde\xc9\xea\xad\xb7\x37\x6c\xee\x38\x6f\x72\xe7\x3a\xbf\x91\x76\xae\xc1\x80\xb0\x5d\x01\xd3\x2b\x93\xa1\x6f\x28\x8a\x7d\x01\xf2\x59\x5b\xdd\x67\x9b\xb9\x9a\x47\x81\x08\x7d\x6f\x87\xdc\x49\xee\x4a\xf8\xe2\xb3\x75\x80\x6b\x53\x21\x7d\x1d\x8b\x83\x52\x0f\x9b\xd2\xeb\xcf\xb1\x2d\x32\xd4\x9f\xa1\x77\x1f\xee\x5d\xa2\x9a\xc6\x59\xc9\xec\x0e\x35\x88\x72\x19\x4a\xc7\xe3\x72\xd0\x5b\x42\x29\xf5\x26\x43\xe9\xe9\xec\x0b\xdb\xc9\x08\x30\x3f\x63\xa3\x3d\xc8\x34\x71\x6e\xdb\xc5\x9b\x1f\x7b\xae\x39\x6e\xe8\xa3\xc7\x9c\xd0\xba\x77\x02\x76\x07\x02\x8c\x99\x6b\xb9\x84\x7e\x50\x85\xb5\xea\x23\xd8\x61\x9c\x2a\xe0\x1d\xde\x6a\x76\x1e\x50\xfc\xfc\xb3\xf1\x6c\x3c\xe8\xf6\xd5\xdf\xcf\xff\xe9\xf6\x76\xde\x13\xf8\x42\xc3\x30\xbf\x02\x8e\x37\x0b\x20\x52\x50\x93\xa1\xb3\xfe\x50\xcb\x4a\x90\xce\xb6\xc2\x69\x4f\x66\x1c\x21\x60\xcc\x6d\xa1\xc1\x14\x92\xd3\x0c\xa5\x3d\x69\x8e\x19\x77\x1a\x7a\xd2\xe9\xe0\xc5\x88\xbd\x38\xdc\xe1\x3b\x53\x2f\xda\x74\x96\xfe\xed\x68\xd3\x7f\x3b\xda\x90\x31\x2f\xc8\x28\xd2\xbc\x71\xee\x6c\x57\x8f\x1e\x54\xaa\xb8\x2c\xec\x6f\x5e\xa1\x1a\xd6\x69\xbe\x53\x83\x03\x9e\xad\xf0\xaf\x00\x00\x00\xff\xff\xd7\x1e\xea\xc2\x99\x15\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\xcd\x72\xdb\x36\x10\xbe\xeb\x29\x76\xd8\x43\x2e\x21\x2d\x5a\x91\xad\xf0\xe6\x5a\x99\xc4\x93\xc6\xd5\x44\x6e\x2f\x99\x1c\x20\x60\x29\xa2\x02\x01\x14\x00\x15\xab\xaa\xdf\xbd\x03\xfe\x89\x94\xe4\xc8\x6e\xda\x9b\x87\x3c\x80\xd8\x1f\xec\x7e\xf8\x16\x00\x11\x86\xe1\xe0\x27\x98\xab\xc2\x50\x4c\x80\x2a\x83\x4c\xda\x33\x87\xb9\x16\xc4\xa1\x3d\xa3\x4a\xa6\x7c\x99\x13\x1d\x6d\x48\x2e\x06\x44\xf3\xdf\xd1\x58\xae\x64\x02\xeb\x78\xb0\xe2\x92\x25\x70\x5d\xea\x7c\x22\x7a\x90\xa3\x23\x8c\x38\x92\x0c\x00\x24\xc9\x77\x1e\x07\x00\x82\x2c\x50\x58\x2f\x01\x20\x5a\x47\xab\x62\x81\x46\xa2\x43\x1b\x71\x75\x96\x13\x49\x96\xc8\xc2\xc5\x26\x81\xe0\x03\x8a\x3c\x78\x44\x91\x4b\xeb\x88\xf4\xc1\x06\xb5\xef\x4a\x33\x43\x91\x47\x36\x3b\xa3\x19\x31\x6e\x27\x0c\xe3\xe8\x32\x8a\x2b\x95\xd5\xc4\x86\x44\xeb\x7d\xcb\xbe\x7f\x2a\x0a\xeb\xd0\x84\x16\xcd\x9a\x97\xc3\x38\x53\xe0\x31\xcd\x2a\xc1\xe0\x5a\x19\x9c\xde\xce\x1f\x8b\xb7\x0f\x43\x03\x8e\x37\x4a\xb9\xc0\x04\xfe\x0e\x4b\xc3\x28\x19\x8f\x60\x5b\x36\xfd\x8b\xc6\x28\x63\xdb\xcf\x0c\x89\x70\x59\x47\xee\x5f\x41\x72\x64\x05\x5d\xc1\x78\xa7\xf8\xd0\xb6\x0c\x12\xb6\x69\xbf\x76\x31\x41\x9d\x60\x24\x14\x25\x02\xb8\x0c\x09\x63\x26\x22\x46\x13\xe0\xfa\xa2\x6a\xf4\x07\xd2\x8a\x59\xe0\xd2\x22\x2d\x0c\xf6\x24\x29\x11\xc2\x65\x46\x15\xcb\xec\xb8\xa7\x9e\xb6\x73\x02\x46\xc3\x23\xb1\x6a\xa3\x72\x74\x19\x16\x16\x86\x51\xf9\x24\x6f\xe3\xf1\xa8\x95\xa7\xca\x7c\x23\x86\x41\x04\x67\xe8\xe8\x99\x41\xab\xc4\x3a\xf2\xd4\x6c\x55\x28\xa1\x19\x76\xbd\x0b\xa5\x74\xfb\x61\x50\x28\xc2\x3a\x32\xc2\x16\x44\x78\x1a\x95\x7d\x0f\x83\x93\x65\x50\x81\x66\x94\xc0\xc3\x42\x30\x0b\x42\x23\x52\xb8\x4c\x19\xfe\x17\x71\x5c\xc9\x68\x35\x29\xc9\xba\x2b\x91\xca\xfe\xb3\x12\xf8\x52\x24\xa7\x8a\xc4\x14\x02\x6d\x32\x08\x81\x68\xfe\xde\xa8\x42\x97\x80\x84\x10\x78\x73\x3f\xf9\x7e\x96\xea\x3e\x94\x4c\x2b\x2e\x9d\x2f\x81\x10\xea\x78\xaa\x0f\x4f\xdb\xb2\xe1\xbd\x5b\x4d\xaa\xfe\x35\x9a\x45\x6d\x2b\xb8\x75\x65\xe3\x1b\x71\x34\x7b\x0e\x07\x16\x5c\x32\x2e\x97\x3f\x4a\x85\x9f\x2b\x37\x2f\x8c\x38\xc9\x08\x25\xf0\x33\xa6\x7e\xda\x1a\x4e\x7c\x07\xeb\x01\xc0\x61\xd5\xed\x23\x6b\x8b\xc5\x1f\x48\x5d\xc9\xb3\x4a\x7b\x5e\x91\xe7\x8a\x52\x55\x48\xd7\x1a\x30\x4c\x49\x21\x9a\xef\x92\x47\x09\xf8\x60\x43\xbb\xb1\x0e\xf3\x93\xbc\xa9\x21\x39\x24\x4b\x4b\x89\x7a\xe4\x17\x1e\x9c\xe2\x01\x00\x91\x52\xb9\x72\x8d\xad\xb1\xd8\xed\x1d\x5e\x5d\xab\x72\xf3\xf7\xbb\x47\x70\x44\x6c\xa9\x21\x7a\x17\x94\xd5\x48\xbd\x17\x8b\x02\xa9\x53\x26\x79\x26\x68\x8f\x20\x72\x3a\x8b\x1a\xa5\x9b\x59\x02\xc1\x76\x0b\x5c\x32\xbc\x87\xe8\x56\x31\xac\xce\x52\x85\x29\x53\x8c\x3e\x16\x0b\x14\xe8\xfa\x9d\x35\xab\xa7\xb7\x73\x18\xc2\xc3\x83\x0f\xc5\xa7\x5d\x2f\x6a\x5b\xdf\x4e\x60\x3c\x7a\x0d\xda\x28\xa7\xa8\x12\x09\xfc\x36\x9d\xbd\xae\x19\x55\x30\x1d\x8e\x47\x0f\x8f\xeb\xde\x5d\xb7\xba\x8e\x36\xba\x6e\xa3\xb1\xad\xa7\x9b\xd9\x49\xce\x33\x82\xb9\x92\x16\xdd\x21\xeb\x89\xd6\x76\xb7\x1a\x4e\x4b\xc5\x39\xba\x17\xf2\x9f\x22\xff\x71\xb6\xe6\x7e\xef\xfa\xa5\x83\xcf\x73\x12\x7f\x34\xaf\xd3\xd1\x00\x34\xb3\x5d\xc7\xd1\x99\x3e\x80\xfe\x94\x7d\x77\xa0\xa7\x0c\xf5\xfc\xbc\x0e\xd6\x09\xff\xd2\x0c\xe9\xca\x16\x79\xfd\x57\x93\xc0\x9b\xd1\x39\x25\x17\xe7\x13\x9c\x0c\x47\x6f\xcf\xe9\x68\x1c\xc7\x97\x97\x74\x18\xb3\x74\xbc\x18\x61\x7c\xfe\x86\x4e\x2e\xc7\x23\x86\x93\x31\xc3\xf4\xe2\x72\xf1\x26\x45\x72\x71\x31\x24\x17\xa3\x78\xcc\x62\x5c\xb4\x9e\x2d\xcd\x90\x15\x02\x4d\x44\x84\xce\xc8\x5e\x98\xd4\x70\xc7\x29\x11\xa1\x56\x2c\x81\x57\xaf\x9e\x68\xe6\x94\x40\x53\xe7\x00\xaf\xbe\x6c\x83\x15\x6e\x82\x24\xb8\xae\xbd\x5d\x31\xa6\xa4\xfd\x55\x8a\x4d\xf0\x1a\x02\xa5\xbd\xae\x32\x41\x12\xbc\xbb\xe7\xd6\xd9\xe0\xe1\x6b\x35\x50\xc3\x1b\xff\xd8\xde\x1e\x77\xbb\xb7\xc1\x79\x0d\x26\xed\x4c\x09\x4e\x37\x09\x4c\x7b\x02\x92\xa6\x5c\x72\xb7\xd9\xc1\x29\x15\xc3\xab\x83\x5e\x7f\x44\xfb\xb3\xe0\x06\xd9\xb4\x30\x5c\x2e\xe7\x55\x8e\x5c\x2e\x6f\x96\x52\xb5\xdd\xef\xee\x91\x16\x3e\xb7\xae\x65\xe5\x73\x5e\x13\xfc\x0e\x4d\xde\x99\x3d\xff\x86\x15\xdf\xdf\xdd\x6b\x83\xd6\xf6\x67\xb7\xd1\x58\xe1\x26\x29\xfd\x84\xfe\xa8\xb6\x87\x69\x4e\xfc\xe1\x6b\xcf\x06\xa0\x41\x2f\x81\x0a\xbc\x56\x41\x2b\x76\x25\x1d\xff\x6f\xd3\x0c\xab\x05\xad\xc9\xb3\x2b\x6a\x4b\xfa\x74\x8a\x75\x45\x7d\x27\x97\x1b\x79\x20\x5c\x13\x51\xe0\x81\x47\x1f\x92\xc7\x29\xec\x96\x9b\x7f\x9c\xd2\x4a\xa8\xe5\xe6\x63\x39\x60\x0f\xc9\x4c\x59\xe7\xeb\xb4\xd6\xef\x92\x75\xb0\x17\xe9\x21\x63\x07\xc7\xc2\xdd\x83\x3e\x04\x4c\x53\xa4\x2e\x81\x5b\x55\xa3\xdb\xfd\xfb\x7c\xfa\x34\x53\x25\x1d\xe1\x12\x4d\x1b\x59\x58\xef\x30\x87\x6b\x11\xcf\xc9\xd2\x0b\xb6\x5b\x88\x6e\x7c\x7b\xb7\xb2\xd4\x7b\x6d\x47\x6f\x56\x08\xd1\x14\xcb\x4d\x7a\xab\xdc\xcc\xa0\x45\xd9\x54\x0c\x00\x31\x4b\x9b\xc0\x17\x08\x42\xbf\xe2\xf8\x32\x2d\x7f\x60\x6b\x8f\x67\xcd\x2d\x40\x00\x5f\x5b\x93\xb5\x12\x45\x8e\x9f\x7c\x79\xf6\x80\x6c\x96\x44\x7f\x12\x08\x2b\xa5\x56\x0a\x90\x7b\xfd\x19\x71\x59\x02\xdd\x11\x5a\x8d\xde\x5f\x53\xf3\x08\x9e\xf3\xee\x20\xfe\xa1\xba\x48\x20\x1e\x0e\xf3\x5e\x6f\x8e\xb9\x32\x9b\x04\xe2\xf3\xc9\x27\xde\x91\xf8\x12\x40\xfb\x23\x3e\xda\x93\x4b\x93\xe7\xb6\x9d\xad\xd9\x53\x0f\x32\x4f\x33\x3d\x7a\xae\xf1\x86\x00\x6d\x43\xf0\x35\x4a\xb4\x76\x66\xd4\xa2\xde\xd7\xea\x0b\x18\xe7\xf4\x7b\x74\xdd\x2e\x00\x5d\xe1\x5d\x5d\xce\xf4\x25\x65\xe8\x93\xe1\x64\x77\x1f\xd1\x2c\xf7\x3e\xf4\x0f\x77\x77\xb3\x8e\xc0\x2f\xa0\x9c\x88\x29\x0a\xb2\x99\x23\x55\x92\xd9\x04\x2e\xba\xa6\x8e\xe7\xa8\x0a\xd7\x0a\xc7\x1d\x99\x2d\x28\x45\x6b\xef\x32\x83\x36\x53\x82\x25\x10\x77\xa4\x29\xe1\xa2\x30\xd8\x91\xee\x6c\xfd\x15\x11\x7f\x76\xba\xfd\x8b\xa5\x4e\xb6\xf1\x24\xfe\xd7\xd9\xc6\xff\x77\xb6\x55\xc5\x3c\xa3\xa2\x68\x73\xa9\xd9\x07\xe1\xf8\xc9\xc4\x3f\xdc\xe1\xe1\x6e\x55\x2e\x52\x4d\x99\xf7\x64\x0d\x9e\xd7\xca\x60\xca\x05\x0e\xfe\x19\x00\xbd\xfe\x8c\xd4\x8a\x15\x00\x00"),
 		},
 		"/kube-proxy.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "kube-proxy.yaml",
 			modTime:          time.Time{},
-			uncompressedSize: 1874,
+			uncompressedSize: 1836,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x55\xcd\x8e\xe3\x36\x0c\xbe\xfb\x29\x78\xe8\xa1\x3d\xc8\x46\x6f\x03\x03\x7b\x08\xb2\x59\xa0\x68\x27\x13\xec\xa0\x05\x7a\x2a\x68\x99\xce\x08\x91\x45\x43\xa2\xdd\x18\xe9\xbe\x7b\xa1\xd8\x71\xec\x4d\xb2\x33\xbc\x24\xe0\x3f\xf9\x51\x9f\x95\x52\x09\x36\xe6\x2f\xf2\xc1\xb0\xcb\x01\x9b\x26\x64\xdd\xaf\xc9\xc1\xb8\x32\x87\xcf\x48\x35\xbb\x57\x92\xa4\x26\xc1\x12\x05\xf3\x04\xc0\x62\x41\x36\xc4\x7f\x00\x87\xa7\xa0\xb0\x69\x72\x38\xb4\x05\xa9\xc6\xf3\xb1\x4f\x00\x1c\xd6\x74\x47\x15\x1a\xd4\x17\x7d\xe8\x83\x50\x9d\x84\x86\x74\xcc\x14\xc8\x92\x16\xf6\x43\xd6\x1a\x45\xbf\xfd\x31\x2b\xf3\xa8\x50\xdb\x94\x28\xf4\x2a\x1e\x85\xf6\xfd\xe0\x2b\x7d\x43\x39\x7c\x65\x6b\x8d\xdb\xff\x79\x76\x48\x00\x84\xea\xc6\xa2\xd0\x58\x60\x36\x4e\x14\xbb\xa8\xf5\xa8\xda\x20\xa7\x93\x02\x53\x41\xfa\x7b\x5b\xd0\x2e\x5a\xd6\xec\x2a\xb3\x6f\x3d\x8a\x61\x97\x6e\x8e\xe2\x71\xe8\x1d\xbe\x7d\x4b\x4e\x27\x10\xfe\x1b\x6b\xfb\xa1\x80\xff\xc0\xb8\x92\x9c\xc0\x53\x8c\x9d\x57\x24\x57\x5e\x54\x97\x9d\x45\x69\xbc\x61\x6f\xa4\x5f\x5b\x0c\x61\x7b\x5e\xfb\xb0\x59\xe5\xb8\x24\xa5\xbd\x11\xa3\xd1\x8e\xde\x9a\x9d\xa0\x71\xe4\xa7\x51\xd5\x3d\xac\x06\x31\x35\xee\xa3\xe5\x29\xa4\x7b\xed\x53\xc3\xd9\xd5\x29\xef\x4e\x27\x48\xb7\x5c\xd2\x72\x96\x38\xa2\x77\x24\x14\xc6\x8b\x9a\x8f\x71\x4e\xb8\x6b\xad\xdd\xb1\x35\xba\xcf\xe1\xb7\x6a\xcb\xb2\xf3\x14\xc8\xc9\xe4\xa5\xb9\xae\xd1\x95\x57\x2c\x14\x64\x6d\xf0\x99\x65\x8d\x36\x2b\x8c\xcb\xee\xf4\xaa\x40\x29\x7d\xee\xe4\x53\xd6\xa1\xcf\xac\x29\x66\x6e\xd9\x60\x4a\xe3\xcf\x22\xe4\x8d\x83\xc4\xf9\x15\x77\xe4\xbd\x29\xe9\xd3\x4f\x3f\x6f\x5f\x3e\x6f\xfe\xd9\xae\x9e\x37\xbf\x4c\xae\x81\x74\x7b\x5e\x32\x3b\xa1\xa3\x5c\x5b\x3b\xef\xbf\x33\x96\xf6\x54\xe6\x20\xbe\xa5\xc9\xd4\xb1\x6d\x6b\x7a\xe6\xd6\x49\x98\xcf\x52\x47\xcd\x0e\xe5\x2d\x87\x3b\x9d\xce\x32\x3f\xc4\x65\x99\xc3\xb7\x2e\x3b\x0a\x16\x96\x42\x6a\x59\x1f\x6e\x32\x8c\x46\xf5\x9d\xd1\x13\x96\x2f\xce\xf6\x39\x54\x68\x03\x3d\xc8\x1e\xbb\xab\xb9\x6c\x2d\x85\x9b\xc4\xd6\x14\xea\xd6\x76\xcd\xbb\x58\x07\xb9\x6e\xbe\xb6\xcb\xd9\x4d\xcb\x9e\xd9\x00\x3a\xb4\x2d\x7d\xf1\x5c\xe7\x0b\x35\x40\x65\xc8\x96\x5f\xa9\xfa\x5e\x3f\x5a\x86\xa6\xe3\xf3\x48\xe3\xf5\xc7\xe7\x30\x3a\x46\xa0\xb7\x24\xff\xb2\x3f\x2c\x1a\x0b\xe4\x3b\xa3\x69\xa5\x75\x1c\x7a\x7b\x7f\xe5\x03\x94\x1f\x79\x32\xc3\x9d\x3d\x63\x93\xbf\x0f\xa4\xfa\x11\x3e\xb1\xdf\xf3\x34\xf3\x53\x7b\x17\xf0\x81\xf9\xbe\x18\x4b\x2f\x7e\xed\x69\x20\xbe\x79\xad\x7b\x90\xfd\xa0\xd4\x2d\xfa\xc2\x96\x86\xa7\x3e\x5b\xc7\x81\xfa\x1c\xd6\x23\xd3\xac\xca\x92\x5d\x88\x17\x30\xe5\xe3\x26\xc6\xb0\xcf\x61\x73\x34\x41\xc2\x14\xf8\xc0\x10\xb1\x7b\x5d\x7c\x0f\xa2\x14\x24\x98\x1e\x26\x7e\x89\x7c\xc4\x21\x8e\xe4\xda\xe3\xe8\xf4\x0e\x33\xaf\xaa\xca\x38\x23\xfd\x95\x93\x70\xd4\xe4\x1f\xa0\xe9\x29\xfa\x1e\x47\xcf\x18\xfa\xff\x00\x00\x00\xff\xff\x29\x83\x3f\xf0\x52\x07\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x54\x4f\x6b\xeb\x46\x10\xbf\xeb\x53\x0c\xa6\x87\xf6\xb0\x12\xbd\x3d\x04\xef\x60\xfc\x1c\x08\x6d\x1c\x93\xd0\x42\x4f\x65\xb4\x1a\x39\x8b\x57\xbb\x62\x77\xa4\x5a\xa8\xfe\xee\x8f\xb5\x64\x59\x4e\xe4\xc4\x8c\xc1\xcb\xfc\x9f\xdf\x6f\x34\x42\x88\x08\x2b\xf5\x37\x39\xaf\xac\x49\x01\xab\xca\x27\xcd\xef\xd1\x5e\x99\x3c\x85\x1f\x48\xa5\x35\xaf\xc4\x51\x49\x8c\x39\x32\xa6\x11\x80\xc6\x8c\xb4\x0f\x2f\x80\xfd\x37\x2f\xb0\xaa\x52\xd8\xd7\x19\x89\xca\xd9\x43\x1b\x01\x18\x2c\x69\x46\xe5\x2b\x94\x67\xbd\x6f\x3d\x53\x19\xf9\x8a\x64\xc8\xe4\x49\x93\x64\xeb\xc2\x1b\xa0\x44\x96\x6f\x7f\x4e\xca\xdc\x2a\x54\x57\x39\x32\xbd\xb2\x43\xa6\x5d\xdb\x07\x73\x5b\x51\x0a\x2f\x56\x6b\x65\x76\x7f\x9d\x1c\x22\x00\xa6\xb2\xd2\xc8\x34\x14\x98\x8c\x03\x70\x3d\xd2\xed\x6a\xbd\x74\x9d\x00\x55\x40\xfc\x47\x9d\xd1\x36\x4c\xb7\xb2\xa6\x50\xbb\xda\x21\x2b\x6b\xe2\xf5\x81\x1d\xf6\xbd\xc3\xf1\x18\x75\x1d\xb0\xfd\x07\x4b\x7d\x57\xc0\xff\xa0\x4c\x4e\x86\xe1\x5b\x88\x9d\x56\x24\x93\x9f\x55\x67\xcc\x82\x54\x4e\x59\xa7\xb8\x5d\x69\xf4\x7e\x73\x82\xbd\x47\x56\x18\x9b\x93\x90\x4e\xb1\x92\xa8\x07\x6f\x69\x0d\xa3\x32\xe4\xc6\x51\xc5\x1c\x57\xbd\xa8\x12\x77\x94\xc2\xa2\xeb\x20\x7e\x0c\x6f\x58\x5c\xbc\x16\x70\x3c\x2e\xae\x5d\xb7\xb5\xd6\x5b\xab\x95\x6c\x53\x78\x2c\x36\x96\xb7\x8e\x3c\x19\x1e\xbd\xa4\x2d\x4b\x34\xf9\xb9\x34\x80\x80\xa4\xf6\x2e\xd1\x56\xa2\x4e\x32\x65\x92\x99\x2e\x04\x08\x21\x4f\x78\x7d\x4f\x1a\x74\x89\x56\xd9\xc4\x2d\xe9\x4d\x71\xf8\xbb\x0a\x79\xb3\x9e\xc3\x64\xc2\x36\xe4\x9c\xca\xe9\xfb\x2f\xbf\x6e\x9e\x7f\xac\xff\xdd\x2c\x9f\xd6\xbf\x8d\xae\x9e\x64\x7d\x82\xcf\x1a\xa6\x03\x5f\x5a\x3b\x21\xdb\x28\x4d\x3b\xca\x53\x60\x57\xd3\x68\x6a\xac\xae\x4b\x7a\xb2\xb5\xe1\xc9\xc6\x08\x28\x83\x66\x8b\xfc\x96\xc2\x4c\xa7\xa3\xe3\xec\xd7\x31\x97\xc3\xd5\x26\x39\x30\x66\x9a\x7c\xac\xad\xdc\x7f\xc8\x30\x18\xc5\x3b\xa3\x23\xcc\x9f\x8d\x6e\x53\x28\x50\x7b\xba\x91\x3d\xe0\x58\xda\xbc\xd6\xe4\x27\xb1\x7d\x6b\x5a\x65\xe2\xa3\xed\x92\xf7\x0a\x0e\x32\xcd\x14\xb6\xf3\x42\x8d\x60\x4f\x6c\x00\x0d\xea\x9a\x1e\x9c\x2d\xa7\x21\x41\x0a\x45\x3a\x7f\xa1\xe2\xbd\x7e\xb0\xf4\xb0\x86\xc5\x8f\xc3\x5e\x87\x45\x1f\x1c\x03\xd1\x1b\xe2\xff\xac\xdb\x5f\x35\xe6\xc9\x35\x4a\xd2\x52\xca\x40\xcb\x66\x1e\xf2\x9e\xca\x7b\x3e\x86\xb0\x60\x6a\xf7\x84\x55\xfa\x35\x91\xe2\x33\x7e\x42\xbf\xa7\x69\x46\x0d\x40\xf5\x25\xe1\xfd\x4d\x7b\x50\x9a\x9e\xdd\xca\x51\x7f\xd2\xa6\xb5\xe6\x28\xfb\xa4\xd4\x47\xf6\xd9\x6a\xea\x0f\xd2\x04\x8e\x3d\xb5\x29\xac\x86\x1b\xb2\xcc\x73\x6b\x7c\xd8\x80\xc1\x0e\x60\xab\x10\x63\x5d\x0a\xeb\x83\xf2\x7c\xce\x25\x6e\x19\x02\x77\xaf\x57\x97\x3e\xfc\x32\x62\x8c\x03\x8a\xce\x10\x93\x8f\x95\x4d\xac\x0f\x23\x99\xfa\x10\xdd\x75\x73\x97\x45\xa1\x8c\xe2\xf6\x72\x34\x71\xd0\xa4\x77\x1c\xe0\x31\x7a\xee\xfa\x76\x9d\x00\x32\x39\x1c\x8f\xd1\xcf\x01\x00\x77\x8b\xc7\xd3\x2c\x07\x00\x00"),
+		},
+		"/metrics-server.yaml": &vfsgen۰CompressedFileInfo{
+			name:             "metrics-server.yaml",
+			modTime:          time.Time{},
+			uncompressedSize: 3538,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x56\x4f\x4f\xe4\xc6\x13\xbd\xfb\x53\x94\x7c\xfa\xfd\xa4\xed\x01\x04\x52\x56\x96\x38\x10\x56\x49\x90\x80\x8c\x80\x70\x89\xf6\xd0\xd3\xae\x31\x1d\xda\xdd\x9d\xea\xf2\x2c\x13\xc4\x77\x8f\xda\xe3\x36\xb6\x99\x61\xb5\x40\x76\x91\xc6\x76\xb9\xfe\xbd\x7a\xfd\x5c\x42\x88\x4c\x7a\x7d\x8b\x14\xb4\xb3\x05\xd0\x42\xaa\x99\x6c\xf8\xce\x91\xfe\x47\xb2\x76\x76\x76\xff\x39\xcc\xb4\xdb\x5b\x1d\x64\xf7\xda\x96\x05\x9c\x9a\x26\x30\xd2\x95\x33\x98\xd5\xc8\xb2\x94\x2c\x8b\x0c\xc0\xca\x1a\x0b\x08\xeb\xc0\x58\x17\xb2\xaa\x08\x2b\xc9\x58\x8a\x1a\x99\xb4\x0a\x82\x50\x96\x48\x19\x80\x91\x0b\x34\x21\xba\xc0\x2b\xf9\xfa\x08\x82\x9d\x58\x69\xfc\x56\x40\xce\xd4\x60\xfe\x23\x7e\x58\x6a\x7e\x8b\x9f\x2c\x6b\x6d\x7b\x47\x6a\x0c\x86\x22\x13\x20\xbd\xfe\x95\x5c\xe3\x43\x01\x7f\xe6\x5d\x5f\x9d\x7f\xfe\x35\x03\x20\x0c\xae\x21\x85\xad\xdd\xbb\x32\xe4\x9f\x20\xb7\xae\xc4\xd0\x9a\x57\x48\x8b\xd6\x54\x21\x47\x8b\xd1\xa1\xfd\xfd\x26\x59\xdd\xe5\x5f\xb3\xf7\x0d\xe3\x67\x6d\x4b\x6d\xab\x2d\x33\x49\x13\x08\x48\x2b\xa4\x22\x8d\xa8\xe1\x3b\x51\xa2\x89\x20\x3b\xca\xc8\x19\xbc\xc2\x65\x1c\x4b\x6a\xf3\x95\x0a\x32\x80\x97\x6c\x98\x72\x60\x9c\x20\x34\x8b\xbf\x50\x71\x0b\xe4\xc6\xf7\x1a\x69\xa5\x15\x9e\x28\xe5\x1a\xcb\x3b\xca\xed\x1e\x07\x2f\x15\x16\x70\xdf\x2c\x50\x6c\xe2\xbf\x05\xae\x1f\xc1\x49\xc4\x50\xcf\xac\xdd\x51\xc4\x1b\x61\x1b\xe1\x85\x0f\x8c\x36\x8e\x5c\x48\xaf\x07\xc9\xd1\xb2\x56\xad\x7b\x2a\xe3\x3f\xc7\x50\x7a\x4d\x58\xe9\xc0\xb4\x1d\xc0\x93\xf9\x59\x97\x71\x0b\x7e\xab\x83\x05\xb2\x3c\x98\x75\xc9\x53\xcf\xc1\xa3\x8a\x20\xc7\xce\xb4\xc2\x78\xb9\xb3\xcc\x9d\x85\x02\x54\x1b\x4a\x4e\x82\x03\xac\x52\xed\x5d\xfa\x0c\x40\xdb\x80\xaa\x21\xbc\xbe\xd7\xfe\xe6\xfc\xfa\x16\x49\x2f\xd7\x05\x44\xf9\x48\x81\xe6\xa4\x1d\x69\x5e\x5f\x68\xab\xeb\xa6\x2e\xe0\x60\x7f\xff\x39\x58\xb2\x6e\x1e\x4f\x41\xea\xd1\x98\x80\xff\x3d\x46\x65\x3b\xbb\x9b\x66\x90\xde\x87\x67\xd0\xbf\xa0\x37\x6e\x5d\xe3\xbb\x52\x8c\x75\xf7\xfe\x73\x10\xd2\xfb\x17\xee\x69\x56\x84\xde\x68\x25\x43\x01\x8f\x8f\xe0\x08\xfe\xa7\x6d\x89\x0f\x30\xbb\x95\xa6\xc1\x00\x79\xb2\xe7\xff\x87\xfc\x20\x87\xa7\xa7\x76\xbe\x06\x15\x3b\x8a\xfe\x00\x75\x14\xb5\xf3\x41\xca\xdd\x49\x01\x18\x6b\x6f\x24\x77\xdc\x18\x36\xf9\x2a\x57\xc6\x3d\xbd\x9e\x02\x20\xf5\x06\xd0\x73\xb1\x9b\xdc\xe5\xee\x04\xbe\x63\xc2\xa9\x91\x21\x5c\x0e\xe4\x4d\xa8\x8d\xf4\x0a\x45\x3a\x1e\x53\xd3\x39\xac\x9c\x69\x6a\xec\x4b\x12\x5d\xf5\x5c\x7b\x51\xea\x14\x15\x00\x6b\xcf\xeb\x2f\x9a\x0a\x78\x7c\xea\x1e\x2a\x67\x59\x6a\x8b\xf4\xc2\x79\x6b\x65\x00\xba\x96\x15\xee\x9a\x50\x6b\x8c\xe3\x89\x07\xa5\x52\x14\x3f\x1b\xe3\x38\x93\xdb\x62\xb5\x3f\x3b\x9c\xfd\xd4\x0d\x73\x90\x61\xde\x18\x33\x77\x46\xab\x75\x01\x67\xcb\x4b\xc7\x73\xc2\x10\xc9\x98\xde\x92\x54\xf5\x25\xc7\x3f\x01\x42\x28\x24\x8e\x0d\x1f\xef\x71\xed\x27\xb6\xcd\xe1\x14\xde\x11\x1f\x1f\x1d\x1d\x1d\x4e\xcc\xf1\x60\x18\x64\xe1\x09\x97\x48\x84\xa5\x90\x65\x49\x18\x82\xe0\xb5\xc7\x70\x7c\x66\x19\xc9\x4a\x73\x36\xff\xf4\x9b\x0b\x1c\xf1\x1d\x44\x78\x7c\x14\xa0\x97\x80\x7f\xbf\x40\xa4\x8b\x7b\xd6\x89\xc3\xcd\xf9\x75\x84\x27\x8a\xc2\xa8\xe7\x71\x11\x49\x4a\x04\x9b\x30\xc9\x82\xb6\x1c\xfa\xc5\x76\x06\x30\xf4\xb3\x93\xda\xb6\xad\xf6\x96\xc1\xa4\xe7\x8e\xb8\x80\x09\x06\x9e\x1c\x3b\xe5\x4c\x01\x37\xa7\xf3\xfe\x79\x5b\x46\x24\xa2\xb3\x8c\x0f\x3c\xc4\x3b\x7e\x1c\x7e\xb7\x66\x7d\xe5\x1c\xff\xa2\x0d\x76\xdf\xdf\xa4\x77\xe9\x1f\x35\xf6\x24\x5c\x3a\x1b\x5f\xdb\x6e\xfc\x23\x20\xb5\x92\xb7\xdf\x5b\x36\x74\xbe\x88\xfa\xb6\xa5\xb9\x29\xab\x01\xea\xf8\xe6\x5c\xf2\x5d\x01\x83\xc9\xc7\x1d\xe8\x7a\x24\x0f\xf1\x7f\xc4\x98\x2c\x32\x46\x25\xdf\x73\xa1\x00\xa3\x6d\xf3\xf0\x5d\xbd\xfd\x38\x15\x1c\x15\x10\x5d\x0a\xc8\x2f\x46\xa1\xf2\x2d\x2f\xa6\x93\x9f\x3e\x68\x69\x4f\x4c\x02\x33\x16\xc2\xdd\x92\xd4\x33\x46\x80\xef\x88\x70\x98\x6d\x67\x00\x4b\xaa\x90\x37\x74\x79\x66\xd4\x1b\x36\xa0\xe1\xbe\xf6\x12\xc6\x8e\x39\x93\x4a\xb7\xec\xbf\x6d\xd1\x79\x3e\xda\x79\xbb\x46\xca\x78\x4e\x44\x3b\xf2\xc1\xd5\x5e\x60\xc9\xdd\x7d\x1a\xcd\xe6\x56\x39\xbb\xd4\x55\x2d\x7d\xe8\x57\xe4\xf6\x79\x85\xdc\xfe\xc6\x2d\xb9\xbd\x68\xd7\xe4\x77\xf6\xbc\x7b\xf9\xdb\xd1\xfa\x87\x6f\xc5\x93\x04\x1f\xbe\xd1\xfd\x3b\x00\xb1\x2f\xc1\x0d\xd2\x0d\x00\x00"),
 		},
 		"/psp-privileged.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "psp-privileged.yaml",
 			modTime:          time.Time{},
 			uncompressedSize: 1460,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x53\xc1\x6a\x1b\x31\x10\xbd\xef\x57\x0c\xf4\xb0\x50\xd8\x4d\x43\x49\x29\xba\xa5\x4e\x29\x86\x10\x16\x97\xf6\x12\x7a\x98\xd5\x8e\x6d\xd5\x5a\x49\x68\xa4\x4d\xdc\xaf\x2f\xd2\xae\x5d\x1b\xd7\xad\x03\xed\x51\x33\x6f\xde\x9b\x79\x33\x42\xa7\xbe\x92\x67\x65\x8d\x00\x67\xb5\x92\xdb\xab\xe1\xba\xa5\x80\xd7\xc5\x46\x99\x4e\x40\x63\xbb\xcf\x24\xa3\x57\x61\xdb\xe4\x7c\xd1\x53\xc0\x0e\x03\x8a\x02\xc0\x60\x4f\x02\x9c\x57\x83\xd2\xb4\xa2\xae\x00\x40\x63\x6c\xc0\xa0\xac\xe1\x84\x00\x60\x92\xd2\xf6\xae\xe6\x89\xa6\x46\xed\xd6\x58\x6f\x62\x4b\xde\x50\x20\xae\x95\xbd\x42\xad\xed\x13\x75\x8d\xb7\x4b\xa5\xe9\x01\x7b\x62\x01\xe5\xeb\xb2\x60\x47\x32\xf1\xfc\xd2\x10\x10\x7c\xa4\xa4\x94\x6a\x9a\x5d\xfc\x23\x4b\xd4\x59\xf7\x18\x40\xdd\x0c\x1d\xb6\x4a\xab\xa0\x28\xb7\x54\x65\x62\x80\xc1\xea\xd8\x1f\x87\xd6\x96\xc3\x03\x85\x27\xeb\x37\x7b\x96\x14\x6b\xac\x0f\x13\xb0\x57\x46\xc0\x9b\x3c\x59\x8f\xcf\x02\xde\xdd\xdc\xbc\xbd\x99\x60\xf3\x66\x76\x5c\x36\xbf\xdb\xbf\x7d\x34\xb7\xfc\x85\xc9\x8f\xae\xf8\xa8\x49\x40\xb9\x48\xd1\x5b\xb3\x4d\xe2\x4c\xf7\xca\xc4\xe7\xf3\xf9\xe8\x9c\xa6\x9e\x4c\x40\xfd\xc9\xdb\xe8\xf8\x2c\x74\xc9\x19\x70\x26\x5f\x55\xd5\xb4\xdd\x99\x8e\x1c\xc8\x2f\xac\xa6\xe2\xf0\x14\x7c\x8b\xb2\xc6\x18\xd6\xd6\xab\x1f\xd9\xd4\x7a\xf3\x3e\x2f\x6a\xb8\xfe\xdd\x01\xb0\x13\x07\x47\x90\x04\x59\x14\x15\xa0\x53\x53\xa3\xf0\x58\x8e\xe7\x55\x7e\x4b\x5e\x10\xdb\xe8\x25\x4d\xf1\x6e\x77\x1a\x19\xa2\x88\x33\x68\x20\xdf\xb2\x48\x03\xc0\x63\x19\x99\x8e\x2a\xc7\x0b\xc9\x0b\x39\x10\x4e\x83\x5d\x3c\xc6\x89\x03\x1f\x94\xe9\x94\x59\x5d\x30\x9f\xd5\xb4\xa0\x65\xca\xef\x26\xfc\x83\x56\x01\x70\x6a\xf6\x19\x66\x8e\xed\x77\x92\x21\x9b\x37\x16\x65\xf6\x8b\x85\x46\x52\xde\x72\xa0\x5e\x30\xf9\x41\x49\x42\x29\x6d\x34\x81\x45\xfa\x71\xd5\x98\x3b\xa1\xff\x7b\xa1\xb1\x1d\x55\x9a\x90\xe9\xc2\x6e\x5e\x2e\xe1\x62\xab\x95\xfc\x1f\xf4\x1d\x2d\x31\xea\xf0\x2f\xa8\x93\x0f\xfc\x42\xa2\xf4\xed\x2f\xde\xe1\x2b\xb8\xa7\x15\xca\x2d\x24\x25\x98\xdf\xed\x1b\x48\x1e\x69\x0a\xc5\xcf\x00\x00\x00\xff\xff\x01\x43\x9a\x99\xb4\x05\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x53\x41\x8b\xdb\x3c\x10\xbd\xfb\x57\x0c\x7c\x07\xc3\x07\xf6\x36\x94\x94\xa2\xdb\x36\x5b\x4a\x60\x59\x4c\xca\xf6\xb2\xf4\x30\x96\x27\x89\x1a\x59\x12\x1a\xc9\xbb\xe9\xaf\x2f\x52\x9c\x34\x21\x4d\x9b\x85\xf6\xe8\x99\x37\xef\xcd\x3c\x3d\xa3\x53\x5f\xc8\xb3\xb2\x46\x80\xb3\x5a\xc9\xed\xcd\x30\x69\x29\xe0\xa4\xd8\x28\xd3\x09\x68\x6c\xf7\x99\x64\xf4\x2a\x6c\x9b\xdc\x2f\x7a\x0a\xd8\x61\x40\x51\x00\x18\xec\x49\x80\xf3\x6a\x50\x9a\x56\xd4\x15\x00\x68\x8c\x0d\x18\x94\x35\x9c\x10\x00\x4c\x52\xda\xde\xd5\x3c\xd2\xd4\xa8\xdd\x1a\xeb\x4d\x6c\xc9\x1b\x0a\xc4\xb5\xb2\x37\xa8\xb5\x7d\xa6\xae\xf1\x76\xa9\x34\x3d\x60\x4f\x2c\xa0\xfc\xbf\x2c\xd8\x91\x4c\x3c\x3f\x35\x04\x04\x1f\x29\x29\xa5\x99\x66\x5f\xff\xc8\x12\x75\xd6\x3d\x05\x50\x37\x43\x87\xad\xd2\x2a\x28\xca\x2b\x55\x99\x18\x60\xb0\x3a\xf6\xa7\xa5\xb5\xe5\xf0\x40\xe1\xd9\xfa\xcd\x81\x25\xd5\x1a\xeb\xc3\x08\xec\x95\x11\xf0\x26\x5f\xd6\xe3\x8b\x80\x77\xd3\xe9\xdb\xe9\x08\x9b\x37\xb3\xd3\xb1\xf9\xdd\xe1\xdb\x47\x73\xcb\x8f\x4c\x3e\xd1\x00\xf8\xa8\x49\x40\xb9\x48\xd5\x5b\xb3\x2d\x8b\x64\xd4\xbd\x32\xf1\xe5\x72\x3f\x3a\xa7\xa9\x27\x13\x50\x7f\xf2\x36\x3a\xbe\x08\x5d\x72\x06\x5c\xe8\x57\x55\x35\xbe\xee\x4c\x47\x0e\xe4\x17\x56\x53\x71\x1c\x05\xdf\xa2\xac\x31\x86\xb5\xf5\xea\x7b\x36\xb5\xde\xbc\xcf\x0f\x35\x4c\x7e\x15\x00\x76\xe2\x28\x04\x49\x90\x45\x51\x01\x3a\x35\x2e\x0a\x4f\xe5\x2e\x5e\xe5\xd7\xe4\x05\xb1\x8d\x5e\xd2\x58\xef\xf6\xd1\xc8\x10\x45\x9c\x41\x03\xf9\x96\x45\x3a\x00\x9e\xca\xc8\x74\x32\xb9\x4b\x48\x7e\x90\x23\xe1\x74\xd8\xd5\x67\x9c\x39\xf0\x41\x99\x4e\x99\xd5\x15\xf7\x59\x4d\x0b\x5a\x26\x77\xf7\x17\xfe\x46\xab\x00\x38\x37\xfb\x02\x33\xc7\xf6\x1b\xc9\x14\xb5\x6a\x1c\xca\xfe\x5d\x2d\xb4\x23\xe5\x2d\x07\xea\x05\x93\x1f\x94\x24\x94\xd2\x46\x13\x58\xa4\x3f\xae\xda\xf5\xce\xe8\xff\x3c\x68\x6c\x47\x95\x26\x64\xba\x72\x9b\xd7\x4b\xb8\xd8\x6a\x25\xff\x05\x7d\x47\x4b\x8c\x3a\xfc\x0d\xea\xe4\x03\xbf\x92\xe8\x91\xc9\x5f\x39\x02\xf0\x1f\xdc\xd3\x0a\xe5\x16\x92\x12\xcc\xef\x0e\x0b\x6c\x62\x4b\x9a\x42\xf1\x63\x00\x01\x43\x9a\x99\xb4\x05\x00\x00"),
 		},
 		"/psp-restricted.yaml": &vfsgen۰CompressedFileInfo{
 			name:             "psp-restricted.yaml",
 			modTime:          time.Time{},
 			uncompressedSize: 2195,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x55\xcd\x6e\x23\x37\x13\xbc\xcf\x53\x34\xe0\x83\x0e\x9f\x25\xc3\xf8\xe0\x20\x98\x9b\x62\xef\x06\x06\xbc\x5e\xc1\x9b\xec\x65\x91\x43\x8b\x6c\x69\x18\x71\x48\xa6\xbb\x29\xad\xf2\xf4\x01\x39\xa3\x1f\xef\x1f\x74\x49\x8e\xd3\x22\xab\xaa\xbb\x9a\x25\x4c\xee\x23\xb1\xb8\x18\x5a\x48\xd1\x3b\xb3\xbf\xd9\xde\x2e\x49\xf1\xb6\xd9\xb8\x60\x5b\x58\x44\xfb\x81\x4c\x66\xa7\xfb\x45\xfd\xbd\xe9\x49\xd1\xa2\x62\xdb\x00\x04\xec\xa9\x05\x4b\x2b\xcc\x5e\xa7\xc6\x67\x51\xe2\x29\x93\x28\x3b\xa3\x64\x1b\x00\x0c\x21\x2a\xaa\x8b\x41\xca\x0d\x00\x21\x63\x62\x9f\x66\x32\xc2\xce\xd0\xa7\x0e\x67\x9b\xbc\x24\x0e\xa4\x24\x33\x17\x6f\xd0\xfb\xb8\x23\xbb\xe0\xb8\x72\x9e\x9e\xb1\x27\x69\x61\x62\xa3\xd9\x10\xdf\x8c\x7c\xd7\x9c\x83\xba\x9e\x0e\xdf\x93\x0a\x8f\x29\x21\xf7\x91\x4f\xf8\xa5\x9d\x8b\xe0\xbf\x89\x77\x91\xdc\xf1\xc6\x19\x5e\x0b\xdf\xc1\xbb\x48\xdf\x85\x78\x92\xc8\x94\xa1\x26\x76\x5b\xe7\x69\x4d\xb6\x85\x15\x7a\xa1\x06\xe0\x0a\x5e\xe8\xaf\xec\x98\x2c\x68\x84\xc4\xb4\xa5\xa0\x40\x62\xd0\x0f\x6e\x94\x32\xc7\xa8\xb3\x62\x52\x99\xc7\xe2\x80\xf2\xe6\x78\xe8\x1c\xee\xb7\xce\x09\x38\x01\x26\x9b\x83\xc5\xa0\xb0\x73\xda\x41\x88\x61\x5a\x60\xe0\x7f\x60\x9d\x54\xa0\x93\x9e\x33\xbe\xeb\x0a\xb2\xcc\x0a\x3b\x02\x83\x01\x12\xc7\xad\xb3\x04\x4e\x61\x15\xb9\x2c\x11\x05\x21\x70\x01\x2c\x25\xed\x8a\x2c\x1e\x3b\x78\xe0\x98\xee\x31\xe1\xd2\x79\xa7\x8e\xc6\x45\x9a\xc2\xfc\xe9\xa9\xa2\xce\x2b\xab\x89\x4c\xb0\x8d\x3e\xf7\x04\xba\x4f\x24\x05\x62\xf8\x3e\xde\x98\x98\x18\x56\x6e\xfd\x0e\xd3\xe4\x50\xa1\x3e\xe9\xfe\xc1\xf1\xb1\x90\x38\xfe\x49\x65\x7b\x8f\x15\x21\xc3\xa4\xc7\x4f\x1b\x77\x61\x87\x6c\xe7\x8b\xc7\xa1\x76\x05\x73\x91\x4a\xdb\xa1\x42\x2a\x0f\x4a\x94\x82\x7e\x1c\xc8\x41\x48\x21\x27\x58\xee\x41\x3b\x82\xf1\x99\x00\xda\xde\x05\x40\x26\x10\x5c\x51\xb1\x23\x0b\xcd\x8e\x2a\xbe\x40\xb9\xf7\xe8\xfa\x42\xd7\x45\xd1\x67\xd2\x5d\xe4\xcd\xc9\x9e\x52\x7c\x5c\xdc\xbf\x2e\x2c\x1e\x1f\x4e\x05\xce\x61\x2e\xbf\x0b\x71\x3b\x4a\x1e\xd7\x63\x90\x14\x83\xa2\x0b\xc4\x75\x29\x72\xa8\xd6\xc6\xac\x75\x41\x4e\x76\xca\xa0\x8e\xb3\xa7\x16\x26\xef\xb2\xe8\x4b\x41\x7d\x8e\xe1\x25\xc6\x3a\x1f\xa1\x27\x17\xf2\xe7\x03\x47\xdd\x99\x21\x57\x00\xeb\x88\xa4\xf2\x85\x68\x49\x6a\xeb\x59\x5c\x58\xc3\x3c\xa5\x79\x79\x14\xc0\xa8\x5d\x51\xd1\x61\x80\x0f\x6f\x2a\xd6\x2b\xce\xca\x37\x0f\xfb\xca\x95\x53\xf2\xd4\x53\x50\xf4\xbf\x72\xcc\x69\x74\xf9\x4b\x79\x83\x47\x8c\x61\x7d\xd8\x83\x22\xed\x6d\xe4\xa5\xb3\x80\xd6\x16\x01\x45\x54\xed\x75\x5d\x80\x66\xe3\xa9\x29\xf4\x2e\xb4\x70\x3b\x7e\x02\xf4\xf8\xb9\x85\x9f\xee\xee\xfe\x7f\xd7\x00\xac\xa4\xb2\xfe\xb7\xa4\x4c\x68\xdf\x07\xbf\x2f\x03\x7f\xeb\x3c\xc9\x5e\x94\xfa\x83\xcd\xd3\xe9\x74\xcc\xed\xfb\x61\xc9\x5e\xa2\xa7\xe6\x3c\xe4\x79\x89\x66\x86\x59\xbb\xc8\xee\xef\xfa\x30\x67\x9b\x9f\x6b\xe6\x6c\x6f\xbf\x11\xed\x49\x52\x7b\x16\xe7\xa5\x4b\x69\x9b\x29\x60\x72\xe3\xc8\xe1\xd3\x64\x30\x78\xf2\x47\x95\x27\x31\xb3\xa1\xb1\x6e\x0f\x21\x57\x8f\x38\x92\x7a\x68\x4b\xbc\x94\xb6\xf6\xf6\x69\x92\x85\x5e\xdd\x1c\xc2\xb8\x29\x73\xf8\xc1\xff\x4a\x69\xf4\xe2\xb6\xbe\x9a\xc8\x2f\x2e\x14\x03\x2e\xe8\x37\x7a\x7a\xa1\x55\xf9\xfd\xd0\xf1\x0f\xb8\x1a\x80\xaf\x87\xff\x1d\x64\xc9\xcb\x12\x32\xd2\x36\x57\x30\x1f\xb1\xa8\xe4\x30\x08\xf1\xd6\x19\x02\x34\x26\xe6\xa0\x52\x12\x11\x2b\x88\x24\x34\x54\xa6\x3f\xb0\x54\x39\x17\x2b\x1b\x54\x8c\xeb\x32\x72\x1c\x28\x9a\x2b\x78\xcf\x50\xf2\x60\x8b\x9e\x82\xfa\xfd\x75\x95\x52\xa0\x28\xa8\x33\xa8\x64\x4b\x3a\xf1\xbf\xa2\xe6\x15\x4d\xf3\x4f\x00\x00\x00\xff\xff\x55\x10\x47\xf6\x93\x08\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x55\xc1\x6e\x1b\x37\x10\xbd\xef\x57\x0c\xe0\x83\x0e\xf5\xca\x30\x0a\x17\xc5\xde\x54\x3b\x29\x0c\x38\x8e\xe0\xb4\xb9\x04\x3d\x8c\xc8\x91\x97\x15\x97\x64\x67\x86\x52\xd4\xaf\x2f\x48\xed\xca\x72\x93\x06\x42\x81\xf6\xb8\x23\xf2\xbd\x37\xf3\x86\x4f\x98\xdc\x47\x62\x71\x31\x74\x90\xa2\x77\x66\x7f\xb5\xbd\x5e\x91\xe2\x75\xb3\x71\xc1\x76\xb0\x8c\xf6\x03\x99\xcc\x4e\xf7\xcb\xfa\x7b\x33\x90\xa2\x45\xc5\xae\x01\x08\x38\x50\x07\x96\xd6\x98\xbd\xb6\xc6\x67\x51\xe2\x96\x49\x94\x9d\x51\xb2\x0d\x00\x86\x10\x15\xd5\xc5\x20\xe5\x06\x80\x90\x31\x71\x48\x73\x19\x61\xe7\xe8\x53\x8f\xf3\x4d\x5e\x11\x07\x52\x92\xb9\x8b\x57\xe8\x7d\xdc\x91\x5d\x72\x5c\x3b\x4f\x8f\x38\x90\x74\x30\xb3\xd1\x6c\x88\xaf\x46\xbe\x4b\xce\x41\xdd\x40\xd3\xf7\xac\xc2\x63\x4a\xc8\x43\xe4\x17\xfc\xd2\xce\x59\xf0\x5f\xc5\x3b\x4b\xee\x78\xe3\x04\xaf\x03\x98\xfd\x7b\x7d\x67\xe2\x49\x22\x53\x86\x9a\xd8\x6d\x9d\xa7\x67\xb2\x1d\xac\xd1\x0b\x35\x00\x17\xf0\x44\x7f\x64\xc7\x64\x41\x23\x24\xa6\x2d\x05\x05\x12\x83\xfe\xe0\x46\x29\x73\x8c\x3a\x2f\x26\x95\x79\x2c\x27\x94\x37\xc7\x43\xa7\x70\xbf\xf4\x4e\xc0\x09\x30\xd9\x1c\x2c\x06\x85\x9d\xd3\x1e\x42\x0c\x6d\x81\x81\xef\xc0\x3a\xa9\xbe\xbd\xe8\x39\xe1\xbb\xac\x9a\x56\x59\x61\x47\x60\x30\x40\xe2\xb8\x75\x96\xc0\x29\xac\x23\x97\x25\xa2\x20\x04\x2e\x80\xa5\xa4\x7d\x91\xc5\x63\x07\x77\x1c\xd3\x2d\x26\x5c\x39\xef\xd4\xd1\xb8\x48\x2d\x2c\x1e\x1e\x2a\xea\xa2\xb2\x9a\xc8\x04\xdb\xe8\xf3\x40\xa0\xfb\x44\x52\x20\x0e\xdf\xc7\x1b\x33\x13\xc3\xda\x3d\xbf\xc3\x34\x9b\x2a\x34\x24\xdd\xdf\x39\x3e\x16\x12\xc7\xdf\xa9\x6c\xef\xb1\x22\x64\x98\x46\xff\xda\xb2\x86\xbb\xb0\x43\xb6\x8b\xe5\xfd\xa1\x76\x01\x0b\x91\x4a\xdb\xa3\x42\x2a\x0f\x4a\x94\x82\x7e\x3c\x90\x83\x90\x42\x4e\xb0\xda\x83\xf6\x04\xe3\x33\x01\xb4\x83\x0b\x80\x4c\x20\xb8\xa6\x62\x47\x16\x9a\x4f\x24\x7f\x47\xb9\xf5\xe8\x86\x42\xd7\x47\xd1\x47\xd2\x5d\xe4\xcd\x8b\x3d\xa5\x78\xbf\xbc\x7d\x5d\x58\xde\xdf\xbd\x14\x38\x87\x85\xfc\x2a\xc4\xdd\x28\x79\x5c\x8f\x83\xa4\x18\x14\x5d\x20\x2e\x2a\x38\x87\x6a\x6d\xcc\x0a\xd5\xd9\xa3\x9d\x75\xa2\x05\xca\x53\x07\xb3\x77\x59\xf4\xa9\xa0\x3e\xc6\xf0\x14\x63\x9d\x8f\xd0\x83\x0b\xf9\xf3\xc4\x51\x77\xe6\x90\x2b\x80\x75\x44\x52\xf9\x42\xb4\x24\xb5\xf5\x2c\x2e\x3c\xc3\x22\xa5\x45\x79\xb4\xc0\xa8\x7d\x51\xd1\x63\x80\x0f\x6f\x2a\xd6\x2b\xce\xca\xb7\x08\xfb\xca\x95\x53\xf2\x34\x50\x50\xf4\x3f\x73\xcc\x49\xba\xd3\xa3\x47\x79\xe5\x2c\x00\x63\x78\x9e\xf6\xa0\x48\x7b\x1b\x79\xe5\x2c\xa0\xb5\x45\x40\x11\x55\x7b\x7d\x2e\x40\xf3\xf1\x54\x0b\x83\x0b\x1d\x5c\x8f\x9f\x00\x03\x7e\xee\xe0\x87\x9b\x9b\xef\x6f\x1a\x80\xb5\x54\xd6\xff\x97\x94\x09\xed\xfb\xe0\xf7\x65\xe0\x6f\x9d\x27\xd9\x8b\xd2\x30\xd9\xdc\xb6\xed\x98\xdb\xb7\x87\x25\x7b\x8a\x9e\x9a\xd3\x90\xe7\x15\x9a\x39\x66\xed\x23\xbb\x3f\xeb\xc3\x9c\x6f\x7e\xac\x19\xb6\xbd\xfe\x4a\xb4\x27\x49\xdd\x49\x9c\x97\x2e\xa5\x6b\x5a\xc0\xe4\xc6\x91\xc3\xa7\xd9\xc1\xe0\xd9\x6f\xf5\xc5\x4a\xcc\x6c\x68\xac\xdb\x29\xe4\xea\x11\x47\x52\x0f\x6d\x89\x57\xd2\xd5\xde\x3e\xcd\xb2\xd0\xab\x9b\x25\xec\xaa\x4d\xed\xb7\xfe\x57\x4a\xa3\x67\xb7\xf5\xc5\x44\x7e\x72\xa1\xb8\x7e\x46\xbf\xd1\xd3\x13\xad\x8b\x9c\xa9\xe3\x6f\x70\x35\x00\x5f\x0e\xff\x1f\x90\x25\xaf\x4a\xc8\x48\xd7\x5c\xc0\x62\xc4\xa2\x92\xc3\x20\xc4\x5b\x67\x08\xd0\x98\x98\x83\x4a\x49\x44\xac\x20\x92\xd0\x50\xd7\xb4\x23\x4b\x35\xe0\x6c\x65\x05\xa0\x83\x71\x5d\x46\x8e\x89\xa2\xb9\x80\xf7\x0c\x25\x0f\xb6\xe8\x29\xa8\xdf\x5f\x56\x29\x05\x8a\x82\x3a\x83\x4a\x16\xb2\x10\xff\x27\x6a\x5e\xd1\x34\x7f\x0d\x00\x55\x10\x47\xf6\x93\x08\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/audit-policy.yaml"].(os.FileInfo),
 		fs["/coredns.yaml"].(os.FileInfo),
 		fs["/kube-proxy.yaml"].(os.FileInfo),
+		fs["/metrics-server.yaml"].(os.FileInfo),
 		fs["/psp-privileged.yaml"].(os.FileInfo),
 		fs["/psp-restricted.yaml"].(os.FileInfo),
 	}
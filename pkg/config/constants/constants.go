@@ -26,6 +26,37 @@ const (
 	CritHealthCheckProxyImage = "docker.io/criticalstack/healthcheck-proxy"
 )
 
+// Component names used to reference container images.
+const (
+	KubeAPIServer         = "kube-apiserver"
+	KubeControllerManager = "kube-controller-manager"
+	KubeScheduler         = "kube-scheduler"
+	KubeProxy             = "kube-proxy"
+	CoreDNS               = "coredns"
+	Pause                 = "pause"
+	CritBootstrapServer   = "bootstrap-server"
+	HealthcheckProxy      = "healthcheck-proxy"
+)
+
+const (
+	PodSecurityLevelPrivileged = "privileged"
+	PodSecurityLevelBaseline   = "baseline"
+	PodSecurityLevelRestricted = "restricted"
+)
+
+const (
+	NodeAddressPolicyDefaultRoute   = "DefaultRoute"
+	NodeAddressPolicyInterface      = "Interface"
+	NodeAddressPolicyCIDR           = "CIDR"
+	NodeAddressPolicyFirstInterface = "FirstInterface"
+)
+
+// Labels and taints used to mark control plane nodes.
+const (
+	LabelNodeRoleMaster       = "node-role.kubernetes.io/master"
+	LabelNodeRoleControlPlane = "node-role.kubernetes.io/control-plane"
+)
+
 type ContainerRuntime string
 
 const (
@@ -34,6 +65,20 @@ const (
 	CRIO       ContainerRuntime = "crio"
 )
 
+// ContainerRuntimes are the supported container runtimes.
+var ContainerRuntimes = []ContainerRuntime{Containerd, Docker, CRIO}
+
+// IsValid returns true if the container runtime is supported. CRISocket must
+// only be called for a valid container runtime.
+func (cr ContainerRuntime) IsValid() bool {
+	for _, r := range ContainerRuntimes {
+		if cr == r {
+			return true
+		}
+	}
+	return false
+}
+
 func (cr ContainerRuntime) CRISocket() string {
 	switch cr {
 	case Containerd:
@@ -0,0 +1,17 @@
+package jsonschema
+
+// typeDoc is the documentation of a configuration type, extracted from its
+// godoc comments by hack/tools/genschemadocs.
+type typeDoc struct {
+	Description string
+	Enum        []string
+	Fields      map[string]fieldDoc
+}
+
+// fieldDoc is the documentation of a struct field. Default is the value
+// from a "Default:" comment, which is usually, but not always, valid JSON.
+type fieldDoc struct {
+	Description string
+	Default     string
+	Enum        []string
+}
@@ -0,0 +1,85 @@
+package jsonschema
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	cinderv1alpha1 "github.com/criticalstack/crit/internal/cinder/config/v1alpha1"
+	"github.com/criticalstack/crit/pkg/config/v1alpha1"
+	"github.com/criticalstack/crit/pkg/config/v1alpha2"
+)
+
+// Kinds are the configuration kinds that schemas can be generated for.
+var Kinds = []schema.GroupVersionKind{
+	v1alpha1.SchemeGroupVersion.WithKind("ControlPlaneConfiguration"),
+	v1alpha1.SchemeGroupVersion.WithKind("WorkerConfiguration"),
+	v1alpha2.SchemeGroupVersion.WithKind("ControlPlaneConfiguration"),
+	v1alpha2.SchemeGroupVersion.WithKind("WorkerConfiguration"),
+	cinderv1alpha1.SchemeGroupVersion.WithKind("ClusterConfiguration"),
+}
+
+// ForKind returns the JSON Schema for a configuration kind.
+func ForKind(gvk schema.GroupVersionKind) (*Schema, error) {
+	found := false
+	for _, k := range Kinds {
+		if k == gvk {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("no schema for %s", gvk)
+	}
+	obj, err := clientsetscheme.Scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	return Generate(gvk, obj), nil
+}
+
+// ForDocument returns the JSON Schema for the apiVersion and kind of a
+// configuration document.
+func ForDocument(data []byte) (*Schema, error) {
+	var meta struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	gv, err := schema.ParseGroupVersion(meta.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	return ForKind(gv.WithKind(meta.Kind))
+}
+
+// ValidateDocument validates a configuration document against the schema
+// for its apiVersion and kind.
+func ValidateDocument(data []byte) []error {
+	s, err := ForDocument(data)
+	if err != nil {
+		return []error{err}
+	}
+	return s.Validate(data)
+}
+
+// UnknownFields returns an *UnknownFieldError for each field in a
+// configuration document that is not known to its kind. Other schema
+// violations are ignored, as are documents without a schema, since these are
+// reported when the document is decoded.
+func UnknownFields(data []byte) []error {
+	s, err := ForDocument(data)
+	if err != nil {
+		return nil
+	}
+	errs := make([]error, 0)
+	for _, err := range s.Validate(data) {
+		if _, ok := err.(*UnknownFieldError); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
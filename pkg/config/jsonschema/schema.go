@@ -0,0 +1,228 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	computil "github.com/criticalstack/crit/pkg/cluster/components/util"
+	"github.com/criticalstack/crit/pkg/config/v1alpha2"
+)
+
+// SchemaVersion is the JSON Schema draft used by generated schemas.
+const SchemaVersion = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document. Only the keywords needed to describe
+// configuration types are supported.
+type Schema struct {
+	Schema      string        `json:"$schema,omitempty"`
+	Ref         string        `json:"$ref,omitempty"`
+	Title       string        `json:"title,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Format      string        `json:"format,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Const       interface{}   `json:"const,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Required    []string      `json:"required,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+
+	// AdditionalProperties is either a *Schema or false.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+
+	Items       *Schema            `json:"items,omitempty"`
+	AnyOf       []*Schema          `json:"anyOf,omitempty"`
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+}
+
+var (
+	durationType    = reflect.TypeOf(metav1.Duration{})
+	timeType        = reflect.TypeOf(metav1.Time{})
+	microTimeType   = reflect.TypeOf(metav1.MicroTime{})
+	quantityType    = reflect.TypeOf(resource.Quantity{})
+	intOrStringType = reflect.TypeOf(intstr.IntOrString{})
+	rawExtType      = reflect.TypeOf(runtime.RawExtension{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	apiEndpointType = reflect.TypeOf(computil.APIEndpoint{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+	// rawFieldTypes are the types of fields that are decoded separately,
+	// such as patches, keyed by struct type and field name. The schema of
+	// the type is used rather than allowing any value.
+	rawFieldTypes = map[string]reflect.Type{
+		"github.com/criticalstack/crit/pkg/config/v1alpha2.NodeOverride.NodeConfiguration": reflect.TypeOf(v1alpha2.NodeConfiguration{}),
+	}
+)
+
+// Generate returns the JSON Schema for a configuration object of the
+// provided kind. The godoc comments of the configuration types are used for
+// descriptions, and their "Default:" comments for default values.
+func Generate(gvk schema.GroupVersionKind, obj runtime.Object) *Schema {
+	g := &generator{defs: make(map[string]*Schema)}
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	s := g.structSchema(t)
+	s.Schema = SchemaVersion
+	s.Title = gvk.Kind
+	s.Properties["apiVersion"] = &Schema{Type: "string", Const: gvk.GroupVersion().String()}
+	s.Properties["kind"] = &Schema{Type: "string", Const: gvk.Kind}
+	s.Required = []string{"apiVersion", "kind"}
+	if len(g.defs) > 0 {
+		s.Definitions = g.defs
+	}
+	return s
+}
+
+type generator struct {
+	defs map[string]*Schema
+}
+
+func docFor(t reflect.Type) typeDoc {
+	if t.Name() == "" {
+		return typeDoc{}
+	}
+	return typeDocs[t.PkgPath()+"."+t.Name()]
+}
+
+func definitionName(t reflect.Type) string {
+	return strings.Replace(t.PkgPath(), "/", ".", -1) + "." + t.Name()
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case durationType:
+		return &Schema{Type: "string", Description: "A duration, such as \"30s\" or \"5m\"."}
+	case timeType, microTimeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case quantityType:
+		return &Schema{AnyOf: []*Schema{{Type: "string"}, {Type: "number"}}}
+	case intOrStringType:
+		return &Schema{AnyOf: []*Schema{{Type: "integer"}, {Type: "string"}}}
+	case rawExtType, rawMessageType:
+		return &Schema{}
+	case apiEndpointType:
+		// APIEndpoint can also be provided as a host:port string
+		return &Schema{AnyOf: []*Schema{{Type: "string"}, g.refSchema(t)}}
+	}
+	if t.Kind() != reflect.Interface && reflect.PtrTo(t).Implements(unmarshalerType) {
+		// the JSON representation of types with custom decoding cannot be
+		// known, so any value is allowed
+		return &Schema{Description: docFor(t).Description}
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.refSchema(t)
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.String:
+		s := &Schema{Type: "string"}
+		doc := docFor(t)
+		for _, v := range doc.Enum {
+			s.Enum = append(s.Enum, v)
+		}
+		s.Description = doc.Description
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+	return &Schema{}
+}
+
+// refSchema adds the struct type to the definitions, if it has not been
+// already, and returns a reference to it. Definitions are used so that
+// recursive types and types used in many places are only described once.
+func (g *generator) refSchema(t reflect.Type) *Schema {
+	name := definitionName(t)
+	if _, ok := g.defs[name]; !ok {
+		// a placeholder is stored first to stop recursive types from being
+		// generated again
+		g.defs[name] = &Schema{}
+		*g.defs[name] = *g.structSchema(t)
+	}
+	return &Schema{Ref: "#/definitions/" + name}
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:                 "object",
+		Description:          docFor(t).Description,
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+	g.addFields(s, t)
+	return s
+}
+
+func (g *generator) addFields(s *Schema, t reflect.Type) {
+	doc := docFor(t)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			// embedded structs without a name are inlined, the same as with
+			// encoding/json
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		ft := f.Type
+		if rt, ok := rawFieldTypes[t.PkgPath()+"."+t.Name()+"."+f.Name]; ok {
+			ft = rt
+		}
+		fs := g.schemaFor(ft)
+		fd := doc.Fields[f.Name]
+		if fd.Description != "" {
+			fs.Description = fd.Description
+		}
+		if fd.Default != "" {
+			var v interface{}
+			if err := json.Unmarshal([]byte(fd.Default), &v); err == nil {
+				fs.Default = v
+			} else {
+				// defaults that refer to other fields are kept as text
+				fs.Description = strings.TrimSpace(fs.Description + "\n\nDefault: " + fd.Default)
+			}
+		}
+		for _, v := range fd.Enum {
+			fs.Enum = append(fs.Enum, v)
+		}
+		s.Properties[name] = fs
+	}
+}
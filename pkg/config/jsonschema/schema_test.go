@@ -0,0 +1,154 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/criticalstack/crit/pkg/config/v1alpha2"
+)
+
+func TestGenerate(t *testing.T) {
+	s, err := ForKind(v1alpha2.SchemeGroupVersion.WithKind("ControlPlaneConfiguration"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterName := s.Properties["clusterName"]
+	if clusterName == nil {
+		t.Fatal("expected clusterName property")
+	}
+	if clusterName.Default != "crit" {
+		t.Fatalf("expected default %q, received %v", "crit", clusterName.Default)
+	}
+	if !strings.HasPrefix(s.Properties["podSubnets"].Description, "PodSubnets is") {
+		t.Fatalf("expected description from type comments, received %q", s.Properties["podSubnets"].Description)
+	}
+	if _, err := json.Marshal(s); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name: "valid",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: ControlPlaneConfiguration
+controlPlaneEndpoint: "example.com:6443"
+kubeAPIServer:
+  extraSans:
+  - 10.0.0.1
+addons:
+- name: cilium
+  timeout: 10m
+node:
+  kubernetesVersion: 1.18.5
+  kubelet:
+    cgroupDriver: systemd
+`,
+		},
+		{
+			name: "unknown field",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: ControlPlaneConfiguration
+node:
+  kubelet:
+    cgroupDriverr: systemd
+`,
+			expected: []string{`node.kubelet.cgroupDriverr: unknown field, did you mean "cgroupDriver"?`},
+		},
+		{
+			name: "node override",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+nodeOverrides:
+- hostname: "gpu-*"
+  node:
+    kubelet:
+      maxPod: 50
+`,
+			expected: []string{`nodeOverrides[0].node.kubelet.maxPod: unknown field, did you mean "maxPods"?`},
+		},
+		{
+			name: "wrong type",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+featureGates:
+  BootstrapServer: "yes"
+node:
+  taints:
+  - key: a
+    effect: NoSchedule
+  - 1
+`,
+			expected: []string{
+				"featureGates.BootstrapServer: expected boolean, received string",
+				"node.taints[1]: expected object, received number",
+			},
+		},
+		{
+			name: "enum",
+			config: `apiVersion: cinder.crit.sh/v1alpha1
+kind: ClusterConfiguration
+files:
+- path: /etc/a
+  encoding: base32
+  content: a
+`,
+			expected: []string{`files[0].encoding: unsupported value base32, must be one of: "base64", "gzip", "gzip+base64", "hostpath"`},
+		},
+		{
+			name: "unknown kind",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: ClusterConfiguration
+`,
+			expected: []string{"no schema for crit.sh/v1alpha2, Kind=ClusterConfiguration"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateDocument([]byte(tc.config))
+			if len(errs) != len(tc.expected) {
+				t.Fatalf("expected %d errors, received %v", len(tc.expected), errs)
+			}
+			for i, err := range errs {
+				if err.Error() != tc.expected[i] {
+					t.Errorf("expected %q, received %q", tc.expected[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	config := `apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+ClusterName: crit
+bootstrapTokn: abcdef.0123456789abcdef
+notAField: true
+featureGates:
+  BootstrapServer: "yes"
+node:
+  kubelt: {}
+`
+	expected := []string{
+		`ClusterName: unknown field, did you mean "clusterName"?`,
+		`bootstrapTokn: unknown field, did you mean "bootstrapToken"?`,
+		`node.kubelt: unknown field, did you mean "kubelet"?`,
+		`notAField: unknown field`,
+	}
+	errs := UnknownFields([]byte(config))
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, received %v", len(expected), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != expected[i] {
+			t.Errorf("expected %q, received %q", expected[i], err)
+		}
+	}
+}
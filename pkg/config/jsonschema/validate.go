@@ -0,0 +1,302 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Validate checks a YAML or JSON document against the schema, returning an
+// error for each violation. Errors include the path of the offending field,
+// such as "node.kubelet.cgroupDriver".
+//
+// Null values are always accepted, since they are treated the same as an
+// omitted field when decoding.
+func (s *Schema) Validate(data []byte) []error {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return []error{err}
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return []error{err}
+	}
+	return (&validator{root: s}).validate(s, v, "")
+}
+
+// UnknownFieldError is returned for fields that are not known to the schema.
+// Suggestion is the name of a known field that is similar enough to have
+// been intended instead, if any.
+type UnknownFieldError struct {
+	Path       string
+	Suggestion string
+}
+
+func (e *UnknownFieldError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("%s: unknown field, did you mean %q?", e.Path, e.Suggestion)
+	}
+	return fmt.Sprintf("%s: unknown field", e.Path)
+}
+
+type validator struct {
+	root *Schema
+}
+
+func (vr *validator) resolve(s *Schema) (*Schema, error) {
+	for s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		def, ok := vr.root.Definitions[name]
+		if !ok {
+			return nil, errors.Errorf("cannot resolve schema reference %q", s.Ref)
+		}
+		s = def
+	}
+	return s, nil
+}
+
+func (vr *validator) validate(s *Schema, v interface{}, path string) []error {
+	if v == nil {
+		return nil
+	}
+	s, err := vr.resolve(s)
+	if err != nil {
+		return []error{err}
+	}
+	if len(s.AnyOf) > 0 {
+		types := make([]string, 0)
+		for _, sub := range s.AnyOf {
+			if len(vr.validate(sub, v, path)) == 0 {
+				return nil
+			}
+			sub, err := vr.resolve(sub)
+			if err != nil {
+				return []error{err}
+			}
+			types = append(types, sub.Type)
+		}
+		return []error{errors.Errorf("%s: expected %s, received %s", displayPath(path), strings.Join(types, " or "), typeOf(v))}
+	}
+	if s.Type != "" && !hasType(v, s.Type) {
+		return []error{errors.Errorf("%s: expected %s, received %s", displayPath(path), s.Type, typeOf(v))}
+	}
+	if s.Const != nil && !equal(s.Const, v) {
+		return []error{errors.Errorf("%s: expected %v, received %v", displayPath(path), s.Const, v)}
+	}
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if equal(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allowed := make([]string, 0)
+			for _, e := range s.Enum {
+				allowed = append(allowed, fmt.Sprintf("%q", e))
+			}
+			return []error{errors.Errorf("%s: unsupported value %v, must be one of: %s", displayPath(path), v, strings.Join(allowed, ", "))}
+		}
+	}
+
+	errs := make([]error, 0)
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if prop, ok := s.Properties[k]; ok {
+				errs = append(errs, vr.validate(prop, val[k], joinPath(path, k))...)
+				continue
+			}
+			switch ap := s.AdditionalProperties.(type) {
+			case bool:
+				if !ap {
+					errs = append(errs, &UnknownFieldError{
+						Path:       joinPath(path, k),
+						Suggestion: suggest(k, s.Properties),
+					})
+				}
+			case *Schema:
+				errs = append(errs, vr.validate(ap, val[k], joinPath(path, k))...)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range val {
+				errs = append(errs, vr.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return errs
+}
+
+// suggest returns the known property most similar to an unknown field name,
+// or an empty string if none are close enough to be a likely misspelling.
+func suggest(name string, properties map[string]*Schema) string {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	best, bestDist := "", -1
+	for _, k := range keys {
+		d := distance(strings.ToLower(name), strings.ToLower(k))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	maxDist := len(name) / 3
+	if maxDist < 1 {
+		maxDist = 1
+	}
+	if bestDist < 0 || bestDist > maxDist {
+		return ""
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between two strings.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, ". ") {
+		key = fmt.Sprintf("%q", key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
+
+func hasType(v interface{}, t string) bool {
+	switch t {
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	}
+	return true
+}
+
+func typeOf(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func equal(a, b interface{}) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// Lookup returns the schema of the field at the path, where list items are
+// referred to by their index, or nil if the path is not known to the schema.
+func (s *Schema) Lookup(path ...string) *Schema {
+	vr := &validator{root: s}
+	return vr.lookup(s, path)
+}
+
+func (vr *validator) lookup(s *Schema, path []string) *Schema {
+	s, err := vr.resolve(s)
+	if err != nil {
+		return nil
+	}
+	if len(path) == 0 {
+		return s
+	}
+	for _, sub := range s.AnyOf {
+		if found := vr.lookup(sub, path); found != nil {
+			return found
+		}
+	}
+	switch s.Type {
+	case "object":
+		if prop, ok := s.Properties[path[0]]; ok {
+			return vr.lookup(prop, path[1:])
+		}
+		if ap, ok := s.AdditionalProperties.(*Schema); ok {
+			return vr.lookup(ap, path[1:])
+		}
+	case "array":
+		if _, err := strconv.Atoi(path[0]); err == nil && s.Items != nil {
+			return vr.lookup(s.Items, path[1:])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2020 Critical Stack, LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by genschemadocs. DO NOT EDIT.
+
+package jsonschema
+
+var typeDocs = map[string]typeDoc{
+	"github.com/criticalstack/crit/internal/cinder/config/v1alpha1.ClusterConfiguration": {
+		Fields: map[string]fieldDoc{
+			"Files":             {Description: "Files specifies extra files to be passed to user_data upon creation."},
+			"LocalRegistryName": {Description: "Name of local container registry. Used for DNS resolution.", Default: "\"cinderegg\""},
+			"LocalRegistryPort": {Description: "Port of local container registry.", Default: "5000"},
+			"PostCritCommands":  {Description: "PostCritCommands specifies extra commands to run after crit runs"},
+			"PreCritCommands":   {Description: "PreCritCommands specifies extra commands to run before crit runs"},
+		},
+	},
+	"github.com/criticalstack/crit/internal/cinder/config/v1alpha1.Encoding": {
+		Description: "Encoding specifies the cloud-init file encoding.",
+		Enum:        []string{"base64", "gzip", "gzip+base64", "hostpath"},
+	},
+	"github.com/criticalstack/crit/internal/cinder/config/v1alpha1.File": {
+		Description: "File defines the input for generating write_files in cloud-init.",
+		Fields: map[string]fieldDoc{
+			"Content":     {Description: "Content is the actual content of the file."},
+			"Encoding":    {Description: "Encoding specifies the encoding of the file contents."},
+			"Owner":       {Description: "Owner specifies the ownership of the file, e.g. \"root:root\"."},
+			"Path":        {Description: "Path specifies the full path on disk where to store the file."},
+			"Permissions": {Description: "Permissions specifies the permissions to assign to the file, e.g. \"0640\"."},
+		},
+	},
+	"github.com/criticalstack/crit/internal/cinder/config/v1alpha1.PortMappingProtocol": {
+		Enum: []string{"TCP", "UDP"},
+	},
+	"github.com/criticalstack/crit/pkg/cluster/components/util.APIEndpoint": {
+		Description: "APIEndpoint represents a reachable Kubernetes API endpoint using scheme-less host:port (port is optional).",
+		Fields: map[string]fieldDoc{
+			"Host": {Description: "The hostname on which the API server is serving."},
+			"Port": {Description: "The port on which the API server is serving."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/cluster/components/util.HostPathMount": {
+		Description: "HostPathMount contains elements describing volumes that are mounted from the host.",
+		Fields: map[string]fieldDoc{
+			"HostPath":  {Description: "HostPath is the path in the host that will be mounted inside the pod."},
+			"MountPath": {Description: "MountPath is the path inside the pod where hostPath will be mounted."},
+			"Name":      {Description: "Name of the volume inside the pod template."},
+			"ReadOnly":  {Description: "ReadOnly controls write access to the volume"},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha1.APIEndpoint": {
+		Description: "APIEndpoint represents a reachable Kubernetes API endpoint.",
+		Fields: map[string]fieldDoc{
+			"Host": {Description: "The hostname on which the API server is serving."},
+			"Port": {Description: "The port on which the API server is serving."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha1.ControlPlaneConfiguration": {
+		Fields: map[string]fieldDoc{
+			"APIServerURL":         {Description: "APIServerURL is the kube-apiserver URL."},
+			"ClusterName":          {Description: "ClusterName", Default: "\"crit\""},
+			"ControlPlaneEndpoint": {Description: "ControlPlaneEndpoint is the IP address or DNS name that represents the control plane."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha1.EtcdConfiguration": {
+		Fields: map[string]fieldDoc{
+			"CAKey": {Description: "CAKey is the etcd CA private key. It is only used to encrypt e2db tables, so any file containing data to be used as a secret can be provided here to enable e2db table encryption for shared cluster files."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha1.HostPathMount": {
+		Description: "HostPathMount contains elements describing volumes that are mounted from the host.",
+		Fields: map[string]fieldDoc{
+			"HostPath":  {Description: "HostPath is the path in the host that will be mounted inside the pod."},
+			"MountPath": {Description: "MountPath is the path inside the pod where hostPath will be mounted."},
+			"Name":      {Description: "Name of the volume inside the pod template."},
+			"ReadOnly":  {Description: "ReadOnly controls write access to the volume"},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.AddonConfiguration": {
+		Fields: map[string]fieldDoc{
+			"Name":     {Description: "Name is the name of an addon template embedded in crit. Available addons can be found with `crit addons list`. Either Name or Path must be provided."},
+			"Path":     {Description: "Path is the full file path of a local manifest file, or a directory containing manifest files. Manifests are rendered as templates in the same way as embedded addons."},
+			"SkipWait": {Description: "SkipWait disables waiting for the workloads in an addon to become ready before continuing to the next addon."},
+			"Timeout":  {Description: "Timeout is the amount of time to wait for the workloads in an addon to become ready.", Default: "\"5m\""},
+			"Values":   {Description: "Values is a map of values provided to the addon template as .Values."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.ControlPlaneConfiguration": {
+		Fields: map[string]fieldDoc{
+			"Addons":                             {Description: "Addons is a list of addons that are applied, in order, once the control plane is available."},
+			"ClusterName":                        {Description: "ClusterName", Default: "\"crit\""},
+			"ControlPlaneEndpoint":               {Description: "ControlPlaneEndpoint is the IP address or DNS name that represents the control plane, along with optional port. The host portion is automatically added to the cluster CA SANs."},
+			"ControlPlaneNode":                   {Description: "ControlPlaneNode provides configuration for the taints and labels that mark the node as a control plane node."},
+			"CoreDNSVersion":                     {Description: "CoreDNSVersion is the version given to the CoreDNS template.", Default: "\"1.6.9\""},
+			"CritBootstrapServerConfiguration":   {Description: "CritBootstrapServerConfiguration provides configuration for the crit-bootstrap-server static pod."},
+			"EtcdConfiguration":                  {Description: "EtcdConfiguration provides configuration for the client etcd connection used by the apiserver."},
+			"FeatureGates":                       {Description: "FeatureGates is a map of feature names to bools that enable or disable alpha/experimental or optional features."},
+			"ImageRepository":                    {Description: "ImageRepository is the container image repository used for all component images, replacing the repository of the default images while keeping the image name (e.g. \"registry.local/k8s\" results in \"registry.local/k8s/kube-apiserver\")."},
+			"Images":                             {Description: "Images provides overrides for the container images of individual components."},
+			"KubeAPIServerConfiguration":         {Description: "KubeAPIServerConfiguration provides configuration for the kube-apiserver static pod."},
+			"KubeControllerManagerConfiguration": {Description: "KubeControllerManagerConfiguration provides configuration for the kube-controller-manager static pod."},
+			"KubeProxyConfiguration":             {Description: "KubeProxyConfiguration provides configuration for the kube-proxy daemonset."},
+			"KubeSchedulerConfiguration":         {Description: "KubeSchedulerConfiguration provides configuration for the kube-scheduler static pod."},
+			"NodeConfiguration":                  {Description: "NodeConfiguration provides configuration for the particular node being bootstrapped. This includes host-specific information, such as hostname or IP address, as well as, kubelet configuration."},
+			"NodeOverrides":                      {Description: "NodeOverrides patch NodeConfiguration for the nodes they match, so that a single config can be shared by nodes that need host-specific settings. Every matching override is applied, in order."},
+			"PodSecurity":                        {Description: "PodSecurity provides configuration for enforcing pod security standards. Depending upon the Kubernetes version, this is accomplished either with PodSecurityPolicy or the PodSecurity admission plugin."},
+			"PodSubnet":                          {Description: "PodSubnet is the CIDR range for allocating private IP addresses for pods.", Default: "\"10.253.0.0/16\""},
+			"PodSubnets":                         {Description: "PodSubnets is the list of CIDR ranges for allocating pod IP addresses in a dual-stack cluster, with at most one range per IP family. The first entry is the primary pod subnet and must match PodSubnet when both are provided.", Default: "[PodSubnet]"},
+			"ServiceSubnet":                      {Description: "ServiceSubnet is the CIDR range for allocating private IP addresses for services.", Default: "\"10.254.0.0/16\""},
+			"ServiceSubnets":                     {Description: "ServiceSubnets is the list of CIDR ranges for allocating service IP addresses in a dual-stack cluster, with at most one range per IP family. The first entry is the primary service subnet and determines the IP family of the apiserver advertise address and cluster DNS.", Default: "[ServiceSubnet]"},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.ControlPlaneNodeConfiguration": {
+		Description: "ControlPlaneNodeConfiguration is the taints and labels applied to control plane nodes once the control plane is available. The node-role.kubernetes.io/control-plane label is always applied.",
+		Fields: map[string]fieldDoc{
+			"LegacyMasterLabel": {Description: "LegacyMasterLabel determines if the deprecated node-role.kubernetes.io/master label is applied alongside the node-role.kubernetes.io/control-plane label.", Default: "true"},
+			"Taints":            {Description: "Taints is the taints applied to control plane nodes, usually to prevent workloads from being scheduled on them. An empty list applies no taints, which is useful for single-node clusters. The taints in NodeConfiguration are applied in addition to these.", Default: "[{\"key\": \"node-role.kubernetes.io/master\", \"effect\": \"NoSchedule\"}]"},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.EtcdConfiguration": {
+		Fields: map[string]fieldDoc{
+			"CAKey": {Description: "CAKey is the etcd CA private key. It is only used to encrypt e2db tables, so any file containing data to be used as a secret can be provided here to enable e2db table encryption for shared cluster files."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.ImageOverride": {
+		Description: "ImageOverride overrides parts of a component container image reference.",
+		Fields: map[string]fieldDoc{
+			"Digest":     {Description: "Digest is the image digest (e.g. \"sha256:...\")."},
+			"Repository": {Description: "Repository is the full image repository, without tag or digest (e.g. \"registry.local/coredns\")."},
+			"Tag":        {Description: "Tag is the image tag."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.NodeAddressConfiguration": {
+		Description: "NodeAddressConfiguration is the policy used to select the host address from the network interfaces of the host.",
+		Fields: map[string]fieldDoc{
+			"CIDRs":          {Description: "CIDRs are the CIDR ranges used by the \"CIDR\" policy."},
+			"ExcludeVirtual": {Description: "ExcludeVirtual prevents virtual network interfaces, such as bridges (e.g. docker0), veth pairs and tunnels, from being selected."},
+			"Interface":      {Description: "Interface is the name of the network interface used by the \"Interface\" policy."},
+			"Policy":         {Description: "Policy is the rule used to select the host address, and can be one of: \"DefaultRoute\" to use the interface holding the default route, \"Interface\" to use the interface matching Interface, \"CIDR\" to use the first address contained by CIDRs, or \"FirstInterface\" to use the first non-loopback interface. The \"DefaultRoute\" policy falls back to \"FirstInterface\" when the host does not have a default route.", Default: "\"DefaultRoute\""},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.NodeConfiguration": {
+		Fields: map[string]fieldDoc{
+			"Annotations":          {Description: "Annotations is any annotations to be applied to the node after it has registered."},
+			"CloudProvider":        {Description: "CloudProvider is used to configured in-tree cloud providers."},
+			"ContainerRuntime":     {Description: "ContainerRuntime is the container runtime being used by the Kubelet.", Default: "\"containerd\""},
+			"HostIPv4":             {Description: "HostIPv4 is the IPv4 address of the host for the node being bootstrapped. If this is not provided the address is selected using NodeAddress."},
+			"HostIPv6":             {Description: "HostIPv6 is the IPv6 address of the host for the node being bootstrapped. This is required for IPv6-only and dual-stack clusters, and if this is not provided for a control plane node with an IPv6 subnet, the address is selected using NodeAddress."},
+			"Hostname":             {Description: "Hostname is the hostname for this node. This defaults to the hostname provided by the host. It is unlikely that this will need to be changed."},
+			"KubeDir":              {Description: "KubeDir is the base directory for important Kubernetes configuration files (manifests, configuration files, pki, etc). It is unlikely this will ever need to be changed.", Default: "\"/etc/kubernetes\""},
+			"KubeletConfiguration": {Description: "KubeletConfiguration is the component config for the kubelet. There are quite a few defaults that are being set that can be found in defaults.go of this package."},
+			"KubeletExtraArgs":     {Description: "KubeletExtraArgs is a map of arguments to provide to the kubelet binary. This is useful for settings that are not available in the component config. It should not be used to set deprecated flags that have been moved into the component config."},
+			"KubernetesVersion":    {Description: "KubernetesVersion is the version of Kubernetes for this node."},
+			"Labels":               {Description: "Labels is any labels to be applied to the node. Labels allowed by the NodeRestriction admission plugin are set by the kubelet when the node registers, while restricted labels, such as node-role.kubernetes.io/*, are applied after the node has registered."},
+			"NodeAddress":          {Description: "NodeAddress determines how HostIPv4 and HostIPv6 are selected when they are not provided."},
+			"Taints":               {Description: "Taints is any taints to be applied to the node after initial bootstrapping."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.NodeOverride": {
+		Description: "NodeOverride is a partial NodeConfiguration applied to the nodes matching all of its selectors. At least one selector must be provided.",
+		Fields: map[string]fieldDoc{
+			"CIDR":              {Description: "CIDR matches nodes with a network interface address within the CIDR range."},
+			"Hostname":          {Description: "Hostname matches the hostname of the node, and can be a shell file name pattern, such as \"worker-*\"."},
+			"InstanceTags":      {Description: "InstanceTags matches nodes whose cloud instance has all of these tags. Tags are read from the AWS EC2 instance metadata service, which requires instance tags to be allowed in instance metadata."},
+			"NodeConfiguration": {Description: "NodeConfiguration is merged into the NodeConfiguration of matching nodes as a strategic merge patch, so only the fields being changed need to be provided."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.OIDCConfiguration": {
+		Description: "OIDCConfiguration configures the apiserver to authenticate users with an OpenID Connect provider.",
+		Fields: map[string]fieldDoc{
+			"CAFile":         {Description: "CAFile is the full file path of the CA certificate used to verify the OpenID server's certificate. If not provided, the host's root CAs are used."},
+			"ClientID":       {Description: "ClientID is the client ID for the OpenID Connect client, all tokens must be issued for this client ID."},
+			"GroupsClaim":    {Description: "GroupsClaim is the OpenID claim to use as the user's groups."},
+			"GroupsPrefix":   {Description: "GroupsPrefix is prepended to group claims to prevent clashes with existing names."},
+			"IssuerURL":      {Description: "IssuerURL is the URL of the OpenID issuer. Only the https scheme is accepted."},
+			"UsernameClaim":  {Description: "UsernameClaim is the OpenID claim to use as the username.", Default: "\"sub\""},
+			"UsernamePrefix": {Description: "UsernamePrefix is prepended to username claims to prevent clashes with existing names."},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.PodSecurityConfiguration": {
+		Fields: map[string]fieldDoc{
+			"DefaultLevel": {Description: "DefaultLevel is the pod security standard level (privileged, baseline, restricted) applied to any namespace not found in Namespaces. The baseline level is not available with PodSecurityPolicy and is treated as restricted.", Default: "\"restricted\""},
+			"Enabled":      {Description: "Enabled turns on pod security enforcement. For Kubernetes versions that support the PodSecurity admission plugin (v1.23+), an admission configuration file is written and provided to the apiserver. For older versions, the privileged and restricted PodSecurityPolicies are applied along with the RBAC needed to use them."},
+			"Namespaces":   {Description: "Namespaces is a map of namespace names to the pod security standard level for that namespace.", Default: "{\"kube-system\": \"privileged\", \"kube-node-lease\": \"privileged\", \"kube-public\": \"privileged\"}"},
+		},
+	},
+	"github.com/criticalstack/crit/pkg/config/v1alpha2.WorkerConfiguration": {
+		Fields: map[string]fieldDoc{
+			"BootstrapServerURL":   {Description: "BootstrapServerURL is the full URL to the crit-bootstrap-server static pod. This should only be specified for a cluster using the bootstrap-server, otherwise a bootstrap token should be provided."},
+			"BootstrapToken":       {Description: "BootstrapToken is the Kubernetes bootstrap auth token used for bootstrapping a worker to a control plane. The token format is described here:\nhttps://kubernetes.io/docs/reference/access-authn-authz/bootstrap-tokens/#token-format"},
+			"CACert":               {Description: "CACert is the full file path of the cluster CA certificate. This must be provided during bootstrapping because it is used to verify that the control plane being joined by the worker."},
+			"ClusterName":          {Description: "ClusterName", Default: "\"crit\""},
+			"ControlPlaneEndpoint": {Description: "ControlPlaneEndpoint is the IP address or DNS name that represents the control plane, along with optional port. The host portion is automatically added to the cluster CA SANs."},
+			"FeatureGates":         {Description: "FeatureGates is a map of feature names to bools that enable or disable alpha/experimental or optional features."},
+			"ImageRepository":      {Description: "ImageRepository is the container image repository used for the pause image."},
+			"Images":               {Description: "Images provides overrides for the container images used by the node. Only the pause and kube-proxy images are used by worker nodes."},
+			"NodeConfiguration":    {Description: "NodeConfiguration provides configuration for the particular node being bootstrapped. This includes host-specific information, such as hostname or IP address, as well as, kubelet configuration."},
+			"NodeOverrides":        {Description: "NodeOverrides patch NodeConfiguration for the nodes they match, so that a single config can be shared by nodes that need host-specific settings. Every matching override is applied, in order."},
+		},
+	},
+}
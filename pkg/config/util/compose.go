@@ -0,0 +1,306 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	"github.com/criticalstack/crit/pkg/config/jsonschema"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+// LoadOptions are the sources used to compose a config.
+type LoadOptions struct {
+	// Files are the config files to merge, in order. Each file can contain
+	// multiple YAML documents, which are also merged in order.
+	Files []string
+
+	// Values are path=value expressions, such as
+	// "node.kubelet.cgroupDriver=systemd", that are set once all files have
+	// been merged.
+	Values []string
+
+	// Strict returns an error if the composed config contains unknown
+	// fields.
+	Strict bool
+}
+
+// Load composes a config from files and values, and decodes it. The
+// effective config is logged, with secrets redacted, at the debug level.
+func Load(opts *LoadOptions) (runtime.Object, error) {
+	data, err := Compose(opts.Files, opts.Values)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Strict {
+		if err := CheckUnknownFields(data); err != nil {
+			return nil, err
+		}
+	}
+	obj, err := Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if log.Level() == zapcore.DebugLevel {
+		if data, err := Marshal(Redact(obj)); err == nil {
+			log.Debug("effective config", zap.Strings("files", opts.Files), zap.String("config", string(data)))
+		} else {
+			log.Debug("cannot marshal effective config", zap.Error(err))
+		}
+	}
+	return obj, nil
+}
+
+// Compose reads the YAML documents of each file and merges them, in order,
+// into a single config document using strategic merge patch semantics. The
+// first document determines the apiVersion and kind, which can be omitted
+// from the documents that follow. Environment variables referenced as
+// ${VAR} are substituted in each file before parsing, and path=value
+// expressions are set on the merged document. The composed document is
+// returned as JSON.
+func Compose(files []string, values []string) ([]byte, error) {
+	docs := make([][]byte, 0)
+	for _, path := range files {
+		data, err := ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data, err = ExpandEnv(data)
+		if err != nil {
+			return nil, errors.Wrap(err, path)
+		}
+		fileDocs, err := splitDocuments(data)
+		if err != nil {
+			return nil, errors.Wrap(err, path)
+		}
+		docs = append(docs, fileDocs...)
+	}
+	if len(docs) == 0 {
+		return nil, errors.Errorf("no config documents found in %s", strings.Join(files, ", "))
+	}
+
+	var meta struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := json.Unmarshal(docs[0], &meta); err != nil {
+		return nil, err
+	}
+	gv, err := schema.ParseGroupVersion(meta.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	gvk := gv.WithKind(meta.Kind)
+	data := docs[0]
+	if len(docs) > 1 {
+		obj, err := clientsetscheme.Scheme.New(gvk)
+		if err != nil {
+			return nil, err
+		}
+		for _, patch := range docs[1:] {
+			var patchMeta struct {
+				APIVersion string `json:"apiVersion"`
+				Kind       string `json:"kind"`
+			}
+			if err := json.Unmarshal(patch, &patchMeta); err != nil {
+				return nil, err
+			}
+			if (patchMeta.APIVersion != "" && patchMeta.APIVersion != meta.APIVersion) || (patchMeta.Kind != "" && patchMeta.Kind != meta.Kind) {
+				return nil, errors.Errorf("cannot merge %s %s into %s %s", patchMeta.APIVersion, patchMeta.Kind, meta.APIVersion, meta.Kind)
+			}
+			data, err = strategicpatch.StrategicMergePatch(data, patch, obj)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(values) == 0 {
+		return data, nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	s, err := jsonschema.ForKind(gvk)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		if err := setValue(m, s, v); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(m)
+}
+
+// splitDocuments returns the non-empty documents of a YAML stream as JSON.
+func splitDocuments(data []byte) ([][]byte, error) {
+	docs := make([][]byte, 0)
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		b, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		b, err = yaml.YAMLToJSON(b)
+		if err != nil {
+			return nil, err
+		}
+		if b = bytes.TrimSpace(b); len(b) == 0 || bytes.Equal(b, []byte("null")) {
+			continue
+		}
+		docs = append(docs, b)
+	}
+	return docs, nil
+}
+
+var envVarRegexp = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ExpandEnv replaces ${VAR} references with the value of the environment
+// variable. An error is returned for any variable that is not set, rather
+// than silently replacing it with an empty string, and $${ can be used for a
+// literal ${.
+func ExpandEnv(data []byte) ([]byte, error) {
+	missing := make([]string, 0)
+	data = envVarRegexp.ReplaceAllFunc(data, func(match []byte) []byte {
+		if string(match) == "$${" {
+			return []byte("${")
+		}
+		name := string(match[2 : len(match)-1])
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return []byte(v)
+	})
+	if len(missing) > 0 {
+		return nil, errors.Errorf("environment variables not set: %s", strings.Join(missing, ", "))
+	}
+	return data, nil
+}
+
+// setValue sets the value of a path=value expression. Path elements are
+// separated by dots, which can be escaped with a backslash for keys that
+// contain them (e.g. "node.labels.example\.com/role=worker"), and list items
+// are referred to by index (e.g. "addons[0].name=cilium"). Values are parsed
+// as YAML, unless the schema for the path is a string.
+func setValue(m map[string]interface{}, s *jsonschema.Schema, expr string) error {
+	parts := strings.SplitN(expr, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return errors.Errorf("invalid value %q, must be in the form path=value", expr)
+	}
+	path, err := parsePath(parts[0])
+	if err != nil {
+		return err
+	}
+	var value interface{} = parts[1]
+	if fs := s.Lookup(path...); fs == nil || fs.Type != "string" {
+		data, err := yaml.YAMLToJSON([]byte(parts[1]))
+		if err != nil {
+			return errors.Wrapf(err, "invalid value %q", expr)
+		}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return errors.Wrapf(err, "invalid value %q", expr)
+		}
+	}
+
+	_, err = setPath(m, path, 0, value)
+	return err
+}
+
+// setPath sets the value at path[i:] within cur, creating any objects and
+// lists that do not exist. The updated value of cur is returned, since
+// appending to a list can replace it.
+func setPath(cur interface{}, path []string, i int, value interface{}) (interface{}, error) {
+	if i == len(path) {
+		return value, nil
+	}
+	elem := path[i]
+	idx, err := strconv.Atoi(elem)
+	isIndex := err == nil
+	switch c := cur.(type) {
+	case nil:
+		if isIndex {
+			return setPath(make([]interface{}, 0), path, i, value)
+		}
+		return setPath(make(map[string]interface{}), path, i, value)
+	case map[string]interface{}:
+		v, err := setPath(c[elem], path, i+1, value)
+		if err != nil {
+			return nil, err
+		}
+		c[elem] = v
+		return c, nil
+	case []interface{}:
+		if !isIndex || idx < 0 || idx > len(c) {
+			return nil, errors.Errorf("invalid index %q for %s, which has %d items", elem, strings.Join(path[:i], "."), len(c))
+		}
+		if idx == len(c) {
+			c = append(c, nil)
+		}
+		v, err := setPath(c[idx], path, i+1, value)
+		if err != nil {
+			return nil, err
+		}
+		c[idx] = v
+		return c, nil
+	}
+	return nil, errors.Errorf("cannot set %s, %s is not an object or list", strings.Join(path, "."), strings.Join(path[:i], "."))
+}
+
+// parsePath splits a path into its elements, with list indexes as separate
+// elements, e.g. `addons[0].name` is ["addons", "0", "name"].
+func parsePath(s string) ([]string, error) {
+	path := make([]string, 0)
+	var b strings.Builder
+	escaped := false
+	flush := func() {
+		if b.Len() > 0 {
+			path = append(path, b.String())
+			b.Reset()
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case escaped:
+			b.WriteByte(ch)
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '.':
+			flush()
+		case ch == '[':
+			flush()
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, errors.Errorf("invalid path %q, missing ]", s)
+			}
+			path = append(path, s[i+1:i+end])
+			i += end
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	flush()
+	return path, nil
+}
@@ -0,0 +1,144 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/criticalstack/crit/internal/config"
+)
+
+func writeFile(t *testing.T, dir, name, data string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	base := writeFile(t, dir, "base.yaml", `apiVersion: crit.sh/v1alpha2
+kind: ControlPlaneConfiguration
+controlPlaneEndpoint: "${TEST_CRIT_ENDPOINT}"
+kubeAPIServer:
+  extraArgs:
+    audit-log-maxage: "30"
+node:
+  kubernetesVersion: 1.18.5
+  kubelet:
+    cgroupDriver: cgroupfs
+---
+# documents after the first only need the fields being changed
+kubeAPIServer:
+  extraArgs:
+    audit-log-maxage: "7"
+    anonymous-auth: "false"
+`)
+	overlay := writeFile(t, dir, "overlay.yaml", `apiVersion: crit.sh/v1alpha2
+kind: ControlPlaneConfiguration
+node:
+  kubelet:
+    cgroupDriver: systemd
+`)
+	os.Setenv("TEST_CRIT_ENDPOINT", "example.com:6443")
+	defer os.Unsetenv("TEST_CRIT_ENDPOINT")
+
+	obj, err := Load(&LoadOptions{
+		Files: []string{base, overlay},
+		Values: []string{
+			"kubeAPIServer.extraArgs.v=2",
+			`node.labels.example\.com/role=ingress`,
+			"addons[0].name=cilium",
+		},
+		Strict: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := obj.(*config.ControlPlaneConfiguration)
+	if cfg.ControlPlaneEndpoint.Host != "example.com" || cfg.ControlPlaneEndpoint.Port != 6443 {
+		t.Errorf("expected controlPlaneEndpoint from environment, received %v", cfg.ControlPlaneEndpoint)
+	}
+	expectedArgs := map[string]string{
+		"audit-log-maxage": "7",
+		"anonymous-auth":   "false",
+		"v":                "2",
+	}
+	for k, v := range expectedArgs {
+		if cfg.KubeAPIServerConfiguration.ExtraArgs[k] != v {
+			t.Errorf("expected extraArgs %s=%q, received %q", k, v, cfg.KubeAPIServerConfiguration.ExtraArgs[k])
+		}
+	}
+	if cfg.NodeConfiguration.KubeletConfiguration.CgroupDriver != "systemd" {
+		t.Errorf("expected cgroupDriver from overlay, received %q", cfg.NodeConfiguration.KubeletConfiguration.CgroupDriver)
+	}
+	if cfg.NodeConfiguration.Labels["example.com/role"] != "ingress" {
+		t.Errorf("expected label to be set, received %v", cfg.NodeConfiguration.Labels)
+	}
+	if len(cfg.Addons) != 1 || cfg.Addons[0].Name != "cilium" {
+		t.Errorf("expected addon to be set, received %v", cfg.Addons)
+	}
+}
+
+func TestComposeErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		name     string
+		config   string
+		values   []string
+		expected string
+	}{
+		{
+			name: "missing variable",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+bootstrapToken: ${TEST_CRIT_UNSET}
+`,
+			expected: "environment variables not set: TEST_CRIT_UNSET",
+		},
+		{
+			name: "different kind",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+---
+apiVersion: crit.sh/v1alpha2
+kind: ControlPlaneConfiguration
+`,
+			expected: "cannot merge crit.sh/v1alpha2 ControlPlaneConfiguration into crit.sh/v1alpha2 WorkerConfiguration",
+		},
+		{
+			name: "invalid value",
+			config: `apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+`,
+			values:   []string{"clusterName"},
+			expected: `invalid value "clusterName", must be in the form path=value`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeFile(t, dir, "config.yaml", tc.config)
+			_, err := Compose([]string{path}, tc.values)
+			if err == nil {
+				t.Fatalf("expected error %q", tc.expected)
+			}
+			if !strings.HasSuffix(err.Error(), tc.expected) {
+				t.Fatalf("expected error %q, received %q", tc.expected, err)
+			}
+		})
+	}
+}
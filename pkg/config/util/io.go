@@ -3,12 +3,16 @@ package util
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/criticalstack/crit/internal/config"
+	"github.com/criticalstack/crit/pkg/config/jsonschema"
 	yamlutil "github.com/criticalstack/crit/pkg/kubernetes/yaml"
+	fmtutil "github.com/criticalstack/crit/pkg/util/fmt"
 )
 
 func ReadFile(path string) (data []byte, err error) {
@@ -29,9 +33,26 @@ func Marshal(obj runtime.Object) ([]byte, error) {
 	return yamlutil.MarshalToYaml(obj, config.SchemeGroupVersion)
 }
 
+// LoadFromFile loads the config from a single file. As with Load, every YAML
+// document in the file is merged and environment variables are expanded.
 func LoadFromFile(path string) (runtime.Object, error) {
-	data, err := ReadFile(path)
-	if err != nil {
+	return Load(&LoadOptions{Files: []string{path}})
+}
+
+// CheckUnknownFields returns an error listing every field in a config
+// document that is not known to its kind, such as a misspelled field that
+// would otherwise be silently dropped when decoding.
+func CheckUnknownFields(data []byte) error {
+	if errs := jsonschema.UnknownFields(data); len(errs) > 0 {
+		return errors.Errorf("config contains unknown fields: %s", strings.TrimSpace(fmtutil.FormatErrors(errs)))
+	}
+	return nil
+}
+
+// UnmarshalStrict is the same as Unmarshal, but returns an error if the
+// config contains unknown fields.
+func UnmarshalStrict(data []byte) (runtime.Object, error) {
+	if err := CheckUnknownFields(data); err != nil {
 		return nil, err
 	}
 	return Unmarshal(data)
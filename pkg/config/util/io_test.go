@@ -1,6 +1,7 @@
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/criticalstack/crit/internal/config"
@@ -26,3 +27,22 @@ func TestConfigConversion(t *testing.T) {
 		t.Fatalf("KubeProxyConfiguration conversion failed")
 	}
 }
+
+func TestUnmarshalStrict(t *testing.T) {
+	data := []byte(`apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+controlPlaneEndpoint: "example.com:6443"
+bootstrapTokn: abcdef.0123456789abcdef
+`)
+	if _, err := Unmarshal(data); err != nil {
+		t.Fatal(err)
+	}
+	_, err := UnmarshalStrict(data)
+	if err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+	expected := `bootstrapTokn: unknown field, did you mean "bootstrapToken"?`
+	if !strings.Contains(err.Error(), expected) {
+		t.Fatalf("expected error to contain %q, received %q", expected, err)
+	}
+}
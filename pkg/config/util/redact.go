@@ -0,0 +1,95 @@
+package util
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/criticalstack/crit/internal/config"
+)
+
+// Redacted replaces secret values in redacted configs.
+const Redacted = "REDACTED"
+
+// Redact returns a copy of the config with secret values replaced, such as
+// bootstrap tokens, addon template values and any component arguments that
+// contain credentials. File paths, such as the etcd CA key, are kept since
+// they do not contain the secret itself. The original config is not
+// modified.
+func Redact(obj runtime.Object) runtime.Object {
+	obj = obj.DeepCopyObject()
+	switch cfg := obj.(type) {
+	case *config.ControlPlaneConfiguration:
+		if oidc := cfg.KubeAPIServerConfiguration.OIDC; oidc != nil {
+			redactString(&oidc.ClientID)
+		}
+		for i := range cfg.Addons {
+			for k := range cfg.Addons[i].Values {
+				cfg.Addons[i].Values[k] = Redacted
+			}
+		}
+		redactArgs(cfg.KubeAPIServerConfiguration.ExtraArgs)
+		redactArgs(cfg.KubeControllerManagerConfiguration.ExtraArgs)
+		redactArgs(cfg.KubeSchedulerConfiguration.ExtraArgs)
+		redactArgs(cfg.CritBootstrapServerConfiguration.ExtraArgs)
+		redactArgs(cfg.NodeConfiguration.KubeletExtraArgs)
+	case *config.WorkerConfiguration:
+		redactString(&cfg.BootstrapToken)
+		redactArgs(cfg.NodeConfiguration.KubeletExtraArgs)
+	}
+	return obj
+}
+
+func redactString(s *string) {
+	if *s != "" {
+		*s = Redacted
+	}
+}
+
+// secretArgs are the words found in the names of arguments that provide
+// credentials directly, rather than the path of a file containing them.
+var secretArgs = []string{"token", "secret", "password"}
+
+func redactArgs(args map[string]string) {
+	for k, v := range args {
+		if isSecretArg(k, v) {
+			args[k] = Redacted
+		}
+	}
+}
+
+func isSecretArg(name, value string) bool {
+	name = strings.ToLower(name)
+	if value == "" || strings.HasSuffix(name, "-file") || strings.HasSuffix(name, "-dir") {
+		return false
+	}
+
+	// flags such as enable-bootstrap-token-auth only enable a feature
+	if _, err := strconv.ParseBool(value); err == nil {
+		return false
+	}
+	for _, s := range secretArgs {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+var flagRe = regexp.MustCompile(`--([A-Za-z0-9][A-Za-z0-9._-]*)=([^\s"',]+)`)
+
+// RedactFlags replaces the values of command-line flags, in the form
+// --name=value, that contain credentials. It is used for files generated from
+// the config, such as static pod manifests and the kubelet environment file,
+// and applies the same rules as Redact does for component arguments.
+func RedactFlags(data []byte) []byte {
+	return flagRe.ReplaceAllFunc(data, func(flag []byte) []byte {
+		m := flagRe.FindSubmatch(flag)
+		if !isSecretArg(string(m[1]), string(m[2])) {
+			return flag
+		}
+		return []byte("--" + string(m[1]) + "=" + Redacted)
+	})
+}
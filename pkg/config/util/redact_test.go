@@ -0,0 +1,132 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/criticalstack/crit/internal/config"
+)
+
+const workerConfig = `apiVersion: crit.sh/v1alpha2
+kind: WorkerConfiguration
+controlPlaneEndpoint: "example.com:6443"
+bootstrapToken: abcdef.0123456789abcdef
+`
+
+func TestRedact(t *testing.T) {
+	obj, err := Unmarshal([]byte(workerConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := obj.(*config.WorkerConfiguration)
+	redacted := Redact(cfg).(*config.WorkerConfiguration)
+	if redacted.BootstrapToken != Redacted {
+		t.Fatalf("expected BootstrapToken to be redacted, received %q", redacted.BootstrapToken)
+	}
+	if cfg.BootstrapToken != "abcdef.0123456789abcdef" {
+		t.Fatal("original config was modified")
+	}
+}
+
+const controlPlaneConfig = `apiVersion: crit.sh/v1alpha2
+kind: ControlPlaneConfiguration
+etcd:
+  caKey: /etc/kubernetes/pki/etcd/ca.key
+kubeAPIServer:
+  extraArgs:
+    enable-bootstrap-token-auth: "true"
+    token-auth-file: /etc/kubernetes/tokens.csv
+    oidc-username-claim: email
+  oidc:
+    issuerURL: https://accounts.example.com
+    clientID: my-client-id
+addons:
+- path: /etc/crit/addons/registry.yaml
+  values:
+    password: hunter2
+`
+
+func TestRedactControlPlane(t *testing.T) {
+	obj, err := Unmarshal([]byte(controlPlaneConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Redact(obj).(*config.ControlPlaneConfiguration)
+	if cfg.EtcdConfiguration.CAKey != "/etc/kubernetes/pki/etcd/ca.key" {
+		t.Errorf("expected etcd CAKey path to be kept, received %q", cfg.EtcdConfiguration.CAKey)
+	}
+	if v := cfg.Addons[0].Values["password"]; v != Redacted {
+		t.Errorf("expected addon values to be redacted, received %q", v)
+	}
+	if v := cfg.KubeAPIServerConfiguration.OIDC.ClientID; v != Redacted {
+		t.Errorf("expected OIDC ClientID to be redacted, received %q", v)
+	}
+	if v := cfg.KubeAPIServerConfiguration.OIDC.IssuerURL; v != "https://accounts.example.com" {
+		t.Errorf("expected OIDC IssuerURL to be kept, received %q", v)
+	}
+	expected := map[string]string{
+		"enable-bootstrap-token-auth": "true",
+		"token-auth-file":             "/etc/kubernetes/tokens.csv",
+		"oidc-username-claim":         "email",
+	}
+	for k, v := range expected {
+		if cfg.KubeAPIServerConfiguration.ExtraArgs[k] != v {
+			t.Errorf("expected %s to be kept, received %q", k, cfg.KubeAPIServerConfiguration.ExtraArgs[k])
+		}
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	args := map[string]string{
+		"bootstrap-token":        "abcdef.0123456789abcdef",
+		"basic-auth-password":    "hunter2",
+		"client-secret":          "s3cr3t",
+		"service-account-issuer": "https://kubernetes.default.svc",
+	}
+	redactArgs(args)
+	for _, k := range []string{"bootstrap-token", "basic-auth-password", "client-secret"} {
+		if args[k] != Redacted {
+			t.Errorf("expected %s to be redacted, received %q", k, args[k])
+		}
+	}
+	if args["service-account-issuer"] != "https://kubernetes.default.svc" {
+		t.Errorf("expected service-account-issuer to be kept, received %q", args["service-account-issuer"])
+	}
+}
+
+func TestRedactFlags(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name: "manifest",
+			data: `    command:
+    - kube-apiserver
+    - --enable-bootstrap-token-auth=true
+    - --token-auth-file=/etc/kubernetes/tokens.csv
+    - --oidc-client-secret=s3cr3t
+    - "--basic-auth-password=hunter2"
+`,
+			expected: `    command:
+    - kube-apiserver
+    - --enable-bootstrap-token-auth=true
+    - --token-auth-file=/etc/kubernetes/tokens.csv
+    - --oidc-client-secret=REDACTED
+    - "--basic-auth-password=REDACTED"
+`,
+		},
+		{
+			name:     "kubelet env file",
+			data:     `KUBELET_ARGS="--node-ip=192.0.2.10 --registry-password=hunter2"` + "\n",
+			expected: `KUBELET_ARGS="--node-ip=192.0.2.10 --registry-password=REDACTED"` + "\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if s := string(RedactFlags([]byte(tc.data))); s != tc.expected {
+				t.Errorf("expected:\n%s\nreceived:\n%s", tc.expected, s)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -47,6 +48,11 @@ func Convert_v1alpha1_ControlPlaneConfiguration_To_v1alpha2_ControlPlaneConfigur
 	}
 	out.PodSubnet = in.NodeConfiguration.PodSubnet
 	out.ServiceSubnet = in.NodeConfiguration.ServiceSubnet
+	out.PodSubnets = []string{in.NodeConfiguration.PodSubnet}
+	out.ServiceSubnets = []string{in.NodeConfiguration.ServiceSubnet}
+	if in.CritBootstrapServerConfiguration.ImageRegistry != "" && in.CritBootstrapServerConfiguration.ImageRegistry != "docker.io" {
+		out.Images.CritBootstrapServer.Repository = path.Join(in.CritBootstrapServerConfiguration.ImageRegistry, "criticalstack/bootstrap-server")
+	}
 	return autoConvert_v1alpha1_ControlPlaneConfiguration_To_v1alpha2_ControlPlaneConfiguration(in, out, s)
 }
 
@@ -82,6 +88,8 @@ func Convert_v1alpha2_ControlPlaneConfiguration_To_v1alpha1_ControlPlaneConfigur
 	}
 	out.NodeConfiguration.PodSubnet = in.PodSubnet
 	out.NodeConfiguration.ServiceSubnet = in.ServiceSubnet
+	// NodeOverrides are dropped since v1alpha1 requires a config file for
+	// each node
 	return autoConvert_v1alpha2_ControlPlaneConfiguration_To_v1alpha1_ControlPlaneConfiguration(in, out, s)
 }
 
@@ -93,6 +101,8 @@ func Convert_v1alpha2_WorkerConfiguration_To_v1alpha1_WorkerConfiguration(in *v1
 		out.APIServerURL = fmt.Sprintf("https://%s", in.ControlPlaneEndpoint)
 	}
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint.Host
+	// NodeOverrides are dropped since v1alpha1 requires a config file for
+	// each node
 	return autoConvert_v1alpha2_WorkerConfiguration_To_v1alpha1_WorkerConfiguration(in, out, s)
 }
 
@@ -118,6 +128,14 @@ func Convert_v1alpha2_KubeSchedulerConfiguration_To_v1alpha1_KubeSchedulerConfig
 	return autoConvert_v1alpha2_KubeSchedulerConfiguration_To_v1alpha1_KubeSchedulerConfiguration(in, out, s)
 }
 
+func Convert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration(in *v1alpha2.NodeConfiguration, out *NodeConfiguration, s conversion.Scope) error {
+	// HostIPv6 and NodeAddress are dropped since v1alpha1 only supports IPv4
+	// clusters and always used the first network interface
+	// Labels and Annotations are dropped since v1alpha1 only supports labels
+	// set with KubeletExtraArgs
+	return autoConvert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration(in, out, s)
+}
+
 func Convert_v1alpha1_NodeConfiguration_To_v1alpha2_NodeConfiguration(in *NodeConfiguration, out *v1alpha2.NodeConfiguration, s conversion.Scope) error {
 	// KubeProxyMode moved to ControlPlaneConfiguration and conversion is
 	// handled there
@@ -194,9 +194,8 @@ func SetDefaults_KubeletConfiguration(obj *kubeletconfigv1beta1.KubeletConfigura
 	if obj.CgroupsPerQOS == nil {
 		obj.CgroupsPerQOS = pointer.BoolPtr(true)
 	}
-	if obj.CgroupDriver == "" {
-		obj.CgroupDriver = "cgroupfs"
-	}
+	// CgroupDriver is intentionally not defaulted here, it is set to match the
+	// container runtime when the node is bootstrapped
 	if obj.CPUManagerPolicy == "" {
 		obj.CPUManagerPolicy = "none"
 	}
@@ -64,11 +64,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha2.NodeConfiguration)(nil), (*NodeConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration(a.(*v1alpha2.NodeConfiguration), b.(*NodeConfiguration), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddConversionFunc((*ControlPlaneConfiguration)(nil), (*v1alpha2.ControlPlaneConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlaneConfiguration_To_v1alpha2_ControlPlaneConfiguration(a.(*ControlPlaneConfiguration), b.(*v1alpha2.ControlPlaneConfiguration), scope)
 	}); err != nil {
@@ -114,6 +109,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha2.NodeConfiguration)(nil), (*NodeConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration(a.(*v1alpha2.NodeConfiguration), b.(*NodeConfiguration), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha2.WorkerConfiguration)(nil), (*WorkerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_WorkerConfiguration_To_v1alpha1_WorkerConfiguration(a.(*v1alpha2.WorkerConfiguration), b.(*WorkerConfiguration), scope)
 	}); err != nil {
@@ -154,6 +154,8 @@ func autoConvert_v1alpha2_ControlPlaneConfiguration_To_v1alpha1_ControlPlaneConf
 	// WARNING: in.ControlPlaneEndpoint requires manual conversion: inconvertible types (github.com/criticalstack/crit/pkg/cluster/components/util.APIEndpoint vs string)
 	// WARNING: in.PodSubnet requires manual conversion: does not exist in peer-type
 	// WARNING: in.ServiceSubnet requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnets requires manual conversion: does not exist in peer-type
+	// WARNING: in.ServiceSubnets requires manual conversion: does not exist in peer-type
 	out.CoreDNSVersion = in.CoreDNSVersion
 	// WARNING: in.FeatureGates requires manual conversion: does not exist in peer-type
 	if err := Convert_v1alpha2_EtcdConfiguration_To_v1alpha1_EtcdConfiguration(&in.EtcdConfiguration, &out.EtcdConfiguration, s); err != nil {
@@ -172,6 +174,12 @@ func autoConvert_v1alpha2_ControlPlaneConfiguration_To_v1alpha1_ControlPlaneConf
 	if err := Convert_v1alpha2_CritBootstrapServerConfiguration_To_v1alpha1_CritBootstrapServerConfiguration(&in.CritBootstrapServerConfiguration, &out.CritBootstrapServerConfiguration, s); err != nil {
 		return err
 	}
+	// WARNING: in.PodSecurity requires manual conversion: does not exist in peer-type
+	// WARNING: in.ImageRepository requires manual conversion: does not exist in peer-type
+	// WARNING: in.Images requires manual conversion: does not exist in peer-type
+	// WARNING: in.Addons requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneNode requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeOverrides requires manual conversion: does not exist in peer-type
 	if err := Convert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration(&in.NodeConfiguration, &out.NodeConfiguration, s); err != nil {
 		return err
 	}
@@ -246,6 +254,7 @@ func autoConvert_v1alpha2_KubeAPIServerConfiguration_To_v1alpha1_KubeAPIServerCo
 	// WARNING: in.HealthcheckProxyVersion requires manual conversion: does not exist in peer-type
 	// WARNING: in.HealthcheckProxyBindPort requires manual conversion: does not exist in peer-type
 	// WARNING: in.ExtraLabels requires manual conversion: does not exist in peer-type
+	// WARNING: in.OIDC requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -311,19 +320,18 @@ func autoConvert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration(in *v1
 	out.Hostname = in.Hostname
 	out.KubeDir = in.KubeDir
 	out.HostIPv4 = in.HostIPv4
+	// WARNING: in.HostIPv6 requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeAddress requires manual conversion: does not exist in peer-type
 	out.CloudProvider = in.CloudProvider
 	out.ContainerRuntime = ContainerRuntime(in.ContainerRuntime)
 	out.Taints = *(*[]v1.Taint)(unsafe.Pointer(&in.Taints))
+	// WARNING: in.Labels requires manual conversion: does not exist in peer-type
+	// WARNING: in.Annotations requires manual conversion: does not exist in peer-type
 	out.KubeletConfiguration = (*v1beta1.KubeletConfiguration)(unsafe.Pointer(in.KubeletConfiguration))
 	out.KubeletExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.KubeletExtraArgs))
 	return nil
 }
 
-// Convert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration is an autogenerated conversion function.
-func Convert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration(in *v1alpha2.NodeConfiguration, out *NodeConfiguration, s conversion.Scope) error {
-	return autoConvert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration(in, out, s)
-}
-
 func autoConvert_v1alpha1_WorkerConfiguration_To_v1alpha2_WorkerConfiguration(in *WorkerConfiguration, out *v1alpha2.WorkerConfiguration, s conversion.Scope) error {
 	out.ClusterName = in.ClusterName
 	// WARNING: in.ControlPlaneEndpoint requires manual conversion: inconvertible types (string vs github.com/criticalstack/crit/pkg/cluster/components/util.APIEndpoint)
@@ -344,6 +352,9 @@ func autoConvert_v1alpha2_WorkerConfiguration_To_v1alpha1_WorkerConfiguration(in
 	out.BootstrapServerURL = in.BootstrapServerURL
 	out.BootstrapToken = in.BootstrapToken
 	out.CACert = in.CACert
+	// WARNING: in.ImageRepository requires manual conversion: does not exist in peer-type
+	// WARNING: in.Images requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeOverrides requires manual conversion: does not exist in peer-type
 	if err := Convert_v1alpha2_NodeConfiguration_To_v1alpha1_NodeConfiguration(&in.NodeConfiguration, &out.NodeConfiguration, s); err != nil {
 		return err
 	}
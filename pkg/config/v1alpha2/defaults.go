@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
@@ -25,12 +26,24 @@ func SetDefaults_ControlPlaneConfiguration(obj *ControlPlaneConfiguration) {
 	if obj.ClusterName == "" {
 		obj.ClusterName = constants.DefaultClusterName
 	}
+	if obj.PodSubnet == "" && len(obj.PodSubnets) > 0 {
+		obj.PodSubnet = obj.PodSubnets[0]
+	}
 	if obj.PodSubnet == "" {
 		obj.PodSubnet = constants.DefaultPodSubnet
 	}
+	if len(obj.PodSubnets) == 0 {
+		obj.PodSubnets = []string{obj.PodSubnet}
+	}
+	if obj.ServiceSubnet == "" && len(obj.ServiceSubnets) > 0 {
+		obj.ServiceSubnet = obj.ServiceSubnets[0]
+	}
 	if obj.ServiceSubnet == "" {
 		obj.ServiceSubnet = constants.DefaultServiceSubnet
 	}
+	if len(obj.ServiceSubnets) == 0 {
+		obj.ServiceSubnets = []string{obj.ServiceSubnet}
+	}
 	if obj.KubeAPIServerConfiguration.BindPort == 0 {
 		obj.KubeAPIServerConfiguration.BindPort = constants.DefaultKubeAPIServerBindPort
 	}
@@ -51,6 +64,35 @@ func SetDefaults_ControlPlaneConfiguration(obj *ControlPlaneConfiguration) {
 	if obj.KubeAPIServerConfiguration.HealthcheckProxyBindPort == 0 {
 		obj.KubeAPIServerConfiguration.HealthcheckProxyBindPort = constants.DefaultHealthcheckProxyBindPort
 	}
+	if obj.KubeAPIServerConfiguration.OIDC != nil && obj.KubeAPIServerConfiguration.OIDC.UsernameClaim == "" {
+		obj.KubeAPIServerConfiguration.OIDC.UsernameClaim = "sub"
+	}
+	if obj.PodSecurity.DefaultLevel == "" {
+		obj.PodSecurity.DefaultLevel = constants.PodSecurityLevelRestricted
+	}
+	if obj.PodSecurity.Namespaces == nil {
+		obj.PodSecurity.Namespaces = map[string]string{
+			"kube-system":     constants.PodSecurityLevelPrivileged,
+			"kube-node-lease": constants.PodSecurityLevelPrivileged,
+			"kube-public":     constants.PodSecurityLevelPrivileged,
+		}
+	}
+	if obj.ControlPlaneNode.Taints == nil {
+		obj.ControlPlaneNode.Taints = []corev1.Taint{
+			{
+				Key:    constants.LabelNodeRoleMaster,
+				Effect: corev1.TaintEffectNoSchedule,
+			},
+		}
+	}
+	if obj.ControlPlaneNode.LegacyMasterLabel == nil {
+		obj.ControlPlaneNode.LegacyMasterLabel = pointer.BoolPtr(true)
+	}
+	for i := range obj.Addons {
+		if obj.Addons[i].Timeout == zeroDuration {
+			obj.Addons[i].Timeout = metav1.Duration{Duration: 5 * time.Minute}
+		}
+	}
 	if obj.KubeProxyConfiguration.Config == nil {
 		obj.KubeProxyConfiguration.Config = &kubeproxyconfigv1alpha1.KubeProxyConfiguration{}
 		SetDefaults_KubeProxyConfiguration(obj.KubeProxyConfiguration.Config)
@@ -76,6 +118,9 @@ func SetDefaults_NodeConfiguration(obj *NodeConfiguration) {
 	if obj.ContainerRuntime == "" {
 		obj.ContainerRuntime = constants.Containerd
 	}
+	if obj.NodeAddress.Policy == "" {
+		obj.NodeAddress.Policy = constants.NodeAddressPolicyDefaultRoute
+	}
 	if obj.KubeletConfiguration == nil {
 		obj.KubeletConfiguration = &kubeletconfigv1beta1.KubeletConfiguration{}
 		SetDefaults_KubeletConfiguration(obj.KubeletConfiguration)
@@ -202,9 +247,8 @@ func SetDefaults_KubeletConfiguration(obj *kubeletconfigv1beta1.KubeletConfigura
 	if obj.CgroupsPerQOS == nil {
 		obj.CgroupsPerQOS = pointer.BoolPtr(true)
 	}
-	if obj.CgroupDriver == "" {
-		obj.CgroupDriver = "cgroupfs"
-	}
+	// CgroupDriver is intentionally not defaulted here, it is set to match the
+	// container runtime when the node is bootstrapped
 	if obj.CPUManagerPolicy == "" {
 		obj.CPUManagerPolicy = "none"
 	}
@@ -0,0 +1,81 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/criticalstack/crit/pkg/config/constants"
+)
+
+// ResolveImage builds a full container image reference from the default image
+// and tag. The image repository replaces everything but the image name of the
+// default image, and any non-empty fields of the override take precedence.
+func ResolveImage(defaultImage, defaultTag, imageRepository string, o ImageOverride) string {
+	repo := defaultImage
+	if imageRepository != "" {
+		repo = strings.TrimSuffix(imageRepository, "/") + "/" + path.Base(defaultImage)
+	}
+	if o.Repository != "" {
+		repo = o.Repository
+	}
+	tag := defaultTag
+	if o.Tag != "" {
+		tag = o.Tag
+	}
+	image := repo
+	if tag != "" {
+		image = fmt.Sprintf("%s:%s", image, tag)
+	}
+	if o.Digest != "" {
+		image = fmt.Sprintf("%s@%s", image, o.Digest)
+	}
+	return image
+}
+
+// Image returns the container image reference for the named component. It is
+// also available to templates, e.g. {{ .Image "coredns" }}.
+func (c *ControlPlaneConfiguration) Image(component string) string {
+	kubeVersion := "v" + c.NodeConfiguration.KubernetesVersion
+	switch component {
+	case constants.KubeAPIServer:
+		return ResolveImage(constants.KubeAPIServerImage, kubeVersion, c.ImageRepository, c.Images.KubeAPIServer)
+	case constants.KubeControllerManager:
+		return ResolveImage(constants.KubeControllerManagerImage, kubeVersion, c.ImageRepository, c.Images.KubeControllerManager)
+	case constants.KubeScheduler:
+		return ResolveImage(constants.KubeSchedulerImage, kubeVersion, c.ImageRepository, c.Images.KubeScheduler)
+	case constants.KubeProxy:
+		return ResolveImage(constants.KubeProxyImage, kubeVersion, c.ImageRepository, c.Images.KubeProxy)
+	case constants.CoreDNS:
+		return ResolveImage(constants.CoreDNSImage, c.CoreDNSVersion, c.ImageRepository, c.Images.CoreDNS)
+	case constants.Pause:
+		return ResolveImage(constants.PauseImage, constants.DefaultPauseImageVersion, c.ImageRepository, c.Images.Pause)
+	case constants.CritBootstrapServer:
+		return ResolveImage(constants.CritBootstrapServerImage, "v"+c.CritBootstrapServerConfiguration.Version, c.ImageRepository, c.Images.CritBootstrapServer)
+	case constants.HealthcheckProxy:
+		return ResolveImage(constants.CritHealthCheckProxyImage, "v"+c.KubeAPIServerConfiguration.HealthcheckProxyVersion, c.ImageRepository, c.Images.HealthcheckProxy)
+	default:
+		return ""
+	}
+}
+
+// Image returns the container image reference for the named component. Only
+// the pause and kube-proxy images are used by worker nodes, and the kube-proxy
+// image is only accurate when the image configuration matches that of the
+// control plane.
+func (c *WorkerConfiguration) Image(component string) string {
+	switch component {
+	case constants.KubeProxy:
+		return ResolveImage(constants.KubeProxyImage, "v"+c.NodeConfiguration.KubernetesVersion, c.ImageRepository, c.Images.KubeProxy)
+	case constants.Pause:
+		return ResolveImage(constants.PauseImage, constants.DefaultPauseImageVersion, c.ImageRepository, c.Images.Pause)
+	default:
+		return ""
+	}
+}
+
+// CustomPauseImage returns true if the pause image has been changed from the
+// default.
+func (ic *ImagesConfiguration) CustomPauseImage(imageRepository string) bool {
+	return imageRepository != "" || ic.Pause != (ImageOverride{})
+}
@@ -0,0 +1,45 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/criticalstack/crit/pkg/config/constants"
+)
+
+func TestResolveImage(t *testing.T) {
+	cases := []struct {
+		name            string
+		imageRepository string
+		override        ImageOverride
+		expected        string
+	}{
+		{
+			name:     "default",
+			expected: "k8s.gcr.io/kube-apiserver:v1.18.5",
+		},
+		{
+			name:            "image repository",
+			imageRepository: "registry.local/k8s/",
+			expected:        "registry.local/k8s/kube-apiserver:v1.18.5",
+		},
+		{
+			name:            "repository override",
+			imageRepository: "registry.local/k8s",
+			override:        ImageOverride{Repository: "example.com/apiserver"},
+			expected:        "example.com/apiserver:v1.18.5",
+		},
+		{
+			name:     "tag and digest override",
+			override: ImageOverride{Tag: "v1.18.6", Digest: "sha256:abc"},
+			expected: "k8s.gcr.io/kube-apiserver:v1.18.6@sha256:abc",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			image := ResolveImage(constants.KubeAPIServerImage, "v1.18.5", tc.imageRepository, tc.override)
+			if image != tc.expected {
+				t.Fatalf("expected %q, received %q", tc.expected, image)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package v1alpha2
+
+import (
+	netutils "k8s.io/utils/net"
+)
+
+// HostIPs returns the host IP addresses of the node, with the IPv4 address
+// first when both are provided.
+func (n *NodeConfiguration) HostIPs() []string {
+	ips := make([]string, 0)
+	for _, ip := range []string{n.HostIPv4, n.HostIPv6} {
+		if ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// HostIP returns the primary host IP address of the node. The IPv4 address is
+// preferred when both are provided.
+func (n *NodeConfiguration) HostIP() string {
+	if n.HostIPv4 != "" {
+		return n.HostIPv4
+	}
+	return n.HostIPv6
+}
+
+// IsDualStack returns true if the cluster has pod or service subnets for
+// both IP families.
+func (c *ControlPlaneConfiguration) IsDualStack() bool {
+	return len(c.PodSubnets) > 1 || len(c.ServiceSubnets) > 1
+}
+
+// AdvertiseAddress returns the host IP address that the apiserver advertises
+// to cluster members. It matches the IP family of the primary service subnet
+// when the node has an address for that family.
+func (c *ControlPlaneConfiguration) AdvertiseAddress() string {
+	if netutils.IsIPv6CIDRString(c.ServiceSubnet) && c.NodeConfiguration.HostIPv6 != "" {
+		return c.NodeConfiguration.HostIPv6
+	}
+	return c.NodeConfiguration.HostIP()
+}
@@ -0,0 +1,54 @@
+package v1alpha2
+
+import "testing"
+
+func TestAdvertiseAddress(t *testing.T) {
+	cases := []struct {
+		name           string
+		serviceSubnets []string
+		hostIPv4       string
+		hostIPv6       string
+		expected       string
+	}{
+		{
+			name:     "ipv4",
+			hostIPv4: "10.0.0.2",
+			expected: "10.0.0.2",
+		},
+		{
+			name:           "ipv6",
+			serviceSubnets: []string{"fd00:10:96::/112"},
+			hostIPv6:       "fd00::2",
+			expected:       "fd00::2",
+		},
+		{
+			name:           "dual-stack ipv4 primary",
+			serviceSubnets: []string{"10.254.0.0/16", "fd00:10:96::/112"},
+			hostIPv4:       "10.0.0.2",
+			hostIPv6:       "fd00::2",
+			expected:       "10.0.0.2",
+		},
+		{
+			name:           "dual-stack ipv6 primary",
+			serviceSubnets: []string{"fd00:10:96::/112", "10.254.0.0/16"},
+			hostIPv4:       "10.0.0.2",
+			hostIPv6:       "fd00::2",
+			expected:       "fd00::2",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := &ControlPlaneConfiguration{
+				ServiceSubnets: c.serviceSubnets,
+				NodeConfiguration: NodeConfiguration{
+					HostIPv4: c.hostIPv4,
+					HostIPv6: c.hostIPv6,
+				},
+			}
+			SetDefaults_ControlPlaneConfiguration(cfg)
+			if addr := cfg.AdvertiseAddress(); addr != c.expected {
+				t.Fatalf("expected %q, received %q", c.expected, addr)
+			}
+		})
+	}
+}
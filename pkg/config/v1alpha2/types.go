@@ -5,6 +5,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
 	kubeproxyconfigv1alpha1 "k8s.io/kube-proxy/config/v1alpha1"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
@@ -39,6 +40,20 @@ type ControlPlaneConfiguration struct {
 	// Default: "10.254.0.0/16"
 	// +optional
 	ServiceSubnet string `json:"serviceSubnet,omitempty"`
+	// PodSubnets is the list of CIDR ranges for allocating pod IP addresses
+	// in a dual-stack cluster, with at most one range per IP family. The
+	// first entry is the primary pod subnet and must match PodSubnet when
+	// both are provided.
+	// Default: [PodSubnet]
+	// +optional
+	PodSubnets []string `json:"podSubnets,omitempty"`
+	// ServiceSubnets is the list of CIDR ranges for allocating service IP
+	// addresses in a dual-stack cluster, with at most one range per IP
+	// family. The first entry is the primary service subnet and determines
+	// the IP family of the apiserver advertise address and cluster DNS.
+	// Default: [ServiceSubnet]
+	// +optional
+	ServiceSubnets []string `json:"serviceSubnets,omitempty"`
 	// CoreDNSVersion is the version given to the CoreDNS template.
 	// Default: "1.6.9"
 	// +optional
@@ -71,6 +86,34 @@ type ControlPlaneConfiguration struct {
 	// crit-bootstrap-server static pod.
 	// +optional
 	CritBootstrapServerConfiguration CritBootstrapServerConfiguration `json:"critBootstrapServer"`
+	// PodSecurity provides configuration for enforcing pod security
+	// standards. Depending upon the Kubernetes version, this is accomplished
+	// either with PodSecurityPolicy or the PodSecurity admission plugin.
+	// +optional
+	PodSecurity PodSecurityConfiguration `json:"podSecurity"`
+	// ImageRepository is the container image repository used for all
+	// component images, replacing the repository of the default images
+	// while keeping the image name (e.g. "registry.local/k8s" results in
+	// "registry.local/k8s/kube-apiserver").
+	// +optional
+	ImageRepository string `json:"imageRepository,omitempty"`
+	// Images provides overrides for the container images of individual
+	// components.
+	// +optional
+	Images ImagesConfiguration `json:"images,omitempty"`
+	// Addons is a list of addons that are applied, in order, once the control
+	// plane is available.
+	// +optional
+	Addons []AddonConfiguration `json:"addons,omitempty"`
+	// ControlPlaneNode provides configuration for the taints and labels that
+	// mark the node as a control plane node.
+	// +optional
+	ControlPlaneNode ControlPlaneNodeConfiguration `json:"controlPlaneNode,omitempty"`
+	// NodeOverrides patch NodeConfiguration for the nodes they match, so that
+	// a single config can be shared by nodes that need host-specific
+	// settings. Every matching override is applied, in order.
+	// +optional
+	NodeOverrides []NodeOverride `json:"nodeOverrides,omitempty"`
 	// NodeConfiguration provides configuration for the particular node being
 	// bootstrapped. This includes host-specific information, such as hostname
 	// or IP address, as well as, kubelet configuration.
@@ -109,12 +152,66 @@ type WorkerConfiguration struct {
 	// provided during bootstrapping because it is used to verify that the
 	// control plane being joined by the worker.
 	CACert string `json:"caCert,omitempty"`
+	// ImageRepository is the container image repository used for the pause
+	// image.
+	// +optional
+	ImageRepository string `json:"imageRepository,omitempty"`
+	// Images provides overrides for the container images used by the node.
+	// Only the pause and kube-proxy images are used by worker nodes.
+	// +optional
+	Images ImagesConfiguration `json:"images,omitempty"`
+	// NodeOverrides patch NodeConfiguration for the nodes they match, so that
+	// a single config can be shared by nodes that need host-specific
+	// settings. Every matching override is applied, in order.
+	// +optional
+	NodeOverrides []NodeOverride `json:"nodeOverrides,omitempty"`
 	// NodeConfiguration provides configuration for the particular node being
 	// bootstrapped. This includes host-specific information, such as hostname
 	// or IP address, as well as, kubelet configuration.
 	NodeConfiguration NodeConfiguration `json:"node"`
 }
 
+// NodeOverride is a partial NodeConfiguration applied to the nodes matching
+// all of its selectors. At least one selector must be provided.
+type NodeOverride struct {
+	// Hostname matches the hostname of the node, and can be a shell file name
+	// pattern, such as "worker-*".
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+	// CIDR matches nodes with a network interface address within the CIDR
+	// range.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+	// InstanceTags matches nodes whose cloud instance has all of these tags.
+	// Tags are read from the AWS EC2 instance metadata service, which
+	// requires instance tags to be allowed in instance metadata.
+	// +optional
+	InstanceTags map[string]string `json:"instanceTags,omitempty"`
+	// NodeConfiguration is merged into the NodeConfiguration of matching nodes
+	// as a strategic merge patch, so only the fields being changed need to be
+	// provided.
+	NodeConfiguration runtime.RawExtension `json:"node"`
+}
+
+// ControlPlaneNodeConfiguration is the taints and labels applied to control
+// plane nodes once the control plane is available. The
+// node-role.kubernetes.io/control-plane label is always applied.
+type ControlPlaneNodeConfiguration struct {
+	// Taints is the taints applied to control plane nodes, usually to prevent
+	// workloads from being scheduled on them. An empty list applies no
+	// taints, which is useful for single-node clusters. The taints in
+	// NodeConfiguration are applied in addition to these.
+	// Default: [{"key": "node-role.kubernetes.io/master", "effect": "NoSchedule"}]
+	// +optional
+	Taints []corev1.Taint `json:"taints"`
+	// LegacyMasterLabel determines if the deprecated
+	// node-role.kubernetes.io/master label is applied alongside the
+	// node-role.kubernetes.io/control-plane label.
+	// Default: true
+	// +optional
+	LegacyMasterLabel *bool `json:"legacyMasterLabel,omitempty"`
+}
+
 type NodeConfiguration struct {
 	// KubernetesVersion is the version of Kubernetes for this node.
 	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
@@ -125,14 +222,24 @@ type NodeConfiguration struct {
 	// KubeDir is the base directory for important Kubernetes configuration
 	// files (manifests, configuration files, pki, etc). It is unlikely this
 	// will ever need to be changed.
-	// Default: "/etc/kubernetes
+	// Default: "/etc/kubernetes"
 	// +optional
 	KubeDir string `json:"kubeDir,omitempty"`
 	// HostIPv4 is the IPv4 address of the host for the node being
-	// bootstrapped. If this is not provided the first non-loopback network
-	// adapter address is used.
+	// bootstrapped. If this is not provided the address is selected using
+	// NodeAddress.
 	// +optional
 	HostIPv4 string `json:"hostIPv4,omitempty"`
+	// HostIPv6 is the IPv6 address of the host for the node being
+	// bootstrapped. This is required for IPv6-only and dual-stack clusters,
+	// and if this is not provided for a control plane node with an IPv6
+	// subnet, the address is selected using NodeAddress.
+	// +optional
+	HostIPv6 string `json:"hostIPv6,omitempty"`
+	// NodeAddress determines how HostIPv4 and HostIPv6 are selected when they
+	// are not provided.
+	// +optional
+	NodeAddress NodeAddressConfiguration `json:"nodeAddress,omitempty"`
 	// CloudProvider is used to configured in-tree cloud providers.
 	// +optional
 	CloudProvider string `json:"cloudProvider,omitempty"`
@@ -144,6 +251,16 @@ type NodeConfiguration struct {
 	// bootstrapping.
 	// +optional
 	Taints []corev1.Taint `json:"taints,omitempty"`
+	// Labels is any labels to be applied to the node. Labels allowed by the
+	// NodeRestriction admission plugin are set by the kubelet when the node
+	// registers, while restricted labels, such as node-role.kubernetes.io/*,
+	// are applied after the node has registered.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations is any annotations to be applied to the node after it has
+	// registered.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 	// KubeletConfiguration is the component config for the kubelet. There are
 	// quite a few defaults that are being set that can be found in defaults.go
 	// of this package.
@@ -157,6 +274,31 @@ type NodeConfiguration struct {
 	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
 }
 
+// NodeAddressConfiguration is the policy used to select the host address
+// from the network interfaces of the host.
+type NodeAddressConfiguration struct {
+	// Policy is the rule used to select the host address, and can be one of:
+	// "DefaultRoute" to use the interface holding the default route,
+	// "Interface" to use the interface matching Interface, "CIDR" to use the
+	// first address contained by CIDRs, or "FirstInterface" to use the first
+	// non-loopback interface. The "DefaultRoute" policy falls back to
+	// "FirstInterface" when the host does not have a default route.
+	// Default: "DefaultRoute"
+	// +optional
+	Policy string `json:"policy,omitempty"`
+	// Interface is the name of the network interface used by the "Interface"
+	// policy.
+	// +optional
+	Interface string `json:"interface,omitempty"`
+	// CIDRs are the CIDR ranges used by the "CIDR" policy.
+	// +optional
+	CIDRs []string `json:"cidrs,omitempty"`
+	// ExcludeVirtual prevents virtual network interfaces, such as bridges
+	// (e.g. docker0), veth pairs and tunnels, from being selected.
+	// +optional
+	ExcludeVirtual bool `json:"excludeVirtual,omitempty"`
+}
+
 type EtcdConfiguration struct {
 	Endpoints []string `json:"endpoints,omitempty"`
 	CAFile    string   `json:"caFile,omitempty"`
@@ -193,6 +335,38 @@ type KubeAPIServerConfiguration struct {
 	HealthcheckProxyVersion  string                   `json:"healthcheckProxyVersion,omitempty"`
 	HealthcheckProxyBindPort int                      `json:"healthcheckProxyBindPort,omitempty"`
 	ExtraLabels              map[string]string        `json:"extraLabels,omitempty"`
+	OIDC                     *OIDCConfiguration       `json:"oidc,omitempty"`
+}
+
+// OIDCConfiguration configures the apiserver to authenticate users with an
+// OpenID Connect provider.
+type OIDCConfiguration struct {
+	// IssuerURL is the URL of the OpenID issuer. Only the https scheme is
+	// accepted.
+	IssuerURL string `json:"issuerURL"`
+	// ClientID is the client ID for the OpenID Connect client, all tokens
+	// must be issued for this client ID.
+	ClientID string `json:"clientID"`
+	// UsernameClaim is the OpenID claim to use as the username.
+	// Default: "sub"
+	// +optional
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// UsernamePrefix is prepended to username claims to prevent clashes with
+	// existing names.
+	// +optional
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+	// GroupsClaim is the OpenID claim to use as the user's groups.
+	// +optional
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+	// GroupsPrefix is prepended to group claims to prevent clashes with
+	// existing names.
+	// +optional
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+	// CAFile is the full file path of the CA certificate used to verify the
+	// OpenID server's certificate. If not provided, the host's root CAs are
+	// used.
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
 }
 
 type KubeControllerManagerConfiguration struct {
@@ -209,6 +383,78 @@ type KubeSchedulerConfiguration struct {
 	ExtraLabels  map[string]string        `json:"extraLabels,omitempty"`
 }
 
+type PodSecurityConfiguration struct {
+	// Enabled turns on pod security enforcement. For Kubernetes versions that
+	// support the PodSecurity admission plugin (v1.23+), an admission
+	// configuration file is written and provided to the apiserver. For older
+	// versions, the privileged and restricted PodSecurityPolicies are
+	// applied along with the RBAC needed to use them.
+	// +optional
+	Enabled bool `json:"enabled"`
+	// DefaultLevel is the pod security standard level (privileged, baseline,
+	// restricted) applied to any namespace not found in Namespaces. The
+	// baseline level is not available with PodSecurityPolicy and is treated
+	// as restricted.
+	// Default: "restricted"
+	// +optional
+	DefaultLevel string `json:"defaultLevel,omitempty"`
+	// Namespaces is a map of namespace names to the pod security standard
+	// level for that namespace.
+	// Default: {"kube-system": "privileged", "kube-node-lease": "privileged", "kube-public": "privileged"}
+	// +optional
+	Namespaces map[string]string `json:"namespaces,omitempty"`
+}
+
+// ImageOverride overrides parts of a component container image reference.
+type ImageOverride struct {
+	// Repository is the full image repository, without tag or digest (e.g.
+	// "registry.local/coredns").
+	// +optional
+	Repository string `json:"repository,omitempty"`
+	// Tag is the image tag.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// Digest is the image digest (e.g. "sha256:...").
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+type ImagesConfiguration struct {
+	KubeAPIServer         ImageOverride `json:"kubeAPIServer,omitempty"`
+	KubeControllerManager ImageOverride `json:"kubeControllerManager,omitempty"`
+	KubeScheduler         ImageOverride `json:"kubeScheduler,omitempty"`
+	KubeProxy             ImageOverride `json:"kubeProxy,omitempty"`
+	CoreDNS               ImageOverride `json:"coreDNS,omitempty"`
+	Pause                 ImageOverride `json:"pause,omitempty"`
+	CritBootstrapServer   ImageOverride `json:"critBootstrapServer,omitempty"`
+	HealthcheckProxy      ImageOverride `json:"healthcheckProxy,omitempty"`
+}
+
+type AddonConfiguration struct {
+	// Name is the name of an addon template embedded in crit. Available
+	// addons can be found with `crit addons list`. Either Name or Path must
+	// be provided.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Path is the full file path of a local manifest file, or a directory
+	// containing manifest files. Manifests are rendered as templates in the
+	// same way as embedded addons.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Values is a map of values provided to the addon template as .Values.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+	// SkipWait disables waiting for the workloads in an addon to become ready
+	// before continuing to the next addon.
+	// +optional
+	SkipWait bool `json:"skipWait,omitempty"`
+	// Timeout is the amount of time to wait for the workloads in an addon to
+	// become ready.
+	// Default: "5m"
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
 type KubeProxyConfiguration struct {
 	// NOTE(chrism): KubeProxyConfiguration defines fields using types from
 	// component-base. These contain float values and the package
@@ -42,3 +42,52 @@ func TestAPIEndpointUnmarshal(t *testing.T) {
 		t.Fatalf("APIEndpoint unmarshaled incorrectly: %v", cfg.ControlPlaneEndpoint.Port)
 	}
 }
+
+func TestControlPlaneNodeTaintsDefault(t *testing.T) {
+	obj, err := yamlutil.UnmarshalFromYaml([]byte(apiEndpointString), SchemeGroupVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := obj.(*ControlPlaneConfiguration)
+	SetDefaults_ControlPlaneConfiguration(cfg)
+	if len(cfg.ControlPlaneNode.Taints) != 1 || cfg.ControlPlaneNode.Taints[0].Key != "node-role.kubernetes.io/master" {
+		t.Fatalf("expected default control plane taint, received %v", cfg.ControlPlaneNode.Taints)
+	}
+	if cfg.ControlPlaneNode.LegacyMasterLabel == nil || !*cfg.ControlPlaneNode.LegacyMasterLabel {
+		t.Fatal("expected legacy master label to be enabled by default")
+	}
+
+	obj, err = yamlutil.UnmarshalFromYaml([]byte(apiEndpointString+"\ncontrolPlaneNode:\n  taints: []"), SchemeGroupVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ = obj.(*ControlPlaneConfiguration)
+	SetDefaults_ControlPlaneConfiguration(cfg)
+	if cfg.ControlPlaneNode.Taints == nil || len(cfg.ControlPlaneNode.Taints) != 0 {
+		t.Fatalf("expected no control plane taints, received %v", cfg.ControlPlaneNode.Taints)
+	}
+}
+
+func TestNodeOverridesUnmarshal(t *testing.T) {
+	obj, err := yamlutil.UnmarshalFromYaml([]byte(apiEndpointString+`
+nodeOverrides:
+- hostname: "cp-*"
+  cidr: 10.0.0.0/16
+  node:
+    kubelet:
+      maxPods: 50`), SchemeGroupVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := obj.(*ControlPlaneConfiguration)
+	if len(cfg.NodeOverrides) != 1 {
+		t.Fatalf("expected 1 node override, received %d", len(cfg.NodeOverrides))
+	}
+	o := cfg.NodeOverrides[0]
+	if o.Hostname != "cp-*" || o.CIDR != "10.0.0.0/16" {
+		t.Fatalf("NodeOverride unmarshaled incorrectly: %+v", o)
+	}
+	if string(o.NodeConfiguration.Raw) != `{"kubelet":{"maxPods":50}}` {
+		t.Fatalf("expected node patch to be kept as-is, received %s", o.NodeConfiguration.Raw)
+	}
+}
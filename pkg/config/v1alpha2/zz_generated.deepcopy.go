@@ -28,11 +28,45 @@ import (
 	v1beta1 "k8s.io/kubelet/config/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonConfiguration) DeepCopyInto(out *AddonConfiguration) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Timeout = in.Timeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonConfiguration.
+func (in *AddonConfiguration) DeepCopy() *AddonConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.PodSubnets != nil {
+		in, out := &in.PodSubnets, &out.PodSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceSubnets != nil {
+		in, out := &in.ServiceSubnets, &out.ServiceSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
 		*out = make(map[string]bool, len(*in))
@@ -46,6 +80,23 @@ func (in *ControlPlaneConfiguration) DeepCopyInto(out *ControlPlaneConfiguration
 	in.KubeSchedulerConfiguration.DeepCopyInto(&out.KubeSchedulerConfiguration)
 	in.KubeProxyConfiguration.DeepCopyInto(&out.KubeProxyConfiguration)
 	in.CritBootstrapServerConfiguration.DeepCopyInto(&out.CritBootstrapServerConfiguration)
+	in.PodSecurity.DeepCopyInto(&out.PodSecurity)
+	out.Images = in.Images
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]AddonConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ControlPlaneNode.DeepCopyInto(&out.ControlPlaneNode)
+	if in.NodeOverrides != nil {
+		in, out := &in.NodeOverrides, &out.NodeOverrides
+		*out = make([]NodeOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.NodeConfiguration.DeepCopyInto(&out.NodeConfiguration)
 	return
 }
@@ -68,6 +119,34 @@ func (in *ControlPlaneConfiguration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneNodeConfiguration) DeepCopyInto(out *ControlPlaneNodeConfiguration) {
+	*out = *in
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LegacyMasterLabel != nil {
+		in, out := &in.LegacyMasterLabel, &out.LegacyMasterLabel
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneNodeConfiguration.
+func (in *ControlPlaneNodeConfiguration) DeepCopy() *ControlPlaneNodeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneNodeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CritBootstrapServerConfiguration) DeepCopyInto(out *CritBootstrapServerConfiguration) {
 	*out = *in
@@ -112,6 +191,46 @@ func (in *EtcdConfiguration) DeepCopy() *EtcdConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverride) DeepCopyInto(out *ImageOverride) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverride.
+func (in *ImageOverride) DeepCopy() *ImageOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagesConfiguration) DeepCopyInto(out *ImagesConfiguration) {
+	*out = *in
+	out.KubeAPIServer = in.KubeAPIServer
+	out.KubeControllerManager = in.KubeControllerManager
+	out.KubeScheduler = in.KubeScheduler
+	out.KubeProxy = in.KubeProxy
+	out.CoreDNS = in.CoreDNS
+	out.Pause = in.Pause
+	out.CritBootstrapServer = in.CritBootstrapServer
+	out.HealthcheckProxy = in.HealthcheckProxy
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagesConfiguration.
+func (in *ImagesConfiguration) DeepCopy() *ImagesConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagesConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeAPIServerConfiguration) DeepCopyInto(out *KubeAPIServerConfiguration) {
 	*out = *in
@@ -146,6 +265,11 @@ func (in *KubeAPIServerConfiguration) DeepCopyInto(out *KubeAPIServerConfigurati
 			(*out)[key] = val
 		}
 	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCConfiguration)
+		**out = **in
+	}
 	return
 }
 
@@ -276,9 +400,31 @@ func (in *KubeSchedulerConfiguration) DeepCopy() *KubeSchedulerConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAddressConfiguration) DeepCopyInto(out *NodeAddressConfiguration) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressConfiguration.
+func (in *NodeAddressConfiguration) DeepCopy() *NodeAddressConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAddressConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeConfiguration) DeepCopyInto(out *NodeConfiguration) {
 	*out = *in
+	in.NodeAddress.DeepCopyInto(&out.NodeAddress)
 	if in.Taints != nil {
 		in, out := &in.Taints, &out.Taints
 		*out = make([]v1.Taint, len(*in))
@@ -286,6 +432,20 @@ func (in *NodeConfiguration) DeepCopyInto(out *NodeConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.KubeletConfiguration != nil {
 		in, out := &in.KubeletConfiguration, &out.KubeletConfiguration
 		*out = new(v1beta1.KubeletConfiguration)
@@ -311,6 +471,69 @@ func (in *NodeConfiguration) DeepCopy() *NodeConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeOverride) DeepCopyInto(out *NodeOverride) {
+	*out = *in
+	if in.InstanceTags != nil {
+		in, out := &in.InstanceTags, &out.InstanceTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.NodeConfiguration.DeepCopyInto(&out.NodeConfiguration)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeOverride.
+func (in *NodeOverride) DeepCopy() *NodeOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfiguration) DeepCopyInto(out *OIDCConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfiguration.
+func (in *OIDCConfiguration) DeepCopy() *OIDCConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityConfiguration) DeepCopyInto(out *PodSecurityConfiguration) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityConfiguration.
+func (in *PodSecurityConfiguration) DeepCopy() *PodSecurityConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerConfiguration) DeepCopyInto(out *WorkerConfiguration) {
 	*out = *in
@@ -323,6 +546,14 @@ func (in *WorkerConfiguration) DeepCopyInto(out *WorkerConfiguration) {
 			(*out)[key] = val
 		}
 	}
+	out.Images = in.Images
+	if in.NodeOverrides != nil {
+		in, out := &in.NodeOverrides, &out.NodeOverrides
+		*out = make([]NodeOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.NodeConfiguration.DeepCopyInto(&out.NodeConfiguration)
 	return
 }
@@ -45,6 +45,27 @@ func NewForClient(serverURL, clusterName, userName string, ca, cert, key []byte)
 	return config
 }
 
+// NewForOIDC creates a kubeconfig with a user that authenticates using the
+// kubectl oidc-login exec credential plugin
+// (https://github.com/int128/kubelogin).
+func NewForOIDC(serverURL, clusterName, userName string, ca []byte, issuerURL, clientID string, extraArgs ...string) *clientcmdapi.Config {
+	config := New(serverURL, clusterName, userName, ca)
+	args := []string{
+		"oidc-login",
+		"get-token",
+		fmt.Sprintf("--oidc-issuer-url=%s", issuerURL),
+		fmt.Sprintf("--oidc-client-id=%s", clientID),
+	}
+	config.AuthInfos[userName] = &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion: "client.authentication.k8s.io/v1beta1",
+			Command:    "kubectl",
+			Args:       append(args, extraArgs...),
+		},
+	}
+	return config
+}
+
 func WriteToFile(config *clientcmdapi.Config, path string) error {
 	return clientcmd.WriteToFile(*config, path)
 }
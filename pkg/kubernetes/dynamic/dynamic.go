@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	clientsetscheme "k8s.io/client-go/kubernetes/scheme"
@@ -88,6 +89,28 @@ func (c *client) Update(ctx context.Context, v interface{}) (*unstructured.Unstr
 	return c.getDynamicResource(obj.GetNamespace(), mapping.Scope, mapping.Resource).Update(ctx, obj, metav1.UpdateOptions{})
 }
 
+// ServerSideApply applies the object using server-side apply, creating it if
+// it does not exist and otherwise updating the fields owned by fieldManager to
+// match.
+func (c *client) ServerSideApply(ctx context.Context, obj *unstructured.Unstructured, fieldManager string) (*unstructured.Unstructured, error) {
+	mapping, err := c.getMapping(obj)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameRoot && obj.GetNamespace() == "" {
+		obj.SetNamespace(metav1.NamespaceSystem)
+	}
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	force := true
+	return c.getDynamicResource(obj.GetNamespace(), mapping.Scope, mapping.Resource).Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        &force,
+	})
+}
+
 func convertUnstructuredObject(v interface{}) (*unstructured.Unstructured, error) {
 	switch t := v.(type) {
 	case runtime.Object:
@@ -124,3 +147,24 @@ func Apply(ctx context.Context, config *rest.Config, data []byte) error {
 	}
 	return nil
 }
+
+// Reconcile applies the objects found in data using server-side apply, so
+// that objects which already exist are updated to match the provided
+// manifests. Unlike Apply, any error, including an invalid object, is
+// returned.
+func Reconcile(ctx context.Context, config *rest.Config, fieldManager string, data []byte) error {
+	client, err := newClient(config)
+	if err != nil {
+		return err
+	}
+	objs, err := yamlutil.UnmarshalFromYamlUnstructured(data)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		if _, err := client.ServerSideApply(ctx, obj, fieldManager); err != nil {
+			return errors.Wrapf(err, "cannot apply %s %q", obj.GetKind(), obj.GetName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package kubernetes
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// UpdateNamespaceLabels ensures that the namespace exists and that the
+// provided labels are set. Any existing labels are left unchanged.
+func UpdateNamespaceLabels(k *kubernetes.Clientset, ctx context.Context, name string, labels map[string]string) error {
+	ns, err := k.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err := k.CoreV1().Namespaces().Create(ctx, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if ns.Labels == nil {
+		ns.Labels = make(map[string]string)
+	}
+	for key, val := range labels {
+		ns.Labels[key] = val
+	}
+	_, err = k.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,79 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/pkg/errors"
+
+	"github.com/criticalstack/crit/pkg/config/constants"
+	executil "github.com/criticalstack/crit/pkg/util/exec"
+)
+
+// ContainerdNamespace is the containerd namespace used by the CRI plugin.
+const ContainerdNamespace = "k8s.io"
+
+// The CRI does not provide a way to export or import images, so the container
+// runtime tooling is used directly. For containerd this produces an OCI image
+// layout tarball (the same format as `ctr images export`), while docker
+// produces a docker save archive that also includes an OCI index.
+func archiveCommand(ctx context.Context, cr constants.ContainerRuntime, args ...string) (*exec.Cmd, error) {
+	switch cr {
+	case constants.Containerd:
+		return exec.CommandContext(ctx, "ctr", append([]string{"--namespace", ContainerdNamespace}, args...)...), nil
+	case constants.Docker:
+		return exec.CommandContext(ctx, "docker", args...), nil
+	default:
+		return nil, errors.Errorf("image archives are not supported for container runtime: %q", cr)
+	}
+}
+
+func runArchiveCommand(cmd *exec.Cmd) error {
+	stdout := executil.NewPrefixWriter(os.Stdout, "\t")
+	defer stdout.Close()
+
+	stderr := executil.NewPrefixWriter(os.Stderr, "\t")
+	defer stderr.Close()
+
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
+
+// ExportImages writes an image bundle tarball to path containing all of the
+// provided images. The images must already be present in the container
+// runtime.
+func ExportImages(ctx context.Context, cr constants.ContainerRuntime, path string, images []string) error {
+	var args []string
+	switch cr {
+	case constants.Containerd:
+		args = append([]string{"images", "export", "--platform", fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH), path}, images...)
+	case constants.Docker:
+		args = append([]string{"save", "--output", path}, images...)
+	}
+	cmd, err := archiveCommand(ctx, cr, args...)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(runArchiveCommand(cmd), "cannot export images")
+}
+
+// ImportImages loads all images from an image bundle tarball into the
+// container runtime.
+func ImportImages(ctx context.Context, cr constants.ContainerRuntime, path string) error {
+	var args []string
+	switch cr {
+	case constants.Containerd:
+		args = []string{"images", "import", "--all-platforms", path}
+	case constants.Docker:
+		args = []string{"load", "--input", path}
+	}
+	cmd, err := archiveCommand(ctx, cr, args...)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(runArchiveCommand(cmd), "cannot import images")
+}
@@ -0,0 +1,123 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	"github.com/criticalstack/crit/pkg/config/constants"
+)
+
+const (
+	CgroupDriverSystemd  = "systemd"
+	CgroupDriverCgroupfs = "cgroupfs"
+)
+
+// containerdConfig is the subset of the containerd CRI plugin configuration
+// returned in the verbose runtime status.
+type containerdConfig struct {
+	Containerd struct {
+		DefaultRuntimeName string `json:"defaultRuntimeName"`
+		Runtimes           map[string]struct {
+			Options map[string]interface{} `json:"options"`
+		} `json:"runtimes"`
+	} `json:"containerd"`
+
+	// SystemdCgroup is only used by the deprecated runtime v1 shim.
+	SystemdCgroup bool `json:"systemdCgroup"`
+}
+
+// CgroupDriver returns the cgroup driver of the container runtime using the
+// verbose runtime status. Only containerd includes its configuration in the
+// runtime status, so an empty string is returned if the cgroup driver cannot
+// be determined.
+func (r *RuntimeServiceClient) CgroupDriver(ctx context.Context) (string, error) {
+	resp, err := r.Status(ctx, &runtimeapi.StatusRequest{Verbose: true})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	data, ok := resp.Info["config"]
+	if !ok {
+		return "", nil
+	}
+	var cfg containerdConfig
+	if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+		return "", errors.Wrap(err, "cannot parse runtime status config")
+	}
+	if cfg.SystemdCgroup {
+		return CgroupDriverSystemd, nil
+	}
+	rt, ok := cfg.Containerd.Runtimes[cfg.Containerd.DefaultRuntimeName]
+	if !ok || rt.Options == nil {
+		return "", nil
+	}
+	if v, ok := rt.Options["SystemdCgroup"].(bool); ok && v {
+		return CgroupDriverSystemd, nil
+	}
+	return CgroupDriverCgroupfs, nil
+}
+
+// getInfo decodes the response of an HTTP GET request to the unix socket of
+// the container runtime.
+func getInfo(ctx context.Context, endpoint, path string, v interface{}) error {
+	addr := strings.TrimPrefix(endpoint, "unix://")
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dial(ctx, addr)
+			},
+		},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost"+path, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("%s%s returned status %d", endpoint, path, resp.StatusCode)
+	}
+	return errors.WithStack(json.NewDecoder(resp.Body).Decode(v))
+}
+
+// RuntimeCgroupDriver queries the container runtime for the cgroup driver it
+// is using. The CRI runtime status is used for containerd, while docker and
+// CRI-O provide the cgroup driver from their info endpoints. An empty string
+// is returned if the cgroup driver cannot be determined.
+func RuntimeCgroupDriver(ctx context.Context, cr constants.ContainerRuntime) (string, error) {
+	switch cr {
+	case constants.Containerd:
+		r, err := NewRuntimeServiceClient(ctx, cr.CRISocket())
+		if err != nil {
+			return "", err
+		}
+		return r.CgroupDriver(ctx)
+	case constants.Docker:
+		var info struct {
+			CgroupDriver string `json:"CgroupDriver"`
+		}
+		if err := getInfo(ctx, cr.CRISocket(), "/info", &info); err != nil {
+			return "", err
+		}
+		return info.CgroupDriver, nil
+	case constants.CRIO:
+		var info struct {
+			CgroupDriver string `json:"cgroup_driver"`
+		}
+		if err := getInfo(ctx, cr.CRISocket(), "/info", &info); err != nil {
+			return "", err
+		}
+		return info.CgroupDriver, nil
+	default:
+		return "", errors.Errorf("unknown container runtime: %q", cr)
+	}
+}
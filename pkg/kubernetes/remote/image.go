@@ -0,0 +1,54 @@
+package remote
+
+import (
+	"context"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+)
+
+type ImageServiceClient struct {
+	runtimeapi.ImageServiceClient
+}
+
+func NewImageServiceClient(ctx context.Context, endpoint string) (*ImageServiceClient, error) {
+	conn, err := newConn(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	i := &ImageServiceClient{
+		ImageServiceClient: runtimeapi.NewImageServiceClient(conn),
+	}
+	return i, nil
+}
+
+// GetImages returns all images known to the container runtime.
+func (i *ImageServiceClient) GetImages(ctx context.Context) ([]*runtimeapi.Image, error) {
+	resp, err := i.ListImages(ctx, &runtimeapi.ListImagesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Images, nil
+}
+
+// IsImagePresent checks if the image is present in the container runtime.
+func (i *ImageServiceClient) IsImagePresent(ctx context.Context, image string) (bool, error) {
+	resp, err := i.ImageStatus(ctx, &runtimeapi.ImageStatusRequest{
+		Image: &runtimeapi.ImageSpec{Image: image},
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Image != nil, nil
+}
+
+// Pull pulls the image with the container runtime, returning the image
+// reference (usually the image digest) reported by the container runtime.
+func (i *ImageServiceClient) Pull(ctx context.Context, image string) (string, error) {
+	resp, err := i.PullImage(ctx, &runtimeapi.PullImageRequest{
+		Image: &runtimeapi.ImageSpec{Image: image},
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ImageRef, nil
+}
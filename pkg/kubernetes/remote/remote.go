@@ -5,6 +5,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net"
+	"path"
 	"strings"
 
 	"github.com/hpcloud/tail"
@@ -14,6 +15,7 @@ import (
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 
 	"github.com/criticalstack/crit/pkg/log"
+	"github.com/criticalstack/crit/pkg/trace"
 )
 
 func dial(ctx context.Context, addr string) (net.Conn, error) {
@@ -24,9 +26,30 @@ type RuntimeServiceClient struct {
 	runtimeapi.RuntimeServiceClient
 }
 
-func NewRuntimeServiceClient(ctx context.Context, endpoint string) (*RuntimeServiceClient, error) {
+func newConn(ctx context.Context, endpoint string) (*grpc.ClientConn, error) {
 	addr := strings.TrimPrefix(endpoint, "unix://")
-	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithContextDialer(dial))
+	return grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithContextDialer(dial), grpc.WithUnaryInterceptor(traceUnary))
+}
+
+// traceUnary records a span for each CRI call. Method names have the form
+// /runtime.v1alpha2.RuntimeService/ListContainers.
+func traceUnary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	name := strings.TrimPrefix(method, "/")
+	service, rpc := path.Split(name)
+	ctx, span := trace.Start(ctx, name, trace.SpanKindClient,
+		trace.String("rpc.system", "grpc"),
+		trace.String("rpc.service", strings.TrimSuffix(service, "/")),
+		trace.String("rpc.method", rpc),
+	)
+	defer span.Finish()
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	span.SetError(err)
+	return err
+}
+
+func NewRuntimeServiceClient(ctx context.Context, endpoint string) (*RuntimeServiceClient, error) {
+	conn, err := newConn(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +76,31 @@ func (r *RuntimeServiceClient) GetContainerByName(ctx context.Context, name stri
 	return resp.Containers[0], nil
 }
 
+// GetLatestContainerByName returns the most recently created container with
+// the provided name, including containers that have exited.
+func (r *RuntimeServiceClient) GetLatestContainerByName(ctx context.Context, name string) (*runtimeapi.Container, error) {
+	resp, err := r.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+		Filter: &runtimeapi.ContainerFilter{
+			LabelSelector: map[string]string{
+				"io.kubernetes.container.name": name,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var latest *runtimeapi.Container
+	for _, c := range resp.Containers {
+		if latest == nil || c.CreatedAt > latest.CreatedAt {
+			latest = c
+		}
+	}
+	if latest == nil {
+		return nil, errors.Errorf("cannot find container: %q", name)
+	}
+	return latest, nil
+}
+
 func (r *RuntimeServiceClient) GetContainerStatus(ctx context.Context, id string) (*runtimeapi.ContainerStatus, error) {
 	resp, err := r.ContainerStatus(ctx, &runtimeapi.ContainerStatusRequest{
 		ContainerId: id,
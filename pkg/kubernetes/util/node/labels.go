@@ -0,0 +1,101 @@
+package node
+
+import (
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// kubeletLabels are the labels in the kubernetes.io and k8s.io namespaces
+// that the NodeRestriction admission plugin allows the kubelet to set.
+//
+// Copied from github.com/kubernetes/kubernetes/pkg/kubelet/apis
+var kubeletLabels = map[string]bool{
+	v1.LabelHostname:                true,
+	v1.LabelZoneFailureDomainStable: true,
+	v1.LabelZoneRegionStable:        true,
+	v1.LabelZoneFailureDomain:       true,
+	v1.LabelZoneRegion:              true,
+	v1.LabelInstanceType:            true,
+	v1.LabelInstanceTypeStable:      true,
+	v1.LabelOSStable:                true,
+	v1.LabelArchStable:              true,
+	"beta.kubernetes.io/os":         true,
+	"beta.kubernetes.io/arch":       true,
+}
+
+// kubeletLabelNamespaces are the label namespaces, within the kubernetes.io
+// and k8s.io namespaces, that the kubelet is allowed to set.
+var kubeletLabelNamespaces = []string{
+	"kubelet.kubernetes.io",
+	"node.kubernetes.io",
+}
+
+// IsRestrictedLabel returns true if the label key cannot be set by the
+// kubelet when the NodeRestriction admission plugin is enabled. Restricted
+// labels, such as node-role.kubernetes.io/*, must be applied to the Node
+// after registration by a client with permission to update Nodes.
+func IsRestrictedLabel(key string) bool {
+	if kubeletLabels[key] {
+		return false
+	}
+	namespace := ""
+	if parts := strings.SplitN(key, "/", 2); len(parts) == 2 {
+		namespace = parts[0]
+	}
+	for _, ns := range kubeletLabelNamespaces {
+		if namespace == ns || strings.HasSuffix(namespace, "."+ns) {
+			return false
+		}
+	}
+	for _, ns := range []string{"kubernetes.io", "k8s.io"} {
+		if namespace == ns || strings.HasSuffix(namespace, "."+ns) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitLabels separates labels that the kubelet is allowed to set from those
+// that must be applied after the Node has registered.
+func SplitLabels(labels map[string]string) (allowed, restricted map[string]string) {
+	allowed = make(map[string]string)
+	restricted = make(map[string]string)
+	for k, v := range labels {
+		if IsRestrictedLabel(k) {
+			restricted[k] = v
+			continue
+		}
+		allowed[k] = v
+	}
+	return allowed, restricted
+}
+
+// FormatLabels returns labels in the format used by the kubelet node-labels
+// flag, sorted by key.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// SetLabelsAndAnnotations adds the provided labels and annotations to the
+// Node, overwriting any existing values.
+func SetLabelsAndAnnotations(n *v1.Node, labels, annotations map[string]string) {
+	if len(labels) > 0 && n.ObjectMeta.Labels == nil {
+		n.ObjectMeta.Labels = make(map[string]string)
+	}
+	for k, v := range labels {
+		n.ObjectMeta.Labels[k] = v
+	}
+	if len(annotations) > 0 && n.ObjectMeta.Annotations == nil {
+		n.ObjectMeta.Annotations = make(map[string]string)
+	}
+	for k, v := range annotations {
+		n.ObjectMeta.Annotations[k] = v
+	}
+}
@@ -0,0 +1,49 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsRestrictedLabel(t *testing.T) {
+	cases := []struct {
+		key        string
+		restricted bool
+	}{
+		{"kubernetes.io/hostname", false},
+		{"topology.kubernetes.io/zone", false},
+		{"node.kubernetes.io/instance-type", false},
+		{"node.kubernetes.io/custom", false},
+		{"example.kubelet.kubernetes.io/custom", false},
+		{"example.com/custom", false},
+		{"custom", false},
+		{"node-role.kubernetes.io/worker", true},
+		{"node-role.kubernetes.io/master", true},
+		{"kubernetes.io/custom", true},
+		{"example.k8s.io/custom", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.key, func(t *testing.T) {
+			if got := IsRestrictedLabel(tc.key); got != tc.restricted {
+				t.Fatalf("expected %v, received %v", tc.restricted, got)
+			}
+		})
+	}
+}
+
+func TestSplitLabels(t *testing.T) {
+	allowed, restricted := SplitLabels(map[string]string{
+		"example.com/team":               "a",
+		"node-role.kubernetes.io/worker": "",
+		"topology.kubernetes.io/zone":    "us-east-1a",
+	})
+	if expected := map[string]string{"example.com/team": "a", "topology.kubernetes.io/zone": "us-east-1a"}; !reflect.DeepEqual(allowed, expected) {
+		t.Fatalf("expected allowed labels %v, received %v", expected, allowed)
+	}
+	if expected := map[string]string{"node-role.kubernetes.io/worker": ""}; !reflect.DeepEqual(restricted, expected) {
+		t.Fatalf("expected restricted labels %v, received %v", expected, restricted)
+	}
+	if expected, got := "example.com/team=a,topology.kubernetes.io/zone=us-east-1a", FormatLabels(allowed); got != expected {
+		t.Fatalf("expected %q, received %q", expected, got)
+	}
+}
@@ -54,3 +54,51 @@ func NewDefaultEncoderConfig() zapcore.EncoderConfig {
 		EncodeName:     CapitalColorFullNameEncoder,
 	}
 }
+
+// NewJSONEncoderConfig returns the encoder config used for the json format.
+// The keys and values are kept stable, since they are parsed by other
+// systems.
+func NewJSONEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		TimeKey:        "ts",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		MessageKey:     "msg",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+		EncodeName:     zapcore.FullNameEncoder,
+	}
+}
+
+// NewConsoleEncoderConfig returns the encoder config used for the console
+// format.
+func NewConsoleEncoderConfig() zapcore.EncoderConfig {
+	cfg := NewJSONEncoderConfig()
+	cfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	return cfg
+}
+
+func newEncoder(format string, color bool) zapcore.Encoder {
+	switch format {
+	case FormatJSON:
+		return zapcore.NewJSONEncoder(NewJSONEncoderConfig())
+	case FormatConsole:
+		cfg := NewConsoleEncoderConfig()
+		if !color {
+			cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		}
+		return zapcore.NewConsoleEncoder(cfg)
+	}
+	cfg := NewDefaultEncoderConfig()
+	if !color {
+		cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		cfg.EncodeTime = EpochTimeEncoder
+		cfg.EncodeName = CapitalFullNameEncoder
+	}
+	return NewEncoder(cfg)
+}
@@ -0,0 +1,41 @@
+// Package echolog provides echo middleware that logs requests with the
+// package logger, so that request logs are written in the configured log
+// format.
+package echolog
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.uber.org/zap"
+
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+// Logger returns middleware that logs each request that is not skipped.
+func Logger(skipper middleware.Skipper) echo.MiddlewareFunc {
+	if skipper == nil {
+		skipper = middleware.DefaultSkipper
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper(c) {
+				return next(c)
+			}
+			start := time.Now()
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+			req, res := c.Request(), c.Response()
+			log.Info("request",
+				zap.String("method", req.Method),
+				zap.String("uri", req.RequestURI),
+				zap.Int("status", res.Status),
+				zap.String("remote_ip", c.RealIP()),
+				zap.Duration("latency", time.Since(start)),
+			)
+			return nil
+		}
+	}
+}
@@ -3,6 +3,8 @@ package log
 import (
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -11,23 +13,89 @@ import (
 var (
 	level = zap.NewAtomicLevel()
 
-	log = zap.New(zapcore.NewCore(
-		NewEncoder(NewDefaultEncoderConfig()),
-		zapcore.AddSync(os.Stderr),
-		level,
-	), zap.AddCaller(), zap.AddCallerSkip(1))
+	mu     sync.Mutex
+	format = FormatLogfmt
+	sinks  = []sink{{ws: zapcore.AddSync(os.Stderr), color: true}}
+	file   *rotatingFile
+
+	// log holds the current *zap.Logger, which is replaced by Configure and
+	// With.
+	log atomic.Value
 )
 
+func init() {
+	log.Store(zap.New(newCore(level, ""), zap.AddCaller(), zap.AddCallerSkip(1)))
+}
+
+// sink is a destination for log entries. Colors are only used for sinks
+// that are read by a person, such as stderr.
+type sink struct {
+	ws    zapcore.WriteSyncer
+	color bool
+}
+
+func newCore(lvl zapcore.LevelEnabler, ns string) zapcore.Core {
+	mu.Lock()
+	defer mu.Unlock()
+
+	cores := make([]zapcore.Core, 0, len(sinks))
+	for _, s := range sinks {
+		encoder := newEncoder(format, s.color)
+		if ns != "" {
+			encoder.OpenNamespace(ns)
+		}
+		cores = append(cores, zapcore.NewCore(encoder, s.ws, lvl))
+	}
+	return zapcore.NewTee(cores...)
+}
+
+func logger() *zap.Logger {
+	return log.Load().(*zap.Logger)
+}
+
+// Configure sets the format and destinations of the package logger. Entries
+// are always written to stderr, and additionally to a rotated log file when
+// Options.File is set.
+func Configure(o *Options) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	mu.Lock()
+	newSinks := []sink{{ws: zapcore.AddSync(os.Stderr), color: true}}
+	var f *rotatingFile
+	if o.File != "" {
+		var err error
+		f, err = openRotatingFile(o.File, int64(o.MaxSize)*1024*1024, o.MaxBackups)
+		if err != nil {
+			mu.Unlock()
+			return err
+		}
+		newSinks = append(newSinks, sink{ws: f})
+	}
+	if file != nil {
+		file.Close()
+	}
+	format, sinks, file = o.Format, newSinks, f
+	mu.Unlock()
+
+	log.Store(zap.New(newCore(level, ""), zap.AddCaller(), zap.AddCallerSkip(1)))
+	return nil
+}
+
+// With adds fields to every entry written by the package logger, until the
+// returned function is called to restore the previous logger.
+func With(fields ...zapcore.Field) (restore func()) {
+	prev := logger()
+	log.Store(prev.With(fields...))
+	return func() {
+		log.Store(prev)
+	}
+}
+
 // NewLogger creates a new child logger with the provided namespace.
 func NewLogger(ns string) *zap.Logger {
-	encoder := NewEncoder(NewDefaultEncoderConfig())
-	encoder.OpenNamespace(ns)
-	return log.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
-		return zapcore.NewCore(
-			encoder,
-			zapcore.AddSync(os.Stderr),
-			level,
-		)
+	return logger().WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return newCore(level, ns)
 	}), zap.AddCaller())
 }
 
@@ -35,14 +103,8 @@ func NewLogger(ns string) *zap.Logger {
 // and level. Since this specifies a level, it overrides the global package
 // level for this child logger only.
 func NewLoggerWithLevel(ns string, lvl zapcore.Level) *zap.Logger {
-	encoder := NewEncoder(NewDefaultEncoderConfig())
-	encoder.OpenNamespace(ns)
-	return log.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
-		return zapcore.NewCore(
-			encoder,
-			zapcore.AddSync(os.Stderr),
-			lvl,
-		)
+	return logger().WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return newCore(lvl, ns)
 	}))
 }
 
@@ -55,48 +117,48 @@ func SetLevel(lvl zapcore.Level) {
 }
 
 func Debug(msg string, fields ...zapcore.Field) {
-	log.Debug(msg, fields...)
+	logger().Debug(msg, fields...)
 }
 
 func Debugf(format string, args ...interface{}) {
-	log.Debug(fmt.Sprintf(format, args...))
+	logger().Debug(fmt.Sprintf(format, args...))
 }
 
 func Info(msg string, fields ...zapcore.Field) {
-	log.Info(msg, fields...)
+	logger().Info(msg, fields...)
 }
 
 func Infof(format string, args ...interface{}) {
-	log.Info(fmt.Sprintf(format, args...))
+	logger().Info(fmt.Sprintf(format, args...))
 }
 
 func Warn(msg string, fields ...zapcore.Field) {
-	log.Warn(msg, fields...)
+	logger().Warn(msg, fields...)
 }
 
 func Warnf(format string, args ...interface{}) {
-	log.Warn(fmt.Sprintf(format, args...))
+	logger().Warn(fmt.Sprintf(format, args...))
 }
 
 func Error(msg string, fields ...zapcore.Field) {
-	log.Error(msg, fields...)
+	logger().Error(msg, fields...)
 }
 
 func Errorf(format string, args ...interface{}) {
-	log.Error(fmt.Sprintf(format, args...))
+	logger().Error(fmt.Sprintf(format, args...))
 }
 
 func Fatal(msg interface{}, fields ...zapcore.Field) {
 	switch t := msg.(type) {
 	case string:
-		log.Fatal(t, fields...)
+		logger().Fatal(t, fields...)
 	case error:
-		log.Fatal(t.Error(), fields...)
+		logger().Fatal(t.Error(), fields...)
 	default:
-		log.Fatal(fmt.Sprintf("%+v", msg), fields...)
+		logger().Fatal(fmt.Sprintf("%+v", msg), fields...)
 	}
 }
 
 func Fatalf(format string, args ...interface{}) {
-	log.Fatal(fmt.Sprintf(format, args...))
+	logger().Fatal(fmt.Sprintf(format, args...))
 }
@@ -0,0 +1,60 @@
+package log
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// FormatLogfmt writes entries as key=value pairs.
+	FormatLogfmt = "logfmt"
+
+	// FormatJSON writes entries as JSON objects, one per line.
+	FormatJSON = "json"
+
+	// FormatConsole writes entries in the human-readable format of the zap
+	// console encoder.
+	FormatConsole = "console"
+)
+
+// Formats are the supported log formats.
+var Formats = []string{FormatLogfmt, FormatJSON, FormatConsole}
+
+// Options are the options for the package logger that are shared by
+// commands.
+type Options struct {
+	Format string
+
+	// File is the path of a log file that entries are written to in
+	// addition to stderr.
+	File string
+
+	// MaxSize is the size, in megabytes, that the log file is rotated at.
+	MaxSize int
+
+	// MaxBackups is the number of rotated log files to keep.
+	MaxBackups int
+}
+
+// AddFlags adds the logging flags to a flag set.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Format, "log-format", FormatLogfmt, "log output format, one of logfmt, json or console")
+	fs.StringVar(&o.File, "log-file", "", "write logs to a file in addition to stderr")
+	fs.IntVar(&o.MaxSize, "log-file-max-size", 100, "size in megabytes at which the log file is rotated")
+	fs.IntVar(&o.MaxBackups, "log-file-max-backups", 5, "number of rotated log files to keep")
+}
+
+func (o *Options) Validate() error {
+	switch o.Format {
+	case FormatLogfmt, FormatJSON, FormatConsole:
+	default:
+		return errors.Errorf("invalid log format %q, must be one of %v", o.Format, Formats)
+	}
+	if o.File != "" && o.MaxSize <= 0 {
+		return errors.Errorf("log file max size must be greater than 0, received %d", o.MaxSize)
+	}
+	if o.MaxBackups < 0 {
+		return errors.Errorf("log file max backups cannot be negative, received %d", o.MaxBackups)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// rotatingFile is a log file that is rotated once writing to it would exceed
+// maxSize. Rotated files are renamed with a numeric suffix, so that
+// crit.log.1 is the most recent, and only maxBackups of them are kept.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	r := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrap(err, "cannot open log file")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.f == nil {
+		return 0, errors.New("log file is closed")
+	}
+	var rotateErr error
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if rotateErr = r.rotate(); rotateErr != nil {
+			if r.f == nil {
+				return 0, rotateErr
+			}
+
+			// the entry is still written to the reopened log file, and
+			// rotating is only attempted again once another maxSize bytes
+			// have been written, rather than failing every write
+			r.size = 0
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// rotate renames the log file and its backups, then opens a new log file.
+// The log file is reopened even when renaming fails, so that a failed
+// rotation does not prevent later writes.
+func (r *rotatingFile) rotate() error {
+	closeErr := r.f.Close()
+	r.f = nil
+	err := r.renameBackups()
+	if openErr := r.open(); openErr != nil {
+		return openErr
+	}
+	if err == nil {
+		err = closeErr
+	}
+	return errors.Wrap(err, "cannot rotate log file")
+}
+
+func (r *rotatingFile) renameBackups() error {
+	backup := func(i int) string {
+		return fmt.Sprintf("%s.%d", r.path, i)
+	}
+	if r.maxBackups == 0 {
+		return os.Remove(r.path)
+	}
+	if err := os.Remove(backup(r.maxBackups)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := r.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(backup(i), backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(r.path, backup(1))
+}
+
+func (r *rotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.f == nil {
+		return nil
+	}
+	return r.f.Sync()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
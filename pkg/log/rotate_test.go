@@ -0,0 +1,113 @@
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func writeLines(t *testing.T, r *rotatingFile, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "crit.log")
+	r, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	writeLines(t, r, "line one\n", "line two\n", "line 3\n", "line four\n")
+	expected := map[string]string{
+		path:        "line four\n",
+		path + ".1": "line 3\n",
+		path + ".2": "line two\n",
+	}
+	for p, data := range expected {
+		if s := readFile(t, p); s != data {
+			t.Errorf("expected %s to contain %q, received %q", p, data, s)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected only 2 backups to be kept, received %v", err)
+	}
+}
+
+func TestRotatingFileNoBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "crit.log")
+	r, err := openRotatingFile(path, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	writeLines(t, r, "line one\n", "line two\n")
+	if s := readFile(t, path); s != "line two\n" {
+		t.Errorf("expected log file to be truncated, received %q", s)
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Errorf("expected no backups, received %v", err)
+	}
+}
+
+func TestRotatingFileRotateFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "crit.log")
+	r, err := openRotatingFile(path, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// a non-empty directory in place of the backup cannot be removed or
+	// replaced, so rotating fails
+	if err := os.MkdirAll(filepath.Join(path+".1", "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeLines(t, r, "line one\n")
+	for _, line := range []string{"line two\n", "line 3\n"} {
+		n, err := r.Write([]byte(line))
+		if err == nil {
+			t.Fatal("expected rotate error")
+		}
+		if n != len(line) {
+			t.Fatalf("expected %d bytes to be written, received %d", len(line), n)
+		}
+	}
+	if s := readFile(t, path); s != "line one\nline two\nline 3\n" {
+		t.Errorf("expected writes to continue after a failed rotate, received %q", s)
+	}
+}
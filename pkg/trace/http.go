@@ -0,0 +1,44 @@
+package trace
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// NewTransport returns a RoundTripper that records a client span for each
+// request and propagates the trace to the server with the W3C traceparent
+// header. It can be used to wrap the transport of a Kubernetes rest.Config.
+func NewTransport(rt http.RoundTripper) http.RoundTripper {
+	return &transport{rt: rt}
+}
+
+type transport struct {
+	rt http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Start(req.Context(), fmt.Sprintf("HTTP %s", req.Method), SpanKindClient,
+		String("http.method", req.Method),
+		String("http.url", req.URL.String()),
+	)
+	if span == nil {
+		return t.rt.RoundTrip(req)
+	}
+	defer span.Finish()
+
+	// the request must not be modified, so the header is set on a copy
+	req = req.Clone(ctx)
+	req.Header.Set("traceparent", fmt.Sprintf("00-%s-%s-01", span.TraceID, span.SpanID))
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+	span.SetAttributes(Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetError(errors.New(resp.Status))
+	}
+	return resp, nil
+}
@@ -0,0 +1,145 @@
+package trace
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+
+	"github.com/criticalstack/crit/internal/buildinfo"
+	"github.com/criticalstack/crit/pkg/log"
+)
+
+// Exporter sends finished spans to a tracing backend.
+type Exporter interface {
+	Export(ctx context.Context, resource []Attribute, spans []*Span) error
+	Close() error
+}
+
+// maxQueuedSpans is the number of finished spans that are queued before
+// they are exported, rather than waiting for Shutdown.
+const maxQueuedSpans = 512
+
+type provider struct {
+	mu       sync.Mutex
+	exporter Exporter
+	resource []Attribute
+	queue    []*Span
+	wg       sync.WaitGroup
+}
+
+var defaultProvider = &provider{}
+
+// Enabled returns true if an exporter has been configured.
+func Enabled() bool {
+	defaultProvider.mu.Lock()
+	defer defaultProvider.mu.Unlock()
+
+	return defaultProvider.exporter != nil
+}
+
+func (p *provider) add(s *Span) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.exporter == nil {
+		return
+	}
+	p.queue = append(p.queue, s)
+	if len(p.queue) < maxQueuedSpans {
+		return
+	}
+	e, resource, spans := p.exporter, p.resource, p.queue
+	p.queue = nil
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		if err := e.Export(context.Background(), resource, spans); err != nil {
+			log.Warn("cannot export spans", zap.Error(err))
+		}
+	}()
+}
+
+// Options configure where spans are exported to.
+type Options struct {
+	// Endpoint is the URL of an OTLP/HTTP receiver, such as
+	// http://localhost:4318. The /v1/traces path is used when the URL has
+	// no path.
+	Endpoint string
+
+	// File is the path of a file that spans are appended to, as one OTLP
+	// JSON export request per line.
+	File string
+
+	// ServiceName is the service.name resource attribute of the spans.
+	ServiceName string
+}
+
+// AddFlags adds the tracing flags to a flag set.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Endpoint, "trace-endpoint", "", "export trace spans to this OTLP/HTTP endpoint (e.g. http://localhost:4318)")
+	fs.StringVar(&o.File, "trace-file", "", "write trace spans to this file as OTLP JSON")
+}
+
+// Configure sets the exporter for spans. Tracing stays disabled when neither
+// an endpoint or file is provided.
+func Configure(o *Options) error {
+	var e Exporter
+	switch {
+	case o.Endpoint != "" && o.File != "":
+		return errors.New("cannot specify both a trace endpoint and a trace file")
+	case o.Endpoint != "":
+		var err error
+		e, err = NewHTTPExporter(o.Endpoint)
+		if err != nil {
+			return err
+		}
+	case o.File != "":
+		var err error
+		e, err = NewFileExporter(o.File)
+		if err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	resource := []Attribute{String("service.name", o.ServiceName)}
+	if buildinfo.Version != "" {
+		resource = append(resource, String("service.version", buildinfo.Version))
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		resource = append(resource, String("host.name", hostname))
+	}
+	defaultProvider.mu.Lock()
+	defer defaultProvider.mu.Unlock()
+
+	defaultProvider.exporter = e
+	defaultProvider.resource = resource
+	return nil
+}
+
+// Shutdown exports the queued spans and closes the exporter, after which
+// tracing is disabled. It should be called before the program exits, since
+// spans are otherwise only exported in batches.
+func Shutdown(ctx context.Context) error {
+	p := defaultProvider
+	p.mu.Lock()
+	e, resource, spans := p.exporter, p.resource, p.queue
+	p.exporter, p.queue = nil, nil
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	if e == nil {
+		return nil
+	}
+	defer e.Close()
+
+	if len(spans) == 0 {
+		return nil
+	}
+	return e.Export(ctx, resource, spans)
+}
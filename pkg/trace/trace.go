@@ -0,0 +1,152 @@
+// Package trace records spans for the steps of bootstrapping a node and the
+// calls made to etcd, the container runtime and the Kubernetes API. Spans are
+// exported in the OpenTelemetry protocol (OTLP) JSON encoding, either to an
+// OTLP/HTTP endpoint or to a local file. Spans are only recorded once an
+// exporter has been configured.
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+type (
+	TraceID [16]byte
+	SpanID  [8]byte
+)
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+func (s SpanID) String() string  { return hex.EncodeToString(s[:]) }
+
+func (s SpanID) IsZero() bool { return s == SpanID{} }
+
+// SpanKind describes the relationship of a span to the operation it
+// represents, using the values of the OTLP Span.SpanKind enum.
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindClient   SpanKind = 3
+)
+
+// StatusCode is the status of a span, using the values of the OTLP
+// Status.StatusCode enum.
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusOK    StatusCode = 1
+	StatusError StatusCode = 2
+)
+
+// Attribute is a key/value pair describing a span. Values are strings,
+// int64, float64 or bool.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is a timed operation. A nil *Span is valid and does nothing, which is
+// what Start returns when tracing is disabled.
+type Span struct {
+	mu sync.Mutex
+
+	TraceID    TraceID
+	SpanID     SpanID
+	ParentID   SpanID
+	Name       string
+	Kind       SpanKind
+	Start      time.Time
+	End        time.Time
+	Attributes []Attribute
+	Status     StatusCode
+	Message    string
+
+	ended bool
+}
+
+type spanKey struct{}
+
+// FromContext returns the span of a context, or nil if there is none.
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// Start starts a new span, which is a child of the span of the context if
+// there is one. The returned context contains the new span.
+func Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+	s := &Span{
+		Name:       name,
+		Kind:       kind,
+		Start:      time.Now(),
+		Attributes: attrs,
+	}
+	if parent := FromContext(ctx); parent != nil {
+		s.TraceID = parent.TraceID
+		s.ParentID = parent.SpanID
+	} else {
+		_, _ = rand.Read(s.TraceID[:])
+	}
+	_, _ = rand.Read(s.SpanID[:])
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Attributes = append(s.Attributes, attrs...)
+}
+
+// SetError sets the status of the span to an error, if err is not nil.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Status = StatusError
+	s.Message = err.Error()
+}
+
+// Finish ends the span and queues it to be exported. Spans are only exported
+// once, so calling Finish again has no effect.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	s.mu.Unlock()
+
+	defaultProvider.add(s)
+}
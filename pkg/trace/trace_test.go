@@ -0,0 +1,100 @@
+package trace_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/criticalstack/crit/pkg/trace"
+)
+
+func TestTransport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(dir, "trace.json")
+	if err := trace.Configure(&trace.Options{File: path, ServiceName: "crit"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, root := trace.Start(context.Background(), "root", trace.SpanKindInternal)
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/nodes", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: trace.NewTransport(http.DefaultTransport)}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	root.Finish()
+	if err := trace.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if trace.Enabled() {
+		t.Fatal("expected tracing to be disabled after shutdown")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string `json:"traceId"`
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+					Status       struct {
+						Code int `json:"code"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	spans := result.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, received %d", len(spans))
+	}
+	httpSpan, rootSpan := spans[0], spans[1]
+	if httpSpan.Name != "HTTP GET" || httpSpan.ParentSpanID != rootSpan.SpanID || httpSpan.TraceID != rootSpan.TraceID {
+		t.Errorf("expected HTTP GET span to be a child of root, received %+v", httpSpan)
+	}
+	if httpSpan.Status.Code != int(trace.StatusError) {
+		t.Errorf("expected error status for 404 response, received %d", httpSpan.Status.Code)
+	}
+	expected := fmt.Sprintf("00-%s-%s-01", httpSpan.TraceID, httpSpan.SpanID)
+	if traceparent != expected {
+		t.Errorf("expected traceparent %q, received %q", expected, traceparent)
+	}
+}
+
+func TestStartDisabled(t *testing.T) {
+	ctx, span := trace.Start(context.Background(), "step", trace.SpanKindInternal)
+	if span != nil || trace.FromContext(ctx) != nil {
+		t.Fatal("expected no span when tracing is disabled")
+	}
+	span.SetError(fmt.Errorf("error"))
+	span.Finish()
+}
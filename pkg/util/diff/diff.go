@@ -0,0 +1,190 @@
+// Package diff compares objects field by field, rather than line by line, so
+// that differences in formatting or ordering are not reported.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Change is a field that differs between two objects. Old is nil for fields
+// that were added and New is nil for fields that were removed.
+type Change struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+func (c Change) String() string {
+	path := c.Path
+	if path == "" {
+		path = "<root>"
+	}
+	switch {
+	case c.Old == nil:
+		return fmt.Sprintf("%s: added %s", path, formatValue(c.New))
+	case c.New == nil:
+		return fmt.Sprintf("%s: removed %s", path, formatValue(c.Old))
+	}
+	return fmt.Sprintf("%s: %s -> %s", path, formatValue(c.Old), formatValue(c.New))
+}
+
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// Objects returns the changes between the JSON representation of two
+// objects.
+func Objects(a, b interface{}) ([]Change, error) {
+	av, err := ToValue(a)
+	if err != nil {
+		return nil, err
+	}
+	bv, err := ToValue(b)
+	if err != nil {
+		return nil, err
+	}
+	return Values(av, bv), nil
+}
+
+// ToValue converts an object to the generic value of its JSON
+// representation, made of maps, lists and scalars.
+func ToValue(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Values returns the changes between two generic values, sorted by path.
+// Lists of objects that all have a name, such as containers or volumes, are
+// matched by name rather than position, and their items are referred to as
+// list[name]. Empty maps and lists are treated the same as a missing field.
+func Values(a, b interface{}) []Change {
+	changes := make([]Change, 0)
+	compare(&changes, "", a, b)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func compare(changes *[]Change, path string, a, b interface{}) {
+	a, b = normalize(a), normalize(b)
+	switch {
+	case a == nil && b == nil:
+		return
+	case a == nil || b == nil:
+		*changes = append(*changes, Change{Path: path, Old: a, New: b})
+		return
+	}
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make(map[string]struct{})
+		for k := range av {
+			keys[k] = struct{}{}
+		}
+		for k := range bv {
+			keys[k] = struct{}{}
+		}
+		for k := range keys {
+			compare(changes, JoinPath(path, k), av[k], bv[k])
+		}
+		return
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		an, aok := byName(av)
+		bn, bok := byName(bv)
+		if aok && bok {
+			for k := range an {
+				compare(changes, fmt.Sprintf("%s[%s]", path, k), an[k], bn[k])
+			}
+			for k := range bn {
+				if _, ok := an[k]; !ok {
+					compare(changes, fmt.Sprintf("%s[%s]", path, k), nil, bn[k])
+				}
+			}
+			return
+		}
+		for i := 0; i < len(av) || i < len(bv); i++ {
+			var ai, bi interface{}
+			if i < len(av) {
+				ai = av[i]
+			}
+			if i < len(bv) {
+				bi = bv[i]
+			}
+			compare(changes, fmt.Sprintf("%s[%d]", path, i), ai, bi)
+		}
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, Change{Path: path, Old: a, New: b})
+	}
+}
+
+func normalize(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			return nil
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			return nil
+		}
+	}
+	return v
+}
+
+// byName returns the items of a list keyed by their name, if every item is
+// an object with a unique name.
+func byName(items []interface{}) (map[string]interface{}, bool) {
+	m := make(map[string]interface{})
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := obj["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := m[name]; ok {
+			return nil, false
+		}
+		m[name] = obj
+	}
+	return m, true
+}
+
+// JoinPath adds a key to a field path. Keys containing dots are quoted, so
+// that labels such as "example.com/role" are not mistaken for nested fields.
+func JoinPath(path, key string) string {
+	if strings.ContainsAny(key, ". ") {
+		key = fmt.Sprintf("%q", key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
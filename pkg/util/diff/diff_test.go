@@ -0,0 +1,66 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/criticalstack/crit/pkg/util/diff"
+)
+
+func TestValues(t *testing.T) {
+	a := map[string]interface{}{
+		"clusterName": "crit",
+		"labels": map[string]interface{}{
+			"example.com/role": "a",
+		},
+		"containers": []interface{}{
+			map[string]interface{}{"name": "a", "image": "a:1"},
+			map[string]interface{}{"name": "b", "image": "b:1"},
+		},
+		"subnets":     []interface{}{"10.0.0.0/16"},
+		"extraArgs":   map[string]interface{}{},
+		"featureGate": true,
+	}
+	b := map[string]interface{}{
+		"clusterName": "crit",
+		"labels": map[string]interface{}{
+			"example.com/role": "b",
+		},
+		"containers": []interface{}{
+			map[string]interface{}{"name": "b", "image": "b:2"},
+			map[string]interface{}{"name": "a", "image": "a:1"},
+		},
+		"subnets": []interface{}{"10.0.0.0/16", "fd00::/64"},
+		"port":    6443.0,
+	}
+	expected := []string{
+		`containers[b].image: "b:1" -> "b:2"`,
+		`featureGate: removed true`,
+		`labels."example.com/role": "a" -> "b"`,
+		`port: added 6443`,
+		`subnets[1]: added "fd00::/64"`,
+	}
+	changes := diff.Values(a, b)
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, received %v", len(expected), changes)
+	}
+	for i, c := range changes {
+		if c.String() != expected[i] {
+			t.Errorf("expected %q, received %q", expected[i], c)
+		}
+	}
+}
+
+func TestObjects(t *testing.T) {
+	type obj struct {
+		Name  string            `json:"name"`
+		Args  map[string]string `json:"args,omitempty"`
+		Ports []int             `json:"ports,omitempty"`
+	}
+	changes, err := diff.Objects(obj{Name: "a", Ports: []int{1}}, obj{Name: "a", Ports: []int{1}, Args: map[string]string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes, received %v", changes)
+	}
+}
@@ -0,0 +1,315 @@
+package net
+
+import (
+	"bufio"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	procNetRoute     = "/proc/net/route"
+	procNetIPv6Route = "/proc/net/ipv6_route"
+	sysVirtualNet    = "/sys/devices/virtual/net"
+)
+
+// Interface is a network interface that is up and is not a loopback device,
+// along with its assigned addresses.
+type Interface struct {
+	Name  string
+	Addrs []net.IP
+}
+
+// Virtual checks if the network interface is a virtual device, such as a
+// bridge, veth pair or tunnel, rather than a physical network adapter.
+func (i Interface) Virtual() bool {
+	_, err := os.Stat(filepath.Join(sysVirtualNet, i.Name))
+	return err == nil
+}
+
+func (i Interface) ip(ipv6 bool) net.IP {
+	for _, ip := range i.Addrs {
+		if matchesFamily(ip, ipv6) {
+			return ip
+		}
+	}
+	return nil
+}
+
+func matchesFamily(ip net.IP, ipv6 bool) bool {
+	if ipv6 {
+		return ip.To4() == nil && ip.IsGlobalUnicast()
+	}
+	return ip.To4() != nil
+}
+
+var listInterfaces = func() ([]Interface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	result := make([]Interface, 0)
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		i := Interface{Name: iface.Name}
+		for _, a := range addrs {
+			if ipnet, ok := a.(*net.IPNet); ok {
+				i.Addrs = append(i.Addrs, ipnet.IP)
+			}
+		}
+		result = append(result, i)
+	}
+	return result, nil
+}
+
+// HostInterfaces returns the network interfaces of the host, optionally
+// excluding virtual devices.
+func HostInterfaces(excludeVirtual bool) ([]Interface, error) {
+	ifaces, err := listInterfaces()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Interface, 0)
+	for _, iface := range ifaces {
+		if excludeVirtual && iface.Virtual() {
+			continue
+		}
+		result = append(result, iface)
+	}
+	return result, nil
+}
+
+// FirstInterfaceIP returns the address of the first network interface with an
+// address of the requested IP family.
+func FirstInterfaceIP(ipv6, excludeVirtual bool) (string, string, error) {
+	ifaces, err := HostInterfaces(excludeVirtual)
+	if err != nil {
+		return "", "", err
+	}
+	for _, iface := range ifaces {
+		if ip := iface.ip(ipv6); ip != nil {
+			return ip.String(), iface.Name, nil
+		}
+	}
+	return "", "", errors.Errorf("cannot find a network interface with an %s address", familyName(ipv6))
+}
+
+// InterfaceIP returns the address of the requested IP family for the named
+// network interface.
+func InterfaceIP(name string, ipv6 bool) (string, error) {
+	ifaces, err := HostInterfaces(false)
+	if err != nil {
+		return "", err
+	}
+	for _, iface := range ifaces {
+		if iface.Name != name {
+			continue
+		}
+		if ip := iface.ip(ipv6); ip != nil {
+			return ip.String(), nil
+		}
+		return "", errors.Errorf("network interface %q does not have an %s address", name, familyName(ipv6))
+	}
+	return "", errors.Errorf("cannot find network interface %q", name)
+}
+
+// CIDRInterfaceIP returns the first address of the requested IP family that
+// is contained by any of the provided CIDR ranges.
+func CIDRInterfaceIP(cidrs []string, ipv6, excludeVirtual bool) (string, string, error) {
+	subnets := make([]*net.IPNet, 0)
+	for _, cidr := range cidrs {
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "invalid CIDR %q", cidr)
+		}
+		subnets = append(subnets, subnet)
+	}
+	ifaces, err := HostInterfaces(excludeVirtual)
+	if err != nil {
+		return "", "", err
+	}
+	for _, iface := range ifaces {
+		for _, ip := range iface.Addrs {
+			if !matchesFamily(ip, ipv6) {
+				continue
+			}
+			for _, subnet := range subnets {
+				if subnet.Contains(ip) {
+					return ip.String(), iface.Name, nil
+				}
+			}
+		}
+	}
+	return "", "", errors.Errorf("cannot find an %s address within %v", familyName(ipv6), cidrs)
+}
+
+// DefaultRouteIP returns the address of the requested IP family for the
+// network interface holding the default route. When there are multiple
+// default routes, the one with the lowest metric is used.
+func DefaultRouteIP(ipv6, excludeVirtual bool) (string, string, error) {
+	name, err := DefaultRouteInterface(ipv6)
+	if err != nil {
+		return "", "", err
+	}
+	ifaces, err := HostInterfaces(excludeVirtual)
+	if err != nil {
+		return "", "", err
+	}
+	for _, iface := range ifaces {
+		if iface.Name != name {
+			continue
+		}
+		if ip := iface.ip(ipv6); ip != nil {
+			return ip.String(), iface.Name, nil
+		}
+	}
+	return "", "", errors.Errorf("cannot find an %s address for default route interface %q", familyName(ipv6), name)
+}
+
+// DefaultRouteInterface returns the name of the network interface holding the
+// default route for the requested IP family.
+func DefaultRouteInterface(ipv6 bool) (string, error) {
+	if ipv6 {
+		return defaultRouteInterface(procNetIPv6Route, parseIPv6Route)
+	}
+	return defaultRouteInterface(procNetRoute, parseIPv4Route)
+}
+
+type route struct {
+	iface   string
+	metric  uint64
+	isValid bool
+}
+
+const (
+	rtfUp     = 0x0001
+	rtfReject = 0x0200
+)
+
+func defaultRouteInterface(path string, parse func([]string) route) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer f.Close()
+
+	var best *route
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		r := parse(strings.Fields(s.Text()))
+		if !r.isValid {
+			continue
+		}
+		if best == nil || r.metric < best.metric {
+			best = &r
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", errors.WithStack(err)
+	}
+	if best == nil {
+		return "", errors.Errorf("cannot find default route in %s", path)
+	}
+	return best.iface, nil
+}
+
+// parseIPv4Route parses a line of /proc/net/route, which has the format:
+// Iface Destination Gateway Flags RefCnt Use Metric Mask MTU Window IRTT
+func parseIPv4Route(fields []string) route {
+	if len(fields) < 8 || fields[0] == "Iface" {
+		return route{}
+	}
+	flags, err := strconv.ParseUint(fields[3], 16, 32)
+	if err != nil || flags&rtfUp == 0 {
+		return route{}
+	}
+	if fields[1] != "00000000" || fields[7] != "00000000" {
+		return route{}
+	}
+	metric, err := strconv.ParseUint(fields[6], 10, 64)
+	if err != nil {
+		return route{}
+	}
+	return route{iface: fields[0], metric: metric, isValid: true}
+}
+
+// parseIPv6Route parses a line of /proc/net/ipv6_route, which has the
+// format: Destination DestPrefixLen Source SourcePrefixLen NextHop Metric
+// RefCnt Use Flags Iface
+func parseIPv6Route(fields []string) route {
+	if len(fields) < 10 {
+		return route{}
+	}
+	dst, err := hex.DecodeString(fields[0])
+	if err != nil || !net.IP(dst).IsUnspecified() || fields[1] != "00" {
+		return route{}
+	}
+	flags, err := strconv.ParseUint(fields[8], 16, 32)
+	if err != nil || flags&rtfUp == 0 || flags&rtfReject != 0 {
+		return route{}
+	}
+	if fields[9] == "lo" {
+		return route{}
+	}
+	metric, err := strconv.ParseUint(fields[5], 16, 64)
+	if err != nil {
+		return route{}
+	}
+	return route{iface: fields[9], metric: metric, isValid: true}
+}
+
+// SourceIPFor returns the local address of the requested IP family the host
+// would use to reach the provided address, according to the routing table.
+// No packets are sent.
+func SourceIPFor(host string, port int32, ipv6 bool) (string, error) {
+	conn, err := net.Dial(udpNetwork(ipv6), net.JoinHostPort(host, strconv.Itoa(int(port))))
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP.String(), nil
+}
+
+// IsRoutable checks that the host has a route from the local address to the
+// provided address. No packets are sent.
+func IsRoutable(local, host string, port int32) error {
+	laddr := &net.UDPAddr{IP: net.ParseIP(local)}
+	if laddr.IP == nil {
+		return errors.Errorf("invalid local address: %q", local)
+	}
+	network := udpNetwork(laddr.IP.To4() == nil)
+	raddr, err := net.ResolveUDPAddr(network, net.JoinHostPort(host, strconv.Itoa(int(port))))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	conn, err := net.DialUDP(network, laddr, raddr)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return conn.Close()
+}
+
+func udpNetwork(ipv6 bool) string {
+	if ipv6 {
+		return "udp6"
+	}
+	return "udp4"
+}
+
+func familyName(ipv6 bool) string {
+	if ipv6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
@@ -0,0 +1,149 @@
+package net
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	testRoute = `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+docker0	000011AC	00000000	0001	0	0	0	0000FFFF	0	0	0
+eth1	00000000	0101A8C0	0003	0	0	200	00000000	0	0	0
+eth0	00000000	010010AC	0003	0	0	100	00000000	0	0	0
+eth0	000010AC	00000000	0001	0	0	100	0000FFFF	0	0	0
+`
+	testIPv6Route = `00000000000000000000000000000000 00 00000000000000000000000000000000 00 00000000000000000000000000000000 ffffffff 00000001 00000000 00200200       lo
+fd000000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001     eth1
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 fd000000000000000000000000000001 00000400 00000001 00000000 00000003     eth1
+`
+)
+
+func setupTestHost(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "addr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"proc/net/route":      testRoute,
+		"proc/net/ipv6_route": testIPv6Route,
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "sys/devices/virtual/net/docker0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	origRoute, origIPv6Route, origVirtual, origList := procNetRoute, procNetIPv6Route, sysVirtualNet, listInterfaces
+	procNetRoute = filepath.Join(dir, "proc/net/route")
+	procNetIPv6Route = filepath.Join(dir, "proc/net/ipv6_route")
+	sysVirtualNet = filepath.Join(dir, "sys/devices/virtual/net")
+	listInterfaces = func() ([]Interface, error) {
+		return []Interface{
+			{Name: "docker0", Addrs: []net.IP{net.ParseIP("172.17.0.1")}},
+			{Name: "eth0", Addrs: []net.IP{net.ParseIP("172.16.0.10"), net.ParseIP("fe80::1")}},
+			{Name: "eth1", Addrs: []net.IP{net.ParseIP("192.168.1.10"), net.ParseIP("fd00::10")}},
+		}, nil
+	}
+	return func() {
+		procNetRoute, procNetIPv6Route, sysVirtualNet, listInterfaces = origRoute, origIPv6Route, origVirtual, origList
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSelectHostIP(t *testing.T) {
+	cleanup := setupTestHost(t)
+	defer cleanup()
+
+	cases := []struct {
+		name     string
+		selectIP func() (string, error)
+		expected string
+	}{
+		{
+			name: "default route ipv4",
+			selectIP: func() (string, error) {
+				ip, _, err := DefaultRouteIP(false, false)
+				return ip, err
+			},
+			expected: "172.16.0.10",
+		},
+		{
+			name: "default route ipv6",
+			selectIP: func() (string, error) {
+				ip, _, err := DefaultRouteIP(true, false)
+				return ip, err
+			},
+			expected: "fd00::10",
+		},
+		{
+			name: "first interface",
+			selectIP: func() (string, error) {
+				ip, _, err := FirstInterfaceIP(false, false)
+				return ip, err
+			},
+			expected: "172.17.0.1",
+		},
+		{
+			name: "first interface exclude virtual",
+			selectIP: func() (string, error) {
+				ip, _, err := FirstInterfaceIP(false, true)
+				return ip, err
+			},
+			expected: "172.16.0.10",
+		},
+		{
+			name: "interface",
+			selectIP: func() (string, error) {
+				return InterfaceIP("eth1", false)
+			},
+			expected: "192.168.1.10",
+		},
+		{
+			name: "cidr",
+			selectIP: func() (string, error) {
+				ip, _, err := CIDRInterfaceIP([]string{"10.0.0.0/8", "192.168.0.0/16"}, false, false)
+				return ip, err
+			},
+			expected: "192.168.1.10",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ip, err := c.selectIP()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ip != c.expected {
+				t.Fatalf("expected %q, received %q", c.expected, ip)
+			}
+		})
+	}
+}
+
+func TestSelectHostIPErrors(t *testing.T) {
+	cleanup := setupTestHost(t)
+	defer cleanup()
+
+	if _, err := InterfaceIP("eth2", false); err == nil {
+		t.Fatal("expected error for missing interface")
+	}
+	if _, err := InterfaceIP("docker0", true); err == nil {
+		t.Fatal("expected error for interface without an IPv6 address")
+	}
+	if _, _, err := CIDRInterfaceIP([]string{"10.0.0.0/8"}, false, false); err == nil {
+		t.Fatal("expected error for unmatched CIDR")
+	}
+	procNetRoute = filepath.Join(filepath.Dir(procNetRoute), "missing")
+	if _, _, err := DefaultRouteIP(false, false); err == nil {
+		t.Fatal("expected error for missing route table")
+	}
+}
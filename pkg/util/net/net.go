@@ -12,19 +12,17 @@ import (
 // DetectHostIPv4 attempts to determine the host IPv4 address by finding the
 // first non-loopback device with an assigned IPv4 address.
 func DetectHostIPv4() (string, error) {
-	addrs, err := net.InterfaceAddrs()
-	if err != nil {
-		return "", errors.WithStack(err)
-	}
-	for _, a := range addrs {
-		if ipnet, ok := a.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
-			if ipnet.IP.To4() == nil {
-				continue
-			}
-			return ipnet.IP.String(), nil
-		}
-	}
-	return "", errors.New("cannot detect host IPv4 address")
+	ip, _, err := FirstInterfaceIP(false, false)
+	return ip, err
+}
+
+// DetectHostIPv6 attempts to determine the host IPv6 address by finding the
+// first non-loopback device with an assigned global unicast IPv6 address.
+// Link-local addresses are ignored since they cannot be used as node
+// addresses.
+func DetectHostIPv6() (string, error) {
+	ip, _, err := FirstInterfaceIP(true, false)
+	return ip, err
 }
 
 // CalcNodeCidrSize determines the size of the subnets used on each node, based
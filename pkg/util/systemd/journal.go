@@ -0,0 +1,83 @@
+package systemd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// JournalEntry is a single systemd journal entry.
+type JournalEntry struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// journalctlEntry is the subset of fields exported by journalctl in JSON
+// output mode.
+type journalctlEntry struct {
+	RealtimeTimestamp string          `json:"__REALTIME_TIMESTAMP"`
+	Message           json.RawMessage `json:"MESSAGE"`
+}
+
+// JournalTail returns the last lines of the journal for a systemd unit. The
+// sd-journal library requires cgo, which is not available for static builds,
+// so the journal is read using the JSON export of journalctl.
+func JournalTail(ctx context.Context, unit string, lines int) ([]JournalEntry, error) {
+	cmd := exec.CommandContext(ctx, "journalctl", "--unit", unit, "--lines", strconv.Itoa(lines), "--output", "json", "--no-pager")
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, errors.Errorf("journalctl: %s", bytes.TrimSpace(ee.Stderr))
+		}
+		return nil, errors.WithStack(err)
+	}
+	return parseJournal(out)
+}
+
+func parseJournal(data []byte) ([]JournalEntry, error) {
+	entries := make([]JournalEntry, 0)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+	for s.Scan() {
+		if len(bytes.TrimSpace(s.Bytes())) == 0 {
+			continue
+		}
+		var e journalctlEntry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return nil, errors.Wrap(err, "cannot parse journal entry")
+		}
+		entry := JournalEntry{Message: journalMessage(e.Message)}
+		if usec, err := strconv.ParseInt(e.RealtimeTimestamp, 10, 64); err == nil {
+			entry.Time = time.Unix(0, usec*int64(time.Microsecond))
+		}
+		entries = append(entries, entry)
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return entries, nil
+}
+
+// journalMessage decodes the MESSAGE field, which journalctl exports as an
+// array of bytes rather than a string when it is not valid UTF-8.
+func journalMessage(data json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s
+	}
+	var b []int
+	if err := json.Unmarshal(data, &b); err == nil {
+		msg := make([]byte, len(b))
+		for i, c := range b {
+			msg[i] = byte(c)
+		}
+		return string(msg)
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package systemd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseJournal(t *testing.T) {
+	data := []byte(`{"__REALTIME_TIMESTAMP":"1597000000000000","MESSAGE":"Started kubelet: The Kubernetes Node Agent."}
+{"__REALTIME_TIMESTAMP":"1597000001500000","MESSAGE":[102,97,105,108,101,100]}
+
+`)
+	entries, err := parseJournal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []JournalEntry{
+		{Time: time.Unix(1597000000, 0), Message: "Started kubelet: The Kubernetes Node Agent."},
+		{Time: time.Unix(1597000001, 500000000), Message: "failed"},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, received %d", len(expected), len(entries))
+	}
+	for i, e := range expected {
+		if !entries[i].Time.Equal(e.Time) || entries[i].Message != e.Message {
+			t.Errorf("expected %v, received %v", e, entries[i])
+		}
+	}
+}